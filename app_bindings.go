@@ -1,10 +1,20 @@
 package main
 
 import (
+	"context"
 	"d2tool/config"
+	"d2tool/heroes"
+	"d2tool/heroesLayout"
+	"d2tool/history"
+	"d2tool/providers"
 	"d2tool/steam"
+	"d2tool/update"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -14,11 +24,22 @@ import (
 type AppUpdateState struct {
 	CurrentVersion      string `json:"currentVersion"`
 	LatestVersion       string `json:"latestVersion"`
+	UpdatedFromVersion  string `json:"updatedFromVersion"`
+	ReleaseNotes        string `json:"releaseNotes"`
+	ReleaseURL          string `json:"releaseUrl"`
 	LastCheckTimeMillis int64  `json:"lastCheckTimeMillis"`
 	UpdateAvailable     bool   `json:"updateAvailable"`
+	UpdateInstalled     bool   `json:"updateInstalled"`
 	AppDirectory        string `json:"appDirectory"`
 }
 
+// AppUpdateDownloadProgress is the payload of EventAppUpdateDownloadProgress
+type AppUpdateDownloadProgress struct {
+	BytesDone      int64   `json:"bytesDone"`
+	TotalBytes     int64   `json:"totalBytes"`
+	BytesPerSecond float64 `json:"bytesPerSecond"`
+}
+
 // --- Heroes Layout Update ---
 
 // UpdateHeroesLayout performs the hero layout update synchronously
@@ -55,6 +76,337 @@ func (a *App) SetHeroesLayoutFileEnabled(filePath string, enabled bool) {
 	a.config.SetHeroesLayoutFileEnabled(filePath, enabled)
 }
 
+// SetHeroesLayoutFileTemplate selects the layout template used for a file
+func (a *App) SetHeroesLayoutFileTemplate(filePath string, templateID string) {
+	a.config.SetHeroesLayoutFileTemplate(filePath, templateID)
+}
+
+// SetHeroesLayoutFileJobs replaces the generation jobs of a custom file
+func (a *App) SetHeroesLayoutFileJobs(filePath string, jobs []config.GenerationJob) error {
+	return a.config.SetHeroesLayoutFileJobs(filePath, jobs)
+}
+
+// --- Hero Lists Bindings ---
+
+// GetHeroLists returns the excluded, hero pool and pinned hero lists
+func (a *App) GetHeroLists() config.HeroListsConfig {
+	return a.config.GetHeroLists()
+}
+
+// SetHeroLists replaces the excluded, hero pool and pinned hero lists
+func (a *App) SetHeroLists(lists config.HeroListsConfig) {
+	a.config.SetHeroLists(lists)
+}
+
+// GetHeroes returns the metadata of every known hero
+func (a *App) GetHeroes() []heroes.Hero {
+	return a.heroesLayoutService.GetHeroes()
+}
+
+// --- Generation Profile Bindings ---
+
+// GetGenerationProfiles returns the global settings profile and the user-defined profiles
+func (a *App) GetGenerationProfiles() []config.GenerationProfile {
+	return a.config.GetGenerationProfiles()
+}
+
+// SaveGenerationProfile validates and stores a user-defined generation profile
+func (a *App) SaveGenerationProfile(profile config.GenerationProfile) error {
+	if err := a.config.SaveGenerationProfile(profile); err != nil {
+		return fmt.Errorf("invalid generation profile: %w", err)
+	}
+	runtime.EventsEmit(a.ctx, EventHeroesLayoutDataChanged)
+	return nil
+}
+
+// RemoveGenerationProfile removes a user-defined generation profile
+func (a *App) RemoveGenerationProfile(id string) {
+	a.config.RemoveGenerationProfile(id)
+	runtime.EventsEmit(a.ctx, EventHeroesLayoutDataChanged)
+}
+
+// SetHeroesLayoutFileProfile selects the generation profile used for a custom file
+func (a *App) SetHeroesLayoutFileProfile(filePath string, profileID string) {
+	a.config.SetHeroesLayoutFileProfile(filePath, profileID)
+}
+
+// --- Stats History Bindings ---
+
+// GetHistorySources returns the providers and periods with recorded stats history
+func (a *App) GetHistorySources() ([]history.Source, error) {
+	return a.heroesLayoutService.HistorySources()
+}
+
+// GetHeroHistory returns the daily statistics of a hero in a position recorded for a provider and
+// period over the last days (0 for all). An empty provider or period selects the default one.
+func (a *App) GetHeroHistory(provider string, period string, heroID int, positionID string, days int) ([]history.SeriesPoint, error) {
+	return a.heroesLayoutService.GetHeroHistory(history.Source{Provider: provider, Period: period}, heroID, positionID, days)
+}
+
+// ExportHistory asks for a destination file and exports the stats history of a provider and
+// period as CSV or JSON. Returns the written path, or an empty string when the dialog was cancelled.
+func (a *App) ExportHistory(provider string, period string, format string) (string, error) {
+	if format != history.ExportFormatCSV && format != history.ExportFormatJSON {
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+
+	fileName := "d2tool_history"
+	if provider != "" && period != "" {
+		fileName = fmt.Sprintf("d2tool_history_%s_%s", provider, period)
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export stats history",
+		DefaultFilename: fmt.Sprintf("%s_%s.%s", fileName, time.Now().Format(history.DateLayout), format),
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: fmt.Sprintf("%s Files (*.%s)", strings.ToUpper(format), format),
+				Pattern:     "*." + format,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening save dialog: %w", err)
+	}
+	if filePath == "" {
+		return "", nil
+	}
+
+	source := history.Source{Provider: provider, Period: period}
+	if err := a.heroesLayoutService.ExportHistory(source, filePath, format); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// --- Grid Preview Bindings ---
+
+// PreviewHeroesLayoutFile renders every hero grid of a config file as SVG
+func (a *App) PreviewHeroesLayoutFile(filePath string) ([]heroesLayout.GridPreview, error) {
+	return a.heroesLayoutService.PreviewGridFile(filePath)
+}
+
+// PreviewSteamAccountGrid renders every hero grid of a Steam account as SVG
+func (a *App) PreviewSteamAccountGrid(steamId64 string) ([]heroesLayout.GridPreview, error) {
+	path, ok := a.steamService.GetAccountGridPath(steamId64)
+	if !ok {
+		return nil, fmt.Errorf("hero grid config of account %s not found", steamId64)
+	}
+	return a.heroesLayoutService.PreviewGridFile(path)
+}
+
+// ValidateHeroesLayoutFile checks a hero grid config file for problems
+func (a *App) ValidateHeroesLayoutFile(filePath string) (heroesLayout.GridValidationReport, error) {
+	return a.heroesLayoutService.ValidateGridFile(filePath)
+}
+
+// ValidateSteamAccountGrid checks the hero grid config of a Steam account for problems
+func (a *App) ValidateSteamAccountGrid(steamId64 string) (heroesLayout.GridValidationReport, error) {
+	path, ok := a.steamService.GetAccountGridPath(steamId64)
+	if !ok {
+		return heroesLayout.GridValidationReport{}, fmt.Errorf("hero grid config of account %s not found", steamId64)
+	}
+	return a.heroesLayoutService.ValidateGridFile(path)
+}
+
+// RepairHeroesLayoutFile backs up a hero grid config file and rewrites it with the grids that could be repaired
+func (a *App) RepairHeroesLayoutFile(filePath string) (heroesLayout.GridRepairResult, error) {
+	return a.heroesLayoutService.RepairGridFile(filePath)
+}
+
+// RepairSteamAccountGrid backs up the hero grid config of a Steam account and rewrites it with the grids
+// that could be repaired
+func (a *App) RepairSteamAccountGrid(steamId64 string) (heroesLayout.GridRepairResult, error) {
+	path, ok := a.steamService.GetAccountGridPath(steamId64)
+	if !ok {
+		return heroesLayout.GridRepairResult{}, fmt.Errorf("hero grid config of account %s not found", steamId64)
+	}
+	return a.heroesLayoutService.RepairGridFile(path)
+}
+
+// ExportGridPreview asks for a destination file and renders a hero grid of a config file to SVG or PNG.
+// Returns the written path, or an empty string when the dialog was cancelled.
+func (a *App) ExportGridPreview(filePath string, configName string, format string) (string, error) {
+	if format != heroesLayout.PreviewFormatSVG && format != heroesLayout.PreviewFormatPNG {
+		return "", fmt.Errorf("unknown preview format %q", format)
+	}
+
+	outPath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export hero grid preview",
+		DefaultFilename: "hero_grid." + format,
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: fmt.Sprintf("%s Files (*.%s)", strings.ToUpper(format), format),
+				Pattern:     "*." + format,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening save dialog: %w", err)
+	}
+	if outPath == "" {
+		return "", nil
+	}
+
+	if err := a.heroesLayoutService.ExportGridPreview(filePath, configName, format, outPath); err != nil {
+		return "", err
+	}
+	return outPath, nil
+}
+
+// --- Grid Sharing Bindings ---
+
+// ExportGridConfig asks for a destination file and exports a hero grid of a config file as standalone JSON.
+// Returns the written path, or an empty string when the dialog was cancelled.
+func (a *App) ExportGridConfig(filePath string, configName string) (string, error) {
+	outPath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export hero grid",
+		DefaultFilename: "hero_grid.json",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "JSON Files (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening save dialog: %w", err)
+	}
+	if outPath == "" {
+		return "", nil
+	}
+
+	if err := a.heroesLayoutService.ExportGridConfig(filePath, configName, outPath); err != nil {
+		return "", err
+	}
+	return outPath, nil
+}
+
+// GetGridShareString returns a hero grid of a config file as a share string
+func (a *App) GetGridShareString(filePath string, configName string) (string, error) {
+	return a.heroesLayoutService.GetGridShareString(filePath, configName)
+}
+
+// OpenGridImportFile asks for an exported hero grid file and returns its content,
+// or an empty string when the dialog was cancelled
+func (a *App) OpenGridImportFile() (string, error) {
+	selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select exported hero grid",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "JSON Files (*.json)",
+				Pattern:     "*.json",
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening file dialog: %w", err)
+	}
+	if selection == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(selection)
+	if err != nil {
+		return "", fmt.Errorf("error reading hero grid file: %w", err)
+	}
+	return string(data), nil
+}
+
+// ImportGridConfig adds a shared hero grid, given as a share string or exported JSON, to the grid files
+// of the selected Steam accounts and custom files
+func (a *App) ImportGridConfig(data string, steamId64s []string, filePaths []string, collision string) ([]heroesLayout.GridImportResult, error) {
+	var results []heroesLayout.GridImportResult
+	gridPaths := slices.Clone(filePaths)
+	for _, steamId64 := range steamId64s {
+		path, ok := a.steamService.GetAccountGridPath(steamId64)
+		if !ok {
+			results = append(results, heroesLayout.GridImportResult{
+				FilePath: steamId64,
+				Status:   heroesLayout.ImportStatusFailed,
+				Error:    fmt.Sprintf("hero grid config of account %s not found", steamId64),
+			})
+			continue
+		}
+		gridPaths = append(gridPaths, path)
+	}
+
+	imported, err := a.heroesLayoutService.ImportGridConfig(data, gridPaths, collision)
+	if err != nil {
+		return nil, err
+	}
+	return append(results, imported...), nil
+}
+
+// --- Grid Mirror Bindings ---
+
+// GetGridMirrors returns the grids mirrored from one Steam account into the others
+func (a *App) GetGridMirrors() []config.GridMirror {
+	return a.config.GetGridMirrors()
+}
+
+// SaveGridMirror adds or updates a grid mirror
+func (a *App) SaveGridMirror(mirror config.GridMirror) error {
+	if err := a.config.SaveGridMirror(mirror); err != nil {
+		return fmt.Errorf("invalid grid mirror: %w", err)
+	}
+	runtime.EventsEmit(a.ctx, EventSteamAccountsChanged)
+	return nil
+}
+
+// RemoveGridMirror removes a grid mirror, leaving the copies in the target accounts
+func (a *App) RemoveGridMirror(id string) {
+	a.config.RemoveGridMirror(id)
+	runtime.EventsEmit(a.ctx, EventSteamAccountsChanged)
+}
+
+// SyncGridMirror copies a mirrored grid into every other enabled account now.
+// Targets edited since the last sync are only overwritten with overwriteConflicts.
+func (a *App) SyncGridMirror(id string, overwriteConflicts bool) ([]heroesLayout.MirrorTargetResult, error) {
+	results, err := a.heroesLayoutService.SyncGridMirror(id, overwriteConflicts)
+	runtime.EventsEmit(a.ctx, EventSteamAccountsChanged)
+	return results, err
+}
+
+// GetSteamAccountGridNames returns the names of the hero grids of a Steam account
+func (a *App) GetSteamAccountGridNames(steamId64 string) ([]string, error) {
+	path, ok := a.steamService.GetAccountGridPath(steamId64)
+	if !ok {
+		return nil, fmt.Errorf("hero grid config of account %s not found", steamId64)
+	}
+	return a.heroesLayoutService.GetGridConfigNames(path)
+}
+
+// --- Layout Template Bindings ---
+
+// GetLayoutTemplates returns the built-in and user-defined layout templates
+func (a *App) GetLayoutTemplates() []config.LayoutTemplate {
+	return a.config.GetLayoutTemplates()
+}
+
+// SaveLayoutTemplate validates and stores a user-defined layout template
+func (a *App) SaveLayoutTemplate(template config.LayoutTemplate) error {
+	if err := heroesLayout.ValidateLayoutTemplate(template); err != nil {
+		return fmt.Errorf("invalid layout template: %w", err)
+	}
+	if !a.config.SaveLayoutTemplate(template) {
+		return fmt.Errorf("the built-in layout template can't be modified")
+	}
+	runtime.EventsEmit(a.ctx, EventHeroesLayoutDataChanged)
+	return nil
+}
+
+// GetRankings returns the rankings that can be used as a section sort key
+func (a *App) GetRankings() []providers.Ranking {
+	return providers.GetRankings()
+}
+
+// RemoveLayoutTemplate removes a user-defined layout template
+func (a *App) RemoveLayoutTemplate(id string) {
+	a.config.RemoveLayoutTemplate(id)
+	runtime.EventsEmit(a.ctx, EventHeroesLayoutDataChanged)
+}
+
 // OpenFileDialog opens a file dialog and returns the selected path
 func (a *App) OpenFileDialog() (string, error) {
 	selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
@@ -102,6 +454,68 @@ func (a *App) SetD2PTPeriod(period string) {
 	a.config.SetD2PTPeriod(period)
 }
 
+// --- Composite Provider Bindings ---
+
+// GetCompositeConfig returns the composite provider configuration
+func (a *App) GetCompositeConfig() config.CompositeConfig {
+	return a.config.GetCompositeConfig()
+}
+
+// SetCompositeConfig replaces the composite provider configuration
+func (a *App) SetCompositeConfig(composite config.CompositeConfig) error {
+	return a.config.SetCompositeConfig(composite)
+}
+
+// GetHeroesProviderNames returns the providers jobs and the composite provider can use
+func (a *App) GetHeroesProviderNames() []string {
+	return a.heroesLayoutService.HeroesProviderNames()
+}
+
+// TestHeroesProvider fetches the heroes of a position from a provider and returns them with the
+// diagnostics of the request and the sources behind composite stats
+func (a *App) TestHeroesProvider(provider string, positionID string) (heroesLayout.ProviderTestResult, error) {
+	return a.heroesLayoutService.TestHeroesProvider(provider, positionID)
+}
+
+// GetProviderHealth returns the recent requests of every provider
+func (a *App) GetProviderHealth() []heroesLayout.ProviderHealth {
+	return a.heroesLayoutService.ProviderHealth()
+}
+
+// --- File Provider Bindings ---
+
+// GetFileProviderConfig returns the file provider configuration
+func (a *App) GetFileProviderConfig() config.FileProviderConfig {
+	return a.config.GetFileProviderConfig()
+}
+
+// SetFileProviderSource sets the hero stats file or URL of the file provider
+func (a *App) SetFileProviderSource(source string) error {
+	if err := providers.ValidateFileSource(source); err != nil {
+		return err
+	}
+	a.config.SetFileProviderSource(source)
+	return nil
+}
+
+// SelectFileProviderSource asks for a hero stats file and returns its path,
+// or an empty string when the dialog was cancelled
+func (a *App) SelectFileProviderSource() (string, error) {
+	selection, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Select hero stats file",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "Hero Stats Files (*.json, *.csv, *.yaml, *.yml)",
+				Pattern:     "*.json;*.csv;*.yaml;*.yml",
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error opening file dialog: %w", err)
+	}
+	return selection, nil
+}
+
 // --- Heroes Layout Settings Bindings ---
 
 // GetHeroesPerRow returns the configured heroes per row
@@ -114,6 +528,16 @@ func (a *App) SetHeroesPerRow(heroesPerRow int) error {
 	return a.config.SetHeroesPerRow(heroesPerRow)
 }
 
+// GetHistoryRetentionDays returns how many days of hero statistics history are kept
+func (a *App) GetHistoryRetentionDays() int {
+	return a.config.GetHistoryRetentionDays()
+}
+
+// SetHistoryRetentionDays sets how many days of hero statistics history are kept
+func (a *App) SetHistoryRetentionDays(days int) error {
+	return a.config.SetHistoryRetentionDays(days)
+}
+
 // --- Startup Tab Bindings ---
 
 // GetStartupEnabled returns whether the app is set to run on startup
@@ -164,8 +588,12 @@ func (a *App) GetAppUpdateState() AppUpdateState {
 	return AppUpdateState{
 		CurrentVersion:      updateState.CurrentAppVersion,
 		LatestVersion:       updateState.LatestAppVersion,
+		UpdatedFromVersion:  updateState.UpdatedFromVersion,
+		ReleaseNotes:        updateState.ReleaseNotes,
+		ReleaseURL:          updateState.ReleaseURL,
 		LastCheckTimeMillis: lastCheckTimeMillis,
 		UpdateAvailable:     updateState.UpdateAvailable,
+		UpdateInstalled:     updateState.UpdateInstalled,
 		AppDirectory:        appDirectory,
 	}
 }
@@ -179,12 +607,42 @@ func (a *App) CheckForAppUpdate() error {
 	return nil
 }
 
-// DownloadAppUpdate downloads and installs the update synchronously
+// DownloadAppUpdate downloads and installs the update, emitting
+// EventAppUpdateDownloadProgress while the archive is being downloaded
 func (a *App) DownloadAppUpdate() error {
-	if err := a.updateService.UpdateApp(); err != nil {
+	err := a.updateService.UpdateApp(a.ctx, func(progress update.DownloadProgress) {
+		runtime.EventsEmit(a.ctx, EventAppUpdateDownloadProgress, AppUpdateDownloadProgress{
+			BytesDone:      progress.BytesDone,
+			TotalBytes:     progress.TotalBytes,
+			BytesPerSecond: progress.BytesPerSecond,
+		})
+	})
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("download cancelled")
+	}
+	if err != nil {
 		return fmt.Errorf("error downloading update: %w", err)
 	}
 
+	runtime.EventsEmit(a.ctx, EventAppUpdateDataChanged)
+
+	return nil
+}
+
+// CancelAppUpdateDownload aborts a running DownloadAppUpdate call
+func (a *App) CancelAppUpdateDownload() {
+	a.updateService.CancelUpdate()
+}
+
+// RestartApp starts the installed update and quits the current process.
+// The new process waits for this one to exit before taking over.
+func (a *App) RestartApp() error {
+	if err := a.updateService.RestartApp(); err != nil {
+		return fmt.Errorf("error restarting into new version: %w", err)
+	}
+
+	runtime.Quit(a.ctx)
+
 	return nil
 }
 
@@ -225,6 +683,20 @@ func (a *App) SetSteamAccountEnabled(steamId64 string, enabled bool) {
 	a.steamService.SetAccountEnabled(steamId64, enabled)
 }
 
+func (a *App) SetSteamAccountLayoutTemplate(steamId64 string, templateID string) {
+	a.steamService.SetAccountTemplate(steamId64, templateID)
+}
+
+// SetSteamAccountProfile selects the generation profile used for a Steam account
+func (a *App) SetSteamAccountProfile(steamId64 string, profileID string) {
+	a.steamService.SetAccountProfile(steamId64, profileID)
+}
+
+// SetSteamAccountJobs replaces the generation jobs of a Steam account
+func (a *App) SetSteamAccountJobs(steamId64 string, jobs []config.GenerationJob) error {
+	return a.steamService.SetAccountJobs(steamId64, jobs)
+}
+
 func (a *App) RescanSteamAccounts() error {
 	if err := a.steamService.Scan(); err != nil {
 		return fmt.Errorf("error scanning steam accounts: %w", err)
@@ -249,6 +721,9 @@ func (a *App) IsSteamPathValid() bool {
 
 // --- Background Tasks ---
 
+// heroesSourceCheckInterval is how often heroes sources are checked for changes, like edits of a hero stats file
+const heroesSourceCheckInterval = 15 * time.Second
+
 func (a *App) startBackgroundTasks() {
 	// Start periodic hero layout update
 	go func() {
@@ -299,6 +774,32 @@ func (a *App) startBackgroundTasks() {
 			if err := a.UpdateHeroesLayout(); err != nil {
 				slog.Warn("Error updating hero layout", "error", err)
 			}
+
+			a.heroesLayoutService.SyncAutoGridMirrors()
+			runtime.EventsEmit(a.ctx, EventSteamAccountsChanged)
+		}
+	}()
+
+	// Update the layouts as soon as a heroes source changes, like an edited hero stats file
+	go func() {
+		ticker := time.NewTicker(heroesSourceCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.ctx.Done():
+				slog.Info("Stopping background heroes source check task")
+				return
+			case <-ticker.C:
+			}
+
+			if !a.heroesLayoutService.HeroesSourcesChanged() {
+				continue
+			}
+			slog.Info("Performing hero layout update after heroes source change")
+			if err := a.UpdateHeroesLayout(); err != nil {
+				slog.Warn("Error updating hero layout", "error", err)
+			}
 		}
 	}()
 
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"d2tool/providers"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Modes of the composite provider
+const (
+	// CompositeMerge blends the stats of every source that answers, weighted by the source weights
+	CompositeMerge = "merge"
+	// CompositeFallback uses the first source in order that answers
+	CompositeFallback = "fallback"
+)
+
+// CompositeConfig contains the sources of the composite provider
+type CompositeConfig struct {
+	Mode    string                  `json:"mode"`
+	Sources []CompositeSourceConfig `json:"sources"` // in fallback order, empty disables the composite provider
+}
+
+// CompositeSourceConfig is one provider of the composite provider
+type CompositeSourceConfig struct {
+	Provider string  `json:"provider"`
+	Weight   float64 `json:"weight"`
+}
+
+func defaultCompositeConfig() CompositeConfig {
+	return CompositeConfig{
+		Mode:    CompositeMerge,
+		Sources: []CompositeSourceConfig{},
+	}
+}
+
+// ValidateCompositeConfig checks the mode and the sources; whether the providers exist is checked when fetching
+func ValidateCompositeConfig(composite CompositeConfig) error {
+	if composite.Mode != CompositeMerge && composite.Mode != CompositeFallback {
+		return fmt.Errorf("unknown composite mode %q", composite.Mode)
+	}
+
+	seen := make(map[string]bool, len(composite.Sources))
+	for i, source := range composite.Sources {
+		provider := strings.TrimSpace(source.Provider)
+		if provider == "" {
+			return fmt.Errorf("source %d: provider is required", i+1)
+		}
+		// The composite provider can't be a source of itself
+		if provider == providers.CompositeProviderName {
+			return fmt.Errorf("source %d: the composite provider can't be its own source", i+1)
+		}
+		if seen[provider] {
+			return fmt.Errorf("source %d: duplicate provider %q", i+1, provider)
+		}
+		seen[provider] = true
+
+		if source.Weight <= 0 {
+			return fmt.Errorf("source %q: weight must be positive", provider)
+		}
+	}
+	return nil
+}
+
+// --- Composite Provider Methods ---
+
+// GetCompositeConfig returns a copy of the composite provider configuration
+func (c *Config) GetCompositeConfig() CompositeConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	composite := c.Composite
+	composite.Sources = slices.Clone(composite.Sources)
+	return composite
+}
+
+// SetCompositeConfig replaces the composite provider configuration
+func (c *Config) SetCompositeConfig(composite CompositeConfig) error {
+	if err := ValidateCompositeConfig(composite); err != nil {
+		return err
+	}
+	composite.Sources = slices.Clone(composite.Sources)
+	if composite.Sources == nil {
+		composite.Sources = []CompositeSourceConfig{}
+	}
+	for i := range composite.Sources {
+		composite.Sources[i].Provider = strings.TrimSpace(composite.Sources[i].Provider)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Composite = composite
+	go c.scheduleSave()
+	return nil
+}
@@ -21,10 +21,14 @@ var heroGridConfigPathRegex = regexp.MustCompile(`userdata/(\d+)/570/remote/cfg/
 
 // FileConfig represents a single config file entry
 type FileConfig struct {
-	FilePath                  string `json:"filePath"`
-	Enabled                   bool   `json:"enabled"`
-	LastUpdateTimestampMillis int64  `json:"lastUpdateTimestampMillis"`
-	LastUpdateErrorMessage    string `json:"lastUpdateErrorMessage"`
+	FilePath                  string          `json:"filePath"`
+	Enabled                   bool            `json:"enabled"`
+	LastUpdateTimestampMillis int64           `json:"lastUpdateTimestampMillis"`
+	LastUpdateErrorMessage    string          `json:"lastUpdateErrorMessage"`
+	LastUpdateWarnings        []string        `json:"lastUpdateWarnings"` // problems that didn't stop the update
+	TemplateID                string          `json:"templateId"`
+	ProfileID                 string          `json:"profileId"` // empty uses the global settings
+	Jobs                      []GenerationJob `json:"jobs"`      // empty generates the default job only
 }
 
 // PositionConfig represents a position entry
@@ -35,9 +39,12 @@ type PositionConfig struct {
 
 // HeroesLayoutConfig contains heroes layout related settings
 type HeroesLayoutConfig struct {
-	Files        []FileConfig     `json:"files"`
-	Positions    []PositionConfig `json:"positions"`
-	HeroesPerRow int              `json:"heroesPerRow"`
+	Files        []FileConfig        `json:"files"`
+	Positions    []PositionConfig    `json:"positions"`
+	HeroesPerRow int                 `json:"heroesPerRow"`
+	Templates    []LayoutTemplate    `json:"templates"` // user-defined, the built-in default is not stored
+	HeroLists    HeroListsConfig     `json:"heroLists"`
+	Profiles     []GenerationProfile `json:"profiles"` // user-defined, the global settings profile is not stored
 }
 
 // D2PTConfig contains Dota2ProTracker provider settings
@@ -45,19 +52,34 @@ type D2PTConfig struct {
 	Period string `json:"period"` // "8" for last 8 days, "patch" for current patch
 }
 
+// HistoryConfig contains settings of the local hero statistics history
+type HistoryConfig struct {
+	RetentionDays int `json:"retentionDays"` // snapshots older than this are deleted
+}
+
 // SteamConfig contains Steam-related settings
 type SteamConfig struct {
 	SteamPath             string               `json:"steamPath"`
 	AutoEnableNewAccounts bool                 `json:"autoEnableNewAccounts"`
 	Accounts              []SteamAccountConfig `json:"accounts"`
+	Mirrors               []GridMirror         `json:"mirrors"`
 }
 
 // SteamAccountConfig represents a single Steam account entry
 type SteamAccountConfig struct {
-	SteamID64                 string `json:"steamId64"`
-	Enabled                   bool   `json:"enabled"`
-	LastUpdateTimestampMillis int64  `json:"lastUpdateTimestampMillis"`
-	LastUpdateErrorMessage    string `json:"lastUpdateErrorMessage"`
+	SteamID64                 string          `json:"steamId64"`
+	Enabled                   bool            `json:"enabled"`
+	LastUpdateTimestampMillis int64           `json:"lastUpdateTimestampMillis"`
+	LastUpdateErrorMessage    string          `json:"lastUpdateErrorMessage"`
+	LastUpdateWarnings        []string        `json:"lastUpdateWarnings"` // problems that didn't stop the update
+	TemplateID                string          `json:"templateId"`
+	ProfileID                 string          `json:"profileId"` // empty uses the global settings
+	Jobs                      []GenerationJob `json:"jobs"`      // empty generates the default job only
+}
+
+// isValidD2PTPeriod reports whether period is "8" (last 8 days) or "patch" (current patch)
+func isValidD2PTPeriod(period string) bool {
+	return period == "8" || period == "patch"
 }
 
 func defaultD2PTConfig() D2PTConfig {
@@ -72,7 +94,10 @@ type Config struct {
 
 	HeroesLayout HeroesLayoutConfig `json:"heroesLayout"`
 	D2PT         D2PTConfig         `json:"d2pt"`
+	Composite    CompositeConfig    `json:"composite"`
+	FileProvider FileProviderConfig `json:"fileProvider"`
 	Steam        SteamConfig        `json:"steam"`
+	History      HistoryConfig      `json:"history"`
 
 	// Debounce state for save operations (not persisted)
 	saveTimer *time.Timer
@@ -103,6 +128,10 @@ const (
 	defaultHeroesPerRow = 15
 	minHeroesPerRow     = 1
 	maxHeroesPerRow     = 50
+
+	defaultHistoryRetentionDays = 30
+	minHistoryRetentionDays     = 1
+	maxHistoryRetentionDays     = 365
 )
 
 func LoadConfig() *Config {
@@ -111,11 +140,18 @@ func LoadConfig() *Config {
 			Files:        []FileConfig{},
 			Positions:    defaultPositions(),
 			HeroesPerRow: defaultHeroesPerRow,
+			Templates:    []LayoutTemplate{},
+			HeroLists:    defaultHeroListsConfig(),
 		},
-		D2PT: defaultD2PTConfig(),
+		D2PT:      defaultD2PTConfig(),
+		Composite: defaultCompositeConfig(),
 		Steam: SteamConfig{
 			AutoEnableNewAccounts: true,
 			Accounts:              []SteamAccountConfig{},
+			Mirrors:               []GridMirror{},
+		},
+		History: HistoryConfig{
+			RetentionDays: defaultHistoryRetentionDays,
 		},
 		saveDelay: 500 * time.Millisecond,
 	}
@@ -147,20 +183,59 @@ func LoadConfig() *Config {
 	}
 
 	// Ensure D2PT config has valid period
-	if config.D2PT.Period != "8" && config.D2PT.Period != "patch" {
+	if !isValidD2PTPeriod(config.D2PT.Period) {
 		config.D2PT.Period = "8"
 	}
 
+	// Ensure a hand-edited composite config is usable, or drop it
+	if err := ValidateCompositeConfig(config.Composite); err != nil {
+		slog.Warn("Invalid composite provider config, using defaults", "error", err)
+		config.Composite = defaultCompositeConfig()
+	}
+
 	// Ensure HeroesPerRow is within valid range
 	if config.HeroesLayout.HeroesPerRow < minHeroesPerRow || config.HeroesLayout.HeroesPerRow > maxHeroesPerRow {
 		config.HeroesLayout.HeroesPerRow = defaultHeroesPerRow
 	}
 
+	// Ensure history retention is within valid range
+	if config.History.RetentionDays < minHistoryRetentionDays || config.History.RetentionDays > maxHistoryRetentionDays {
+		config.History.RetentionDays = defaultHistoryRetentionDays
+	}
+
+	// Ensure Profiles is never nil and hand-edited profiles stay usable
+	if config.HeroesLayout.Profiles == nil {
+		config.HeroesLayout.Profiles = []GenerationProfile{}
+	}
+	for i := range config.HeroesLayout.Profiles {
+		profile := &config.HeroesLayout.Profiles[i]
+		if profile.HeroesPerRow < minHeroesPerRow || profile.HeroesPerRow > maxHeroesPerRow {
+			profile.HeroesPerRow = defaultHeroesPerRow
+		}
+		if !isValidD2PTPeriod(profile.Period) {
+			profile.Period = defaultD2PTConfig().Period
+		}
+		profile.HeroLists = profile.HeroLists.normalized()
+	}
+
+	// Ensure Templates is never nil
+	if config.HeroesLayout.Templates == nil {
+		config.HeroesLayout.Templates = []LayoutTemplate{}
+	}
+
+	// Ensure hero lists are never nil and contain no duplicates
+	config.HeroesLayout.HeroLists = config.HeroesLayout.HeroLists.normalized()
+
 	// Ensure Steam.Accounts is never nil
 	if config.Steam.Accounts == nil {
 		config.Steam.Accounts = []SteamAccountConfig{}
 	}
 
+	// Ensure Steam.Mirrors is never nil
+	if config.Steam.Mirrors == nil {
+		config.Steam.Mirrors = []GridMirror{}
+	}
+
 	return config
 }
 
@@ -216,6 +291,10 @@ func (c *Config) GetHeroesLayoutFiles() []FileConfig {
 	defer c.mu.RUnlock()
 	result := make([]FileConfig, len(c.HeroesLayout.Files))
 	copy(result, c.HeroesLayout.Files)
+	for i := range result {
+		result[i].Jobs = cloneGenerationJobs(result[i].Jobs)
+		result[i].LastUpdateWarnings = slices.Clone(result[i].LastUpdateWarnings)
+	}
 	return result
 }
 
@@ -270,7 +349,7 @@ func (c *Config) SetHeroesLayoutFileEnabled(filePath string, enabled bool) {
 	}
 }
 
-func (c *Config) UpdateHeroesLayoutFileStatus(filePaths []string, timestampMillis int64, errorMessage string) {
+func (c *Config) UpdateHeroesLayoutFileStatus(filePaths []string, timestampMillis int64, errorMessage string, warnings []string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -278,6 +357,7 @@ func (c *Config) UpdateHeroesLayoutFileStatus(filePaths []string, timestampMilli
 		if slices.Contains(filePaths, c.HeroesLayout.Files[i].FilePath) {
 			c.HeroesLayout.Files[i].LastUpdateTimestampMillis = timestampMillis
 			c.HeroesLayout.Files[i].LastUpdateErrorMessage = errorMessage
+			c.HeroesLayout.Files[i].LastUpdateWarnings = slices.Clone(warnings)
 		}
 	}
 	go c.scheduleSave()
@@ -380,6 +460,27 @@ func (c *Config) SetHeroesPerRow(heroesPerRow int) error {
 	return nil
 }
 
+// --- History Methods ---
+
+// GetHistoryRetentionDays returns how many days of hero statistics snapshots are kept
+func (c *Config) GetHistoryRetentionDays() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.History.RetentionDays
+}
+
+// SetHistoryRetentionDays sets the snapshot retention with validation (1-365)
+func (c *Config) SetHistoryRetentionDays(days int) error {
+	if days < minHistoryRetentionDays || days > maxHistoryRetentionDays {
+		return fmt.Errorf("retention must be between %d and %d days, got %d", minHistoryRetentionDays, maxHistoryRetentionDays, days)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.History.RetentionDays = days
+	go c.scheduleSave()
+	return nil
+}
+
 // --- Steam Config Methods ---
 
 // GetSteamConfig returns a copy of the Steam configuration
@@ -389,6 +490,7 @@ func (c *Config) GetSteamConfig() SteamConfig {
 	cfg := c.Steam
 	cfg.Accounts = make([]SteamAccountConfig, len(c.Steam.Accounts))
 	copy(cfg.Accounts, c.Steam.Accounts)
+	cfg.Mirrors = cloneGridMirrors(c.Steam.Mirrors)
 	return cfg
 }
 
@@ -414,6 +516,10 @@ func (c *Config) GetSteamAccounts() []SteamAccountConfig {
 	defer c.mu.RUnlock()
 	result := make([]SteamAccountConfig, len(c.Steam.Accounts))
 	copy(result, c.Steam.Accounts)
+	for i := range result {
+		result[i].Jobs = cloneGenerationJobs(result[i].Jobs)
+		result[i].LastUpdateWarnings = slices.Clone(result[i].LastUpdateWarnings)
+	}
 	return result
 }
 
@@ -438,14 +544,15 @@ func (c *Config) SetSteamAccountEnabled(steamId64 string, enabled bool) {
 	}
 }
 
-// UpdateSteamAccountStatus updates the last update timestamp and error message for a Steam account
-func (c *Config) UpdateSteamAccountStatus(steamId64 string, timestampMillis int64, errorMessage string) {
+// UpdateSteamAccountStatus updates the last update timestamp, error message and warnings for a Steam account
+func (c *Config) UpdateSteamAccountStatus(steamId64 string, timestampMillis int64, errorMessage string, warnings []string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	for i := range c.Steam.Accounts {
 		if c.Steam.Accounts[i].SteamID64 == steamId64 {
 			c.Steam.Accounts[i].LastUpdateTimestampMillis = timestampMillis
 			c.Steam.Accounts[i].LastUpdateErrorMessage = errorMessage
+			c.Steam.Accounts[i].LastUpdateWarnings = slices.Clone(warnings)
 			go c.scheduleSave()
 			return
 		}
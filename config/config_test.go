@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -254,7 +255,7 @@ func TestConfig_UpdateHeroesLayoutFileStatus(t *testing.T) {
 	}
 
 	timestamp := time.Now().UnixMilli()
-	cfg.UpdateHeroesLayoutFileStatus([]string{"/file1.json"}, timestamp, "")
+	cfg.UpdateHeroesLayoutFileStatus([]string{"/file1.json"}, timestamp, "", nil)
 
 	files := cfg.GetHeroesLayoutFiles()
 	if files[0].LastUpdateTimestampMillis != timestamp {
@@ -277,7 +278,7 @@ func TestConfig_UpdateHeroesLayoutFileStatus_WithError(t *testing.T) {
 	}
 
 	errorMsg := "failed to update"
-	cfg.UpdateHeroesLayoutFileStatus([]string{"/file1.json"}, 0, errorMsg)
+	cfg.UpdateHeroesLayoutFileStatus([]string{"/file1.json"}, 0, errorMsg, nil)
 
 	files := cfg.GetHeroesLayoutFiles()
 	if files[0].LastUpdateErrorMessage != errorMsg {
@@ -666,7 +667,7 @@ func TestConfig_UpdateSteamAccountStatus(t *testing.T) {
 		saveDelay: 50 * time.Millisecond,
 	}
 
-	cfg.UpdateSteamAccountStatus("76561198000000001", 1700000000000, "some error")
+	cfg.UpdateSteamAccountStatus("76561198000000001", 1700000000000, "some error", nil)
 
 	accounts := cfg.GetSteamAccounts()
 	if accounts[0].LastUpdateTimestampMillis != 1700000000000 {
@@ -900,3 +901,368 @@ func TestConfig_FileConfig_NoAttributes(t *testing.T) {
 		t.Error("FileConfig JSON should not contain 'attributes' key")
 	}
 }
+
+func TestConfig_LayoutTemplates(t *testing.T) {
+	cfg := &Config{
+		HeroesLayout: HeroesLayoutConfig{
+			Files:     []FileConfig{{FilePath: "/file1.json", Enabled: true}},
+			Positions: defaultPositions(),
+		},
+		Steam: SteamConfig{
+			Accounts: []SteamAccountConfig{{SteamID64: "76561198000000001", Enabled: true}},
+		},
+		saveDelay: 50 * time.Millisecond,
+	}
+
+	templates := cfg.GetLayoutTemplates()
+	if len(templates) != 1 || templates[0].ID != DefaultLayoutTemplateID || !templates[0].BuiltIn {
+		t.Fatalf("expected only the built-in template, got %+v", templates)
+	}
+
+	if cfg.SaveLayoutTemplate(DefaultLayoutTemplate()) {
+		t.Error("built-in template should not be overwritable")
+	}
+
+	custom := DefaultLayoutTemplate()
+	custom.ID = "compact"
+	custom.Name = "Compact"
+	custom.BuiltIn = true
+	if !cfg.SaveLayoutTemplate(custom) {
+		t.Fatal("expected custom template to be saved")
+	}
+
+	saved := cfg.GetLayoutTemplate("compact")
+	if saved.Name != "Compact" || saved.BuiltIn {
+		t.Errorf("expected saved user template, got %+v", saved)
+	}
+
+	// Returned templates are copies
+	saved.Sections[0].Labels[0].Format = "changed"
+	if cfg.GetLayoutTemplate("compact").Sections[0].Labels[0].Format == "changed" {
+		t.Error("modifying returned template should not affect config")
+	}
+
+	custom.Name = "Compact v2"
+	cfg.SaveLayoutTemplate(custom)
+	if templates := cfg.GetLayoutTemplates(); len(templates) != 2 || templates[1].Name != "Compact v2" {
+		t.Errorf("expected template to be replaced, got %+v", templates)
+	}
+
+	cfg.SetHeroesLayoutFileTemplate("/file1.json", "compact")
+	cfg.SetSteamAccountTemplate("76561198000000001", "compact")
+	if cfg.GetHeroesLayoutFiles()[0].TemplateID != "compact" {
+		t.Error("expected file template to be set")
+	}
+	if cfg.GetSteamAccounts()[0].TemplateID != "compact" {
+		t.Error("expected account template to be set")
+	}
+
+	cfg.RemoveLayoutTemplate("compact")
+	if got := cfg.GetLayoutTemplate("compact"); got.ID != DefaultLayoutTemplateID {
+		t.Errorf("expected fallback to built-in template after removal, got %q", got.ID)
+	}
+}
+
+func TestConfig_SetHeroLists_Normalizes(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	cfg.SetHeroLists(HeroListsConfig{
+		Exclude: []int{5, 5, 0, 3},
+		Pinned:  map[string][]int{"1": {7, 7}, "2": {}},
+	})
+
+	lists := cfg.GetHeroLists()
+	if len(lists.Exclude) != 2 || lists.Exclude[0] != 5 || lists.Exclude[1] != 3 {
+		t.Errorf("expected deduplicated exclude list [5 3], got %v", lists.Exclude)
+	}
+	if lists.Include == nil || len(lists.Include) != 0 {
+		t.Errorf("expected empty include list, got %v", lists.Include)
+	}
+	if len(lists.Pinned["1"]) != 1 {
+		t.Errorf("expected deduplicated pins, got %v", lists.Pinned["1"])
+	}
+	if _, ok := lists.Pinned["2"]; ok {
+		t.Error("expected empty pin lists to be dropped")
+	}
+
+	// Returned lists are copies
+	lists.Pinned["1"][0] = 99
+	if cfg.GetHeroLists().Pinned["1"][0] != 7 {
+		t.Error("modifying returned lists should not affect config")
+	}
+}
+
+func TestConfig_SetHistoryRetentionDays(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	tests := []struct {
+		name    string
+		days    int
+		wantErr bool
+	}{
+		{"minimum", 1, false},
+		{"maximum", 365, false},
+		{"zero", 0, true},
+		{"too long", 366, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := cfg.SetHistoryRetentionDays(tt.days)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetHistoryRetentionDays(%d) error = %v, wantErr %v", tt.days, err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.GetHistoryRetentionDays() != tt.days {
+				t.Errorf("expected retention %d, got %d", tt.days, cfg.GetHistoryRetentionDays())
+			}
+		})
+	}
+}
+
+func TestValidateGenerationJobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		jobs    []GenerationJob
+		wantErr bool
+	}{
+		{"no jobs", nil, false},
+		{"valid jobs", []GenerationJob{
+			{ID: "last8", Name: "Last 8 Days", Period: "8", Positions: []string{"2"}},
+			{ID: "patch", Name: "This Patch", Period: "patch"},
+		}, false},
+		{"explicit default job", []GenerationJob{DefaultGenerationJob()}, false},
+		{"invalid id", []GenerationJob{{ID: "my job", Name: "Job"}}, true},
+		{"duplicate id", []GenerationJob{{ID: "a", Name: "A"}, {ID: "a", Name: "B"}}, true},
+		{"missing name", []GenerationJob{{ID: "a", Name: " "}}, true},
+		{"unknown period", []GenerationJob{{ID: "a", Name: "A", Period: "30"}}, true},
+		{"unknown position", []GenerationJob{{ID: "a", Name: "A", Positions: []string{"6"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateGenerationJobs(tt.jobs); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGenerationJobs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_SetHeroesLayoutFileJobs(t *testing.T) {
+	cfg := newTestConfig(t, "")
+	cfg.AddHeroesLayoutFile("/path/hero_grid_config.json")
+
+	jobs := []GenerationJob{{ID: "patch", Name: "This Patch", Period: "patch", Positions: []string{"1"}}}
+	if err := cfg.SetHeroesLayoutFileJobs("/path/hero_grid_config.json", jobs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cfg.SetHeroesLayoutFileJobs("/other.json", jobs); err == nil {
+		t.Error("expected error for unknown file")
+	}
+
+	files := cfg.GetHeroesLayoutFiles()
+	if len(files[0].Jobs) != 1 || files[0].Jobs[0].ID != "patch" {
+		t.Fatalf("expected saved jobs, got %+v", files[0].Jobs)
+	}
+
+	// Returned jobs are copies
+	files[0].Jobs[0].Positions[0] = "5"
+	if cfg.GetHeroesLayoutFiles()[0].Jobs[0].Positions[0] != "1" {
+		t.Error("modifying returned jobs should not affect config")
+	}
+
+	if jobs := EffectiveGenerationJobs(nil); len(jobs) != 1 || jobs[0].ID != DefaultGenerationJobID {
+		t.Errorf("expected the default job for targets without jobs, got %+v", jobs)
+	}
+}
+
+func TestConfig_GenerationProfiles(t *testing.T) {
+	cfg := newTestConfig(t, "")
+	cfg.HeroesLayout.HeroesPerRow = 15
+	cfg.D2PT = defaultD2PTConfig()
+	cfg.HeroesLayout.HeroLists = HeroListsConfig{Exclude: []int{3}}
+
+	// Without user profiles, the global settings are the only profile
+	profiles := cfg.GetGenerationProfiles()
+	if len(profiles) != 1 || profiles[0].ID != DefaultGenerationProfileID || !profiles[0].BuiltIn {
+		t.Fatalf("expected only the built-in profile, got %+v", profiles)
+	}
+	if len(profiles[0].EnabledPositionIDs()) != 5 || profiles[0].Period != "8" || profiles[0].HeroLists.Exclude[0] != 3 {
+		t.Errorf("expected the built-in profile to mirror the global settings, got %+v", profiles[0])
+	}
+
+	support := GenerationProfile{
+		ID:           "support",
+		Name:         "Support smurf",
+		Positions:    []PositionConfig{{ID: "4", Enabled: true}, {ID: "5", Enabled: true}, {ID: "1", Enabled: false}},
+		HeroesPerRow: 10,
+		Period:       "patch",
+	}
+	if err := cfg.SaveGenerationProfile(support); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := cfg.GetGenerationProfile("support")
+	if got.Name != "Support smurf" || got.BuiltIn {
+		t.Errorf("unexpected stored profile %+v", got)
+	}
+	if ids := got.EnabledPositionIDs(); len(ids) != 2 || ids[0] != "4" || ids[1] != "5" {
+		t.Errorf("expected positions 4 and 5, got %v", ids)
+	}
+	if got.HeroLists.Pinned == nil {
+		t.Error("expected normalized hero lists")
+	}
+
+	// Unknown IDs fall back to the global settings
+	if cfg.GetGenerationProfile("missing").ID != DefaultGenerationProfileID {
+		t.Error("expected fallback to the global settings profile")
+	}
+
+	// Returned profiles are copies
+	got.Positions[0].ID = "2"
+	if cfg.GetGenerationProfile("support").Positions[0].ID != "4" {
+		t.Error("modifying a returned profile should not affect config")
+	}
+
+	builtIn := profiles[0]
+	builtIn.Name = "Changed"
+	if err := cfg.SaveGenerationProfile(builtIn); err == nil {
+		t.Error("expected the built-in profile to be read-only")
+	}
+
+	cfg.RemoveGenerationProfile("support")
+	if len(cfg.GetGenerationProfiles()) != 1 {
+		t.Error("expected the profile to be removed")
+	}
+}
+
+func TestValidateGenerationProfile(t *testing.T) {
+	valid := GenerationProfile{ID: "mid", Name: "Mid", Positions: []PositionConfig{{ID: "2", Enabled: true}}, HeroesPerRow: 15, Period: "8"}
+
+	tests := []struct {
+		name    string
+		modify  func(p *GenerationProfile)
+		wantErr bool
+	}{
+		{"valid", func(p *GenerationProfile) {}, false},
+		{"invalid id", func(p *GenerationProfile) { p.ID = "mid only" }, true},
+		{"missing name", func(p *GenerationProfile) { p.Name = "" }, true},
+		{"heroes per row out of range", func(p *GenerationProfile) { p.HeroesPerRow = 0 }, true},
+		{"unknown period", func(p *GenerationProfile) { p.Period = "30" }, true},
+		{"unknown position", func(p *GenerationProfile) { p.Positions = []PositionConfig{{ID: "6"}} }, true},
+		{"duplicate position", func(p *GenerationProfile) { p.Positions = []PositionConfig{{ID: "2"}, {ID: "2"}} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := valid
+			profile.Positions = slices.Clone(valid.Positions)
+			tt.modify(&profile)
+			if err := ValidateGenerationProfile(profile); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGenerationProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_GridMirrors(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	if err := cfg.SaveGridMirror(GridMirror{ID: "main", SourceSteamID64: "1", ConfigName: ""}); err == nil {
+		t.Error("expected an error for a mirror without config name")
+	}
+
+	mirror := GridMirror{ID: "main", SourceSteamID64: "1", ConfigName: "My grid"}
+	if err := cfg.SaveGridMirror(mirror); err != nil {
+		t.Fatalf("SaveGridMirror failed: %v", err)
+	}
+	cfg.UpdateGridMirrorSync("main", 1700000000000, map[string]string{"2": "abc"}, []string{"3"}, "")
+
+	// Changing settings only keeps the sync state
+	mirror.AutoSync = true
+	mirror.SyncedHashes = nil
+	if err := cfg.SaveGridMirror(mirror); err != nil {
+		t.Fatalf("SaveGridMirror failed: %v", err)
+	}
+	saved, ok := cfg.GetGridMirror("main")
+	if !ok || !saved.AutoSync || saved.SyncedHashes["2"] != "abc" || len(saved.LastSyncConflicts) != 1 {
+		t.Errorf("expected settings updated and sync state kept, got %+v", saved)
+	}
+
+	// Getters return copies
+	saved.SyncedHashes["2"] = "changed"
+	if again, _ := cfg.GetGridMirror("main"); again.SyncedHashes["2"] != "abc" {
+		t.Error("expected GetGridMirror to return a copy")
+	}
+
+	// A new source starts over
+	mirror.ConfigName = "Other grid"
+	if err := cfg.SaveGridMirror(mirror); err != nil {
+		t.Fatalf("SaveGridMirror failed: %v", err)
+	}
+	saved, _ = cfg.GetGridMirror("main")
+	if len(saved.SyncedHashes) != 0 || len(saved.LastSyncConflicts) != 0 {
+		t.Errorf("expected sync state reset for a new source, got %+v", saved)
+	}
+
+	cfg.RemoveGridMirror("main")
+	if len(cfg.GetGridMirrors()) != 0 {
+		t.Error("expected mirror to be removed")
+	}
+}
+
+func TestValidateCompositeConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		composite CompositeConfig
+		wantErr   bool
+	}{
+		{"empty", CompositeConfig{Mode: CompositeMerge}, false},
+		{"valid", CompositeConfig{Mode: CompositeFallback, Sources: []CompositeSourceConfig{{"d2pt", 1}, {"file", 0.5}}}, false},
+		{"unknown mode", CompositeConfig{Mode: "average"}, true},
+		{"missing provider", CompositeConfig{Mode: CompositeMerge, Sources: []CompositeSourceConfig{{" ", 1}}}, true},
+		{"composite source", CompositeConfig{Mode: CompositeMerge, Sources: []CompositeSourceConfig{{"composite", 1}}}, true},
+		{"duplicate provider", CompositeConfig{Mode: CompositeMerge, Sources: []CompositeSourceConfig{{"d2pt", 1}, {"d2pt", 2}}}, true},
+		{"zero weight", CompositeConfig{Mode: CompositeMerge, Sources: []CompositeSourceConfig{{"d2pt", 0}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCompositeConfig(tt.composite); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCompositeConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_SetCompositeConfig(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	if err := cfg.SetCompositeConfig(CompositeConfig{Mode: "average"}); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+
+	sources := []CompositeSourceConfig{{Provider: " d2pt ", Weight: 2}}
+	if err := cfg.SetCompositeConfig(CompositeConfig{Mode: CompositeMerge, Sources: sources}); err != nil {
+		t.Fatalf("SetCompositeConfig failed: %v", err)
+	}
+	sources[0].Weight = 5
+
+	composite := cfg.GetCompositeConfig()
+	if len(composite.Sources) != 1 || composite.Sources[0].Provider != "d2pt" || composite.Sources[0].Weight != 2 {
+		t.Errorf("expected the trimmed source stored as a copy, got %+v", composite.Sources)
+	}
+
+	composite.Sources[0].Weight = 7
+	if cfg.GetCompositeConfig().Sources[0].Weight != 2 {
+		t.Error("expected GetCompositeConfig to return a copy")
+	}
+}
+
+func TestConfig_SetFileProviderSource(t *testing.T) {
+	cfg := newTestConfig(t, "")
+
+	cfg.SetFileProviderSource("  https://example.com/tiers.yaml ")
+	if source := cfg.GetFileProviderSource(); source != "https://example.com/tiers.yaml" {
+		t.Errorf("expected the trimmed source, got %q", source)
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import "strings"
+
+// FileProviderConfig contains the settings of the file provider
+type FileProviderConfig struct {
+	Source string `json:"source"` // path of a local JSON, CSV or YAML file or an HTTP URL, empty disables the provider
+}
+
+// --- File Provider Methods ---
+
+// GetFileProviderConfig returns the file provider configuration
+func (c *Config) GetFileProviderConfig() FileProviderConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.FileProvider
+}
+
+// GetFileProviderSource returns the file path or URL the file provider reads
+func (c *Config) GetFileProviderSource() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.FileProvider.Source
+}
+
+// SetFileProviderSource sets the file path or URL the file provider reads
+func (c *Config) SetFileProviderSource(source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.FileProvider.Source = strings.TrimSpace(source)
+	go c.scheduleSave()
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultGenerationJobID identifies the job generated for targets without configured jobs.
+// Its config keeps the legacy "[D2T] Heroes Meta <date>" name.
+const DefaultGenerationJobID = "default"
+
+// identifierRegex matches the IDs of jobs and profiles
+var identifierRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// GenerationJob is one hero grid config generated into a target file.
+// Empty fields fall back to the target's profile and template.
+type GenerationJob struct {
+	ID   string `json:"id"` // stable identifier written into the config name, never change it
+	Name string `json:"name"`
+	// DisplayName is the exact config name shown in Dota, "{date}" is replaced with the update date.
+	// Empty uses "[D2T:<id>] <name> <date>".
+	DisplayName string   `json:"displayName"`
+	Provider    string   `json:"provider"`   // empty uses the default provider
+	Period      string   `json:"period"`     // empty uses the profile period
+	Positions   []string `json:"positions"`  // position IDs in order, empty uses the profile's enabled positions
+	TemplateID  string   `json:"templateId"` // empty uses the target's template
+}
+
+// DefaultGenerationJob returns the job used for targets without configured jobs
+func DefaultGenerationJob() GenerationJob {
+	return GenerationJob{ID: DefaultGenerationJobID, Name: "Heroes Meta"}
+}
+
+// ValidateGenerationJobs checks job IDs, names, periods and positions
+func ValidateGenerationJobs(jobs []GenerationJob) error {
+	seen := make(map[string]bool, len(jobs))
+	for i, job := range jobs {
+		if !identifierRegex.MatchString(job.ID) {
+			return fmt.Errorf("job %d: id %q must only contain letters, digits, '-' and '_'", i+1, job.ID)
+		}
+		if seen[job.ID] {
+			return fmt.Errorf("job %d: duplicate id %q", i+1, job.ID)
+		}
+		seen[job.ID] = true
+
+		if strings.TrimSpace(job.Name) == "" {
+			return fmt.Errorf("job %q: name is required", job.ID)
+		}
+		if job.Period != "" && !isValidD2PTPeriod(job.Period) {
+			return fmt.Errorf("job %q: unknown period %q", job.ID, job.Period)
+		}
+		for _, position := range job.Positions {
+			if !isKnownPositionID(position) {
+				return fmt.Errorf("job %q: unknown position %q", job.ID, position)
+			}
+		}
+	}
+	return nil
+}
+
+// EffectiveGenerationJobs returns the configured jobs, or the default job when there are none
+func EffectiveGenerationJobs(jobs []GenerationJob) []GenerationJob {
+	if len(jobs) == 0 {
+		return []GenerationJob{DefaultGenerationJob()}
+	}
+	return cloneGenerationJobs(jobs)
+}
+
+func cloneGenerationJobs(jobs []GenerationJob) []GenerationJob {
+	if jobs == nil {
+		return nil
+	}
+	result := make([]GenerationJob, len(jobs))
+	for i, job := range jobs {
+		job.Positions = slices.Clone(job.Positions)
+		result[i] = job
+	}
+	return result
+}
+
+// --- Generation Job Methods ---
+
+// SetHeroesLayoutFileJobs replaces the generation jobs of a file
+func (c *Config) SetHeroesLayoutFileJobs(filePath string, jobs []GenerationJob) error {
+	if err := ValidateGenerationJobs(jobs); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.HeroesLayout.Files {
+		if c.HeroesLayout.Files[i].FilePath == filePath {
+			c.HeroesLayout.Files[i].Jobs = cloneGenerationJobs(jobs)
+			go c.scheduleSave()
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown file %q", filePath)
+}
+
+// SetSteamAccountJobs replaces the generation jobs of a Steam account
+func (c *Config) SetSteamAccountJobs(steamId64 string, jobs []GenerationJob) error {
+	if err := ValidateGenerationJobs(jobs); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Steam.Accounts {
+		if c.Steam.Accounts[i].SteamID64 == steamId64 {
+			c.Steam.Accounts[i].Jobs = cloneGenerationJobs(jobs)
+			go c.scheduleSave()
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown Steam account %q", steamId64)
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultGenerationProfileID identifies the built-in profile made of the global settings
+const DefaultGenerationProfileID = "default"
+
+// GenerationProfile bundles the settings a target is generated with, so accounts can differ
+// (e.g. a mid-only main account and a support smurf). Jobs may still override the period,
+// the positions and the template.
+type GenerationProfile struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	BuiltIn      bool             `json:"builtIn"`
+	Positions    []PositionConfig `json:"positions"`
+	HeroesPerRow int              `json:"heroesPerRow"`
+	Period       string           `json:"period"`     // D2PT period
+	TemplateID   string           `json:"templateId"` // used when the target has no template
+	HeroLists    HeroListsConfig  `json:"heroLists"`
+}
+
+// EnabledPositionIDs returns the IDs of the enabled positions in order
+func (p GenerationProfile) EnabledPositionIDs() []string {
+	var result []string
+	for _, position := range p.Positions {
+		if position.Enabled {
+			result = append(result, position.ID)
+		}
+	}
+	return result
+}
+
+// clone returns a deep copy so callers can't modify the stored profile
+func (p GenerationProfile) clone() GenerationProfile {
+	p.Positions = slices.Clone(p.Positions)
+	p.HeroLists = p.HeroLists.normalized()
+	return p
+}
+
+// ValidateGenerationProfile checks that a profile can be stored and used for generation
+func ValidateGenerationProfile(profile GenerationProfile) error {
+	if !identifierRegex.MatchString(profile.ID) {
+		return fmt.Errorf("profile id %q must only contain letters, digits, '-' and '_'", profile.ID)
+	}
+	if strings.TrimSpace(profile.Name) == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if profile.HeroesPerRow < minHeroesPerRow || profile.HeroesPerRow > maxHeroesPerRow {
+		return fmt.Errorf("heroes per row must be between %d and %d, got %d", minHeroesPerRow, maxHeroesPerRow, profile.HeroesPerRow)
+	}
+	if !isValidD2PTPeriod(profile.Period) {
+		return fmt.Errorf("unknown period %q", profile.Period)
+	}
+
+	var seen []string
+	for _, position := range profile.Positions {
+		if !isKnownPositionID(position.ID) {
+			return fmt.Errorf("unknown position %q", position.ID)
+		}
+		if slices.Contains(seen, position.ID) {
+			return fmt.Errorf("duplicate position %q", position.ID)
+		}
+		seen = append(seen, position.ID)
+	}
+	return nil
+}
+
+func isKnownPositionID(id string) bool {
+	return slices.ContainsFunc(defaultPositions(), func(p PositionConfig) bool { return p.ID == id })
+}
+
+// --- Generation Profile Methods ---
+
+// defaultGenerationProfileLocked builds the built-in profile from the global settings; c.mu must be held
+func (c *Config) defaultGenerationProfileLocked() GenerationProfile {
+	return GenerationProfile{
+		ID:           DefaultGenerationProfileID,
+		Name:         "Global settings",
+		BuiltIn:      true,
+		Positions:    c.HeroesLayout.Positions,
+		HeroesPerRow: c.HeroesLayout.HeroesPerRow,
+		Period:       c.D2PT.Period,
+		HeroLists:    c.HeroesLayout.HeroLists,
+	}.clone()
+}
+
+// GetGenerationProfiles returns the built-in profile followed by the user-defined ones
+func (c *Config) GetGenerationProfiles() []GenerationProfile {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]GenerationProfile, 0, len(c.HeroesLayout.Profiles)+1)
+	result = append(result, c.defaultGenerationProfileLocked())
+	for _, p := range c.HeroesLayout.Profiles {
+		result = append(result, p.clone())
+	}
+	return result
+}
+
+// GetGenerationProfile returns the profile with the given ID, falling back to the global settings
+func (c *Config) GetGenerationProfile(id string) GenerationProfile {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, p := range c.HeroesLayout.Profiles {
+		if p.ID == id {
+			return p.clone()
+		}
+	}
+	return c.defaultGenerationProfileLocked()
+}
+
+// SaveGenerationProfile validates and adds a user-defined profile or replaces the one with the same ID.
+// The built-in profile is edited through the global settings instead.
+func (c *Config) SaveGenerationProfile(profile GenerationProfile) error {
+	if profile.ID == DefaultGenerationProfileID {
+		return fmt.Errorf("the global settings profile can't be modified")
+	}
+	if err := ValidateGenerationProfile(profile); err != nil {
+		return err
+	}
+	profile = profile.clone()
+	profile.BuiltIn = false
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.HeroesLayout.Profiles {
+		if c.HeroesLayout.Profiles[i].ID == profile.ID {
+			c.HeroesLayout.Profiles[i] = profile
+			go c.scheduleSave()
+			return nil
+		}
+	}
+
+	c.HeroesLayout.Profiles = append(c.HeroesLayout.Profiles, profile)
+	go c.scheduleSave()
+	return nil
+}
+
+// RemoveGenerationProfile removes a user-defined profile. Accounts and files using it
+// fall back to the global settings.
+func (c *Config) RemoveGenerationProfile(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, p := range c.HeroesLayout.Profiles {
+		if p.ID == id {
+			c.HeroesLayout.Profiles = append(c.HeroesLayout.Profiles[:i], c.HeroesLayout.Profiles[i+1:]...)
+			go c.scheduleSave()
+			return
+		}
+	}
+}
+
+// SetHeroesLayoutFileProfile selects the generation profile used for a file
+func (c *Config) SetHeroesLayoutFileProfile(filePath string, profileID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.HeroesLayout.Files {
+		if c.HeroesLayout.Files[i].FilePath == filePath {
+			c.HeroesLayout.Files[i].ProfileID = profileID
+			go c.scheduleSave()
+			return
+		}
+	}
+}
+
+// SetSteamAccountProfile selects the generation profile used for a Steam account
+func (c *Config) SetSteamAccountProfile(steamId64 string, profileID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Steam.Accounts {
+		if c.Steam.Accounts[i].SteamID64 == steamId64 {
+			c.Steam.Accounts[i].ProfileID = profileID
+			go c.scheduleSave()
+			return
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// GridMirror copies a hand-made hero grid config of one Steam account into every other enabled account
+type GridMirror struct {
+	ID              string `json:"id"`
+	SourceSteamID64 string `json:"sourceSteamId64"`
+	ConfigName      string `json:"configName"`
+	AutoSync        bool   `json:"autoSync"` // synced after every periodic update
+	// SyncedHashes holds the hash of the config last written into each target account. A target whose
+	// config no longer matches it was edited there, and is a conflict rather than an outdated copy.
+	SyncedHashes            map[string]string `json:"syncedHashes"`
+	LastSyncTimestampMillis int64             `json:"lastSyncTimestampMillis"`
+	LastSyncErrorMessage    string            `json:"lastSyncErrorMessage"`
+	LastSyncConflicts       []string          `json:"lastSyncConflicts"` // steamId64s of the conflicting targets
+}
+
+// ValidateGridMirror checks the mirror ID, source and config name
+func ValidateGridMirror(mirror GridMirror) error {
+	if !identifierRegex.MatchString(mirror.ID) {
+		return fmt.Errorf("id %q must only contain letters, digits, '-' and '_'", mirror.ID)
+	}
+	if mirror.SourceSteamID64 == "" {
+		return fmt.Errorf("source account is required")
+	}
+	if strings.TrimSpace(mirror.ConfigName) == "" {
+		return fmt.Errorf("config name is required")
+	}
+	return nil
+}
+
+func (m GridMirror) clone() GridMirror {
+	m.SyncedHashes = maps.Clone(m.SyncedHashes)
+	m.LastSyncConflicts = slices.Clone(m.LastSyncConflicts)
+	return m
+}
+
+func cloneGridMirrors(mirrors []GridMirror) []GridMirror {
+	if mirrors == nil {
+		return nil
+	}
+	result := make([]GridMirror, len(mirrors))
+	for i, mirror := range mirrors {
+		result[i] = mirror.clone()
+	}
+	return result
+}
+
+// --- Grid Mirror Methods ---
+
+// GetGridMirrors returns a copy of the configured grid mirrors
+func (c *Config) GetGridMirrors() []GridMirror {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return cloneGridMirrors(c.Steam.Mirrors)
+}
+
+// GetGridMirror returns the mirror with the given ID
+func (c *Config) GetGridMirror(id string) (GridMirror, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, mirror := range c.Steam.Mirrors {
+		if mirror.ID == id {
+			return mirror.clone(), true
+		}
+	}
+	return GridMirror{}, false
+}
+
+// SaveGridMirror adds a mirror or updates the settings of the one with the same ID. The sync state
+// is kept, unless the source changed and the previously mirrored copies no longer belong to it.
+func (c *Config) SaveGridMirror(mirror GridMirror) error {
+	if err := ValidateGridMirror(mirror); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Steam.Mirrors {
+		existing := &c.Steam.Mirrors[i]
+		if existing.ID != mirror.ID {
+			continue
+		}
+		if existing.SourceSteamID64 != mirror.SourceSteamID64 || existing.ConfigName != mirror.ConfigName {
+			existing.SyncedHashes = nil
+			existing.LastSyncConflicts = nil
+		}
+		existing.SourceSteamID64 = mirror.SourceSteamID64
+		existing.ConfigName = mirror.ConfigName
+		existing.AutoSync = mirror.AutoSync
+		go c.scheduleSave()
+		return nil
+	}
+
+	c.Steam.Mirrors = append(c.Steam.Mirrors, GridMirror{
+		ID:              mirror.ID,
+		SourceSteamID64: mirror.SourceSteamID64,
+		ConfigName:      mirror.ConfigName,
+		AutoSync:        mirror.AutoSync,
+	})
+	go c.scheduleSave()
+	return nil
+}
+
+// RemoveGridMirror removes a mirror; the copies already written to the targets are kept
+func (c *Config) RemoveGridMirror(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, mirror := range c.Steam.Mirrors {
+		if mirror.ID == id {
+			c.Steam.Mirrors = append(c.Steam.Mirrors[:i], c.Steam.Mirrors[i+1:]...)
+			go c.scheduleSave()
+			return
+		}
+	}
+}
+
+// UpdateGridMirrorSync records the outcome of a mirror sync
+func (c *Config) UpdateGridMirrorSync(id string, timestampMillis int64, syncedHashes map[string]string, conflicts []string, errorMessage string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Steam.Mirrors {
+		if c.Steam.Mirrors[i].ID == id {
+			c.Steam.Mirrors[i].LastSyncTimestampMillis = timestampMillis
+			c.Steam.Mirrors[i].SyncedHashes = maps.Clone(syncedHashes)
+			c.Steam.Mirrors[i].LastSyncConflicts = slices.Clone(conflicts)
+			c.Steam.Mirrors[i].LastSyncErrorMessage = errorMessage
+			go c.scheduleSave()
+			return
+		}
+	}
+}
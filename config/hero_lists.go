@@ -0,0 +1,67 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+// HeroListsConfig contains hero IDs that override which heroes appear in generated sections
+type HeroListsConfig struct {
+	Exclude []int            `json:"exclude"` // never shown, takes precedence over the other lists
+	Include []int            `json:"include"` // hero pool mode: when not empty only these heroes are shown
+	Pinned  map[string][]int `json:"pinned"`  // position ID -> heroes always shown first in every section of the position
+}
+
+func defaultHeroListsConfig() HeroListsConfig {
+	return HeroListsConfig{
+		Exclude: []int{},
+		Include: []int{},
+		Pinned:  map[string][]int{},
+	}
+}
+
+// normalized returns a deep copy with nil lists replaced and duplicate IDs removed, keeping the first occurrence
+func (l HeroListsConfig) normalized() HeroListsConfig {
+	result := defaultHeroListsConfig()
+	result.Exclude = uniqueHeroIDs(l.Exclude)
+	result.Include = uniqueHeroIDs(l.Include)
+	for positionID, heroIDs := range l.Pinned {
+		if len(heroIDs) > 0 {
+			result.Pinned[positionID] = uniqueHeroIDs(heroIDs)
+		}
+	}
+	return result
+}
+
+func uniqueHeroIDs(heroIDs []int) []int {
+	result := make([]int, 0, len(heroIDs))
+	for _, id := range heroIDs {
+		if id > 0 && !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// --- Hero Lists Methods ---
+
+func (c *Config) GetHeroLists() HeroListsConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	lists := c.HeroesLayout.HeroLists
+	lists.Exclude = slices.Clone(lists.Exclude)
+	lists.Include = slices.Clone(lists.Include)
+	lists.Pinned = maps.Clone(lists.Pinned)
+	for positionID, heroIDs := range lists.Pinned {
+		lists.Pinned[positionID] = slices.Clone(heroIDs)
+	}
+	return lists
+}
+
+func (c *Config) SetHeroLists(lists HeroListsConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HeroesLayout.HeroLists = lists.normalized()
+	go c.scheduleSave()
+}
@@ -0,0 +1,252 @@
+package config
+
+import "slices"
+
+// DefaultLayoutTemplateID identifies the built-in layout template
+const DefaultLayoutTemplateID = "default"
+
+// Section sort keys understood by the layout generator
+const (
+	SortByRating  = "rating"
+	SortByMatches = "matches"
+	// SortByRatingChange ranks heroes by rating gained over the section TrendDays
+	SortByRatingChange = "ratingChange"
+)
+
+// Section groupings understood by the layout generator
+const (
+	GroupByNone       = ""
+	GroupByAttribute  = "attribute"
+	GroupByAttackType = "attackType"
+)
+
+// Ways a section handles the facet variants of a hero
+const (
+	// FacetsMerge shows one entry per hero with the stats of all facets summed
+	FacetsMerge = ""
+	// FacetsBest shows one entry per hero with the stats of its best-rated facet
+	FacetsBest = "best"
+	// FacetsSplit shows one entry per facet, so a hero can appear once for each of its facets
+	FacetsSplit = "split"
+)
+
+// Matchup sections, listing the heroes of a position by their record against or with MatchupHeroes
+const (
+	MatchupNone = ""
+	// MatchupCounters adds one section per matchup hero with the heroes winning most against it
+	MatchupCounters = "counters"
+	// MatchupPartners adds one section with the heroes winning most alongside the matchup heroes
+	MatchupPartners = "partners"
+)
+
+// Auto-fit modes applied when a generated grid is larger than Dota's grid canvas
+const (
+	AutoFitNone = ""
+	// AutoFitHeroesPerRow lowers the heroes per row until the grid is narrow enough
+	AutoFitHeroesPerRow = "heroesPerRow"
+	// AutoFitScale shrinks the hero cards and spacings until the grid fits
+	AutoFitScale = "scale"
+)
+
+// LayoutTemplate declares the sections and geometry of a generated hero grid.
+// Sections are repeated for every enabled position, in order.
+type LayoutTemplate struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	BuiltIn        bool            `json:"builtIn"`
+	Geometry       LayoutGeometry  `json:"geometry"`
+	Sections       []LayoutSection `json:"sections"`
+	HideRowHeaders bool            `json:"hideRowHeaders"` // drops the label headers and the space reserved for them
+	AutoFit        string          `json:"autoFit"`
+}
+
+// LayoutGeometry contains the pixel sizes used to place categories on the grid
+type LayoutGeometry struct {
+	HeroWidth       int `json:"heroWidth"`
+	HeroHeight      int `json:"heroHeight"`
+	HeaderWidth     int `json:"headerWidth"`     // width reserved for row header labels
+	InfoHeight      int `json:"infoHeight"`      // height of a section title
+	LabelSpacing    int `json:"labelSpacing"`    // vertical distance between stacked labels
+	RowSpacing      int `json:"rowSpacing"`      // extra space between hero rows
+	CategorySpacing int `json:"categorySpacing"` // space after each section
+	ColumnSpacing   int `json:"columnSpacing"`   // space between the columns of a grouped section
+}
+
+// LayoutSection describes one list of heroes generated per position.
+// A grouped section is split into side by side columns, one per attribute or attack type,
+// and Count then applies to every column.
+type LayoutSection struct {
+	Title              string         `json:"title"` // "{position}" is replaced with the position name, "{hero}" with the matchup heroes
+	SortBy             string         `json:"sortBy"`
+	Count              int            `json:"count"`
+	Filters            SectionFilters `json:"filters"`
+	Labels             []LabelFormat  `json:"labels"`
+	GroupBy            string         `json:"groupBy"`
+	ColumnHeroesPerRow int            `json:"columnHeroesPerRow"` // heroes per row in each column, 0 splits the heroes per row setting evenly
+	TrendDays          int            `json:"trendDays"`          // window of the ratingChange sort, 0 means 7 days
+	Facets             string         `json:"facets"`
+	Matchup            string         `json:"matchup"`
+	MatchupHeroes      []int          `json:"matchupHeroes"` // faced heroes of counter sections, hero pool of partner sections
+}
+
+// SectionFilters restrict which heroes are eligible for a section
+type SectionFilters struct {
+	MinMatches int     `json:"minMatches"`
+	MinWinrate float64 `json:"minWinrate"` // percent, 0 disables the filter
+}
+
+// LabelFormat is one line of text rendered above each hero.
+// Header is shown once at the start of every row; Format is rendered per hero
+// with the placeholders {winrate}, {matches}, {rating} and {rank} (place in the section),
+// {winrateDelta} and {ratingDelta} showing the change since the previous update,
+// empty while there is no history, and {facet} and {facetWinrate} showing the facet of the entry,
+// or the best facet of a merged hero, as "F2" and its winrate.
+type LabelFormat struct {
+	Header string `json:"header"`
+	Format string `json:"format"`
+}
+
+// DefaultLayoutTemplate returns the built-in layout: top 10 heroes by rating
+// and top 30 heroes by matches for every position
+func DefaultLayoutTemplate() LayoutTemplate {
+	labels := []LabelFormat{
+		{Header: "Winrate", Format: "  {winrate}"},
+		{Header: "Matches", Format: "  {matches}"},
+	}
+
+	return LayoutTemplate{
+		ID:      DefaultLayoutTemplateID,
+		Name:    "Default",
+		BuiltIn: true,
+		Geometry: LayoutGeometry{
+			HeroWidth:       70,
+			HeroHeight:      110,
+			HeaderWidth:     100,
+			InfoHeight:      30,
+			LabelSpacing:    20,
+			RowSpacing:      30,
+			CategorySpacing: 50,
+			ColumnSpacing:   20,
+		},
+		Sections: []LayoutSection{
+			{
+				Title:  "{position} - Top Rating Heroes",
+				SortBy: SortByRating,
+				Count:  10,
+				Labels: slices.Clone(labels),
+			},
+			{
+				Title:  "{position} - Most Matches Heroes",
+				SortBy: SortByMatches,
+				Count:  30,
+				Labels: slices.Clone(labels),
+			},
+		},
+	}
+}
+
+// clone returns a deep copy so callers can't modify the stored template
+func (t LayoutTemplate) clone() LayoutTemplate {
+	t.Sections = slices.Clone(t.Sections)
+	for i := range t.Sections {
+		t.Sections[i].Labels = slices.Clone(t.Sections[i].Labels)
+		t.Sections[i].MatchupHeroes = slices.Clone(t.Sections[i].MatchupHeroes)
+	}
+	return t
+}
+
+// --- Layout Template Methods ---
+
+// GetLayoutTemplates returns the built-in template followed by the user-defined ones
+func (c *Config) GetLayoutTemplates() []LayoutTemplate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]LayoutTemplate, 0, len(c.HeroesLayout.Templates)+1)
+	result = append(result, DefaultLayoutTemplate())
+	for _, t := range c.HeroesLayout.Templates {
+		result = append(result, t.clone())
+	}
+	return result
+}
+
+// GetLayoutTemplate returns the template with the given ID, falling back to the built-in default
+func (c *Config) GetLayoutTemplate(id string) LayoutTemplate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, t := range c.HeroesLayout.Templates {
+		if t.ID == id {
+			return t.clone()
+		}
+	}
+	return DefaultLayoutTemplate()
+}
+
+// SaveLayoutTemplate adds a user-defined template or replaces the one with the same ID.
+// The built-in template can't be overwritten.
+func (c *Config) SaveLayoutTemplate(template LayoutTemplate) bool {
+	if template.ID == DefaultLayoutTemplateID {
+		return false
+	}
+	template = template.clone()
+	template.BuiltIn = false
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.HeroesLayout.Templates {
+		if c.HeroesLayout.Templates[i].ID == template.ID {
+			c.HeroesLayout.Templates[i] = template
+			go c.scheduleSave()
+			return true
+		}
+	}
+
+	c.HeroesLayout.Templates = append(c.HeroesLayout.Templates, template)
+	go c.scheduleSave()
+	return true
+}
+
+// RemoveLayoutTemplate removes a user-defined template. Accounts and files using it
+// fall back to the built-in default.
+func (c *Config) RemoveLayoutTemplate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, t := range c.HeroesLayout.Templates {
+		if t.ID == id {
+			c.HeroesLayout.Templates = append(c.HeroesLayout.Templates[:i], c.HeroesLayout.Templates[i+1:]...)
+			go c.scheduleSave()
+			return
+		}
+	}
+}
+
+// SetHeroesLayoutFileTemplate selects the layout template used for a file
+func (c *Config) SetHeroesLayoutFileTemplate(filePath string, templateID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.HeroesLayout.Files {
+		if c.HeroesLayout.Files[i].FilePath == filePath {
+			c.HeroesLayout.Files[i].TemplateID = templateID
+			go c.scheduleSave()
+			return
+		}
+	}
+}
+
+// SetSteamAccountTemplate selects the layout template used for a Steam account
+func (c *Config) SetSteamAccountTemplate(steamId64 string, templateID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.Steam.Accounts {
+		if c.Steam.Accounts[i].SteamID64 == steamId64 {
+			c.Steam.Accounts[i].TemplateID = templateID
+			go c.scheduleSave()
+			return
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package main
 
 const (
-	EventSteamPathChanged        = "steamPathChanged"
-	EventSteamAccountsChanged    = "steamAccountsChanged"
-	EventHeroesLayoutDataChanged = "heroesLayoutDataChanged"
-	EventAppUpdateDataChanged    = "appUpdateDataChanged"
+	EventSteamPathChanged          = "steamPathChanged"
+	EventSteamAccountsChanged      = "steamAccountsChanged"
+	EventHeroesLayoutDataChanged   = "heroesLayoutDataChanged"
+	EventAppUpdateDataChanged      = "appUpdateDataChanged"
+	EventAppUpdateDownloadProgress = "appUpdateDownloadProgress"
 )
@@ -1,6 +1,11 @@
-import { steam } from '../../wailsjs/go/models'
+import { config, steam } from '../../wailsjs/go/models'
+import { useState } from 'react'
 import { UserIcon } from './Icons'
+import GenerationJobsEditor from './GenerationJobsEditor'
+import GridPreviewPanel from './GridPreviewPanel'
 import RelativeTime from './RelativeTime'
+import TemplateSelect from './TemplateSelect'
+import ProfileSelect from './ProfileSelect'
 
 interface AccountCardProps {
   account: steam.SteamAccountView
@@ -8,9 +13,21 @@ interface AccountCardProps {
     checked: boolean
     onChange: (enabled: boolean) => void
   }
+  templates?: {
+    options: config.LayoutTemplate[]
+    onChange: (templateId: string) => void
+    onSaveJobs?: (jobs: config.GenerationJob[]) => Promise<void>
+  }
+  profiles?: {
+    options: config.GenerationProfile[]
+    onChange: (profileId: string) => void
+  }
 }
 
-function AccountCard({ account, toggle }: AccountCardProps) {
+function AccountCard({ account, toggle, templates, profiles }: AccountCardProps) {
+  const [jobsOpen, setJobsOpen] = useState(false)
+  const [previewOpen, setPreviewOpen] = useState(false)
+
   return (
     <div className={`file-card ${toggle && !account.enabled ? 'disabled' : ''} ${account.lastUpdateErrorMessage ? 'has-error' : ''}`}>
       <div className="account-card-header">
@@ -40,12 +57,43 @@ function AccountCard({ account, toggle }: AccountCardProps) {
             <span className="account-username">{account.accountName}</span>
           )}
         </div>
+        {profiles && (
+          <ProfileSelect
+            profiles={profiles.options}
+            value={account.profileId}
+            onChange={profiles.onChange}
+          />
+        )}
+        {templates && (
+          <TemplateSelect
+            templates={templates.options}
+            value={account.templateId}
+            onChange={templates.onChange}
+          />
+        )}
+        {templates?.onSaveJobs && (
+          <button className="btn btn-secondary btn-sm" onClick={() => setJobsOpen(!jobsOpen)} title="Generated grids">
+            Grids ({account.jobs?.length || 1})
+          </button>
+        )}
+        {templates && (
+          <button className="btn btn-secondary btn-sm" onClick={() => setPreviewOpen(!previewOpen)} title="Preview the hero grids">
+            Preview
+          </button>
+        )}
       </div>
+      {jobsOpen && templates?.onSaveJobs && (
+        <GenerationJobsEditor jobs={account.jobs} templates={templates.options} onSave={templates.onSaveJobs} />
+      )}
+      {previewOpen && <GridPreviewPanel steamId64={account.steamId64} />}
       <div className="file-card-footer">
         <RelativeTime timestampMillis={account.lastUpdateTimestampMillis} prefix="Updated: " />
         {account.lastUpdateErrorMessage && (
           <span className="file-error">{account.lastUpdateErrorMessage}</span>
         )}
+        {account.lastUpdateWarnings?.map((warning) => (
+          <span key={warning} className="file-warning">{warning}</span>
+        ))}
       </div>
     </div>
   )
@@ -0,0 +1,124 @@
+import { useEffect, useState } from 'react'
+import { GetCompositeConfig, GetHeroesProviderNames, SetCompositeConfig } from '../../wailsjs/go/main/App'
+import { config } from '../../wailsjs/go/models'
+import ProviderTestPanel from './ProviderTestPanel'
+
+const COMPOSITE_PROVIDER = 'composite'
+
+interface CompositeProviderCardProps {
+  onSaved: () => void
+}
+
+function CompositeProviderCard({ onSaved }: CompositeProviderCardProps) {
+  const [composite, setComposite] = useState<config.CompositeConfig | null>(null)
+  const [providerNames, setProviderNames] = useState<string[]>([])
+  const [newSource, setNewSource] = useState('')
+  const [message, setMessage] = useState<string | null>(null)
+
+  useEffect(() => {
+    GetCompositeConfig().then(setComposite).catch(console.error)
+    GetHeroesProviderNames().then(names => setProviderNames((names ?? []).filter(name => name !== COMPOSITE_PROVIDER))).catch(console.error)
+  }, [])
+
+  if (!composite) return null
+
+  const sources = composite.sources ?? []
+  const unusedProviders = providerNames.filter(name => !sources.some(source => source.provider === name))
+
+  const save = async (updated: config.CompositeConfig) => {
+    setMessage(null)
+    try {
+      await SetCompositeConfig(updated)
+      setComposite(updated)
+      onSaved()
+    } catch (error) {
+      console.error('Error saving composite provider:', error)
+      setMessage(`Failed to save: ${error}`)
+    }
+  }
+
+  const updateSources = (updated: config.CompositeSourceConfig[]) => {
+    save(config.CompositeConfig.createFrom({ ...composite, sources: updated }))
+  }
+
+  const moveSource = (index: number, offset: number) => {
+    const updated = [...sources]
+    const [source] = updated.splice(index, 1)
+    updated.splice(index + offset, 0, source)
+    updateSources(updated)
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Composite</h2>
+      </div>
+      <div className="card-body">
+        <div className="setting-row">
+          <div className="setting-info">
+            <div className="setting-label">Mode</div>
+            <div className="setting-description">
+              Merge blends the weighted stats of every source, fallback uses the first source that answers
+            </div>
+          </div>
+          <select
+            className="select"
+            value={composite.mode}
+            onChange={(e) => save(config.CompositeConfig.createFrom({ ...composite, mode: e.target.value }))}
+          >
+            <option value="merge">Merge</option>
+            <option value="fallback">Fallback</option>
+          </select>
+        </div>
+        {sources.map((source, index) => (
+          <div key={source.provider} className="composite-source">
+            <div className="composite-source-name">{index + 1}. {source.provider}</div>
+            <input
+              type="number"
+              className="select"
+              min={0.1}
+              step={0.1}
+              title="Weight"
+              defaultValue={source.weight}
+              disabled={composite.mode === 'fallback'}
+              onBlur={(e) => {
+                const weight = Number(e.target.value)
+                if (weight > 0 && weight !== source.weight) {
+                  updateSources(sources.map((s, i) => i === index ? { ...s, weight } : s))
+                }
+              }}
+            />
+            <button className="btn btn-secondary btn-sm" onClick={() => moveSource(index, -1)} disabled={index === 0}>Up</button>
+            <button className="btn btn-secondary btn-sm" onClick={() => moveSource(index, 1)} disabled={index === sources.length - 1}>Down</button>
+            <button className="btn btn-secondary btn-sm" onClick={() => updateSources(sources.filter((_, i) => i !== index))}>Remove</button>
+          </div>
+        ))}
+        <div className="template-actions">
+          <select className="select" value={newSource} onChange={(e) => setNewSource(e.target.value)}>
+            <option value="">Provider</option>
+            {unusedProviders.map((name) => (
+              <option key={name} value={name}>{name}</option>
+            ))}
+          </select>
+          <button
+            className="btn btn-primary btn-sm"
+            onClick={() => {
+              updateSources([...sources, config.CompositeSourceConfig.createFrom({ provider: newSource, weight: 1 })])
+              setNewSource('')
+            }}
+            disabled={!newSource}
+          >
+            Add Source
+          </button>
+        </div>
+        <ProviderTestPanel provider={COMPOSITE_PROVIDER} disabled={sources.length === 0} />
+        {message && <div className="file-error">{message}</div>}
+        <div className="card-hint">
+          Jobs with the provider "composite" use these sources, so a grid keeps updating when one site is down. Sources listing a hero are averaged by weight; testing the provider shows the number each source reported as rating (matches).
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default CompositeProviderCard
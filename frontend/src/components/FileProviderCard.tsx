@@ -0,0 +1,72 @@
+import { useEffect, useState } from 'react'
+import { GetFileProviderConfig, SelectFileProviderSource, SetFileProviderSource } from '../../wailsjs/go/main/App'
+import ProviderTestPanel from './ProviderTestPanel'
+
+interface FileProviderCardProps {
+  onSaved: () => void
+}
+
+function FileProviderCard({ onSaved }: FileProviderCardProps) {
+  const [source, setSource] = useState('')
+  const [savedSource, setSavedSource] = useState('')
+  const [error, setError] = useState<string | null>(null)
+
+  useEffect(() => {
+    GetFileProviderConfig()
+      .then((cfg) => {
+        setSource(cfg.source)
+        setSavedSource(cfg.source)
+      })
+      .catch(console.error)
+  }, [])
+
+  const save = async (updated: string) => {
+    setError(null)
+    try {
+      await SetFileProviderSource(updated)
+      setSource(updated)
+      setSavedSource(updated.trim())
+      onSaved()
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  const handleBrowse = async () => {
+    try {
+      const selection = await SelectFileProviderSource()
+      if (selection) {
+        await save(selection)
+      }
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Team File</h2>
+      </div>
+      <div className="card-body">
+        <div className="template-actions">
+          <input
+            className="select file-provider-source"
+            placeholder="Path or https:// URL of a JSON, CSV or YAML file"
+            value={source}
+            onChange={(e) => setSource(e.target.value)}
+            onBlur={() => source.trim() !== savedSource && save(source)}
+          />
+          <button className="btn btn-secondary btn-sm" onClick={handleBrowse}>Browse</button>
+        </div>
+        {error && <div className="file-error">{error}</div>}
+        <ProviderTestPanel provider="file" disabled={!savedSource} />
+        <div className="card-hint">
+          Select the provider "file" for a grid, or add it as a composite source, to use a team-curated tier list. JSON and YAML files list heroes per position, e.g. {'{"positions": {"1": [{"hero_id": 1, "d2pt_rating": 1450}]}}'}; CSV files have a header row with position and hero_id columns. Optional fields are hero_name, matches, wins, d2pt_rating and facet. A local file is read again when it changes, a URL every 10 minutes.
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default FileProviderCard
@@ -0,0 +1,161 @@
+import { useEffect, useState } from 'react'
+import { GetHeroesProviderNames } from '../../wailsjs/go/main/App'
+import { config } from '../../wailsjs/go/models'
+import { PlusIcon, TrashIcon } from './Icons'
+
+interface GenerationJobsEditorProps {
+  jobs: config.GenerationJob[] | null
+  templates: config.LayoutTemplate[]
+  onSave: (jobs: config.GenerationJob[]) => Promise<void>
+}
+
+const periodOptions = [
+  { value: '', label: 'Profile period' },
+  { value: '8', label: 'Last 8 days' },
+  { value: 'patch', label: 'Current patch' },
+]
+
+const positionIds = ['1', '2', '3', '4', '5']
+
+// Job IDs are written into the generated config names, so they are derived once and never edited
+const uniqueJobId = (name: string, jobs: config.GenerationJob[]) => {
+  const base = name.toLowerCase().replace(/[^a-z0-9]+/g, '-').replace(/^-+|-+$/g, '') || 'job'
+  let id = base
+  for (let i = 2; jobs.some(j => j.id === id); i++) {
+    id = `${base}-${i}`
+  }
+  return id
+}
+
+function GenerationJobsEditor({ jobs, templates, onSave }: GenerationJobsEditorProps) {
+  const [draft, setDraft] = useState<config.GenerationJob[]>(jobs ?? [])
+  const [providerNames, setProviderNames] = useState<string[]>([])
+  const [error, setError] = useState<string | null>(null)
+
+  useEffect(() => {
+    setDraft(jobs ?? [])
+  }, [jobs])
+
+  useEffect(() => {
+    GetHeroesProviderNames().then(names => setProviderNames(names ?? [])).catch(console.error)
+  }, [])
+
+  const updateJob = (index: number, changes: Partial<config.GenerationJob>) => {
+    setDraft(draft.map((job, i) => i === index ? config.GenerationJob.createFrom({ ...job, ...changes }) : job))
+  }
+
+  const togglePosition = (index: number, positionId: string) => {
+    const positions = draft[index].positions ?? []
+    updateJob(index, {
+      positions: positions.includes(positionId)
+        ? positions.filter(p => p !== positionId)
+        : positionIds.filter(p => p === positionId || positions.includes(p)),
+    })
+  }
+
+  const handleAdd = () => {
+    const name = `Grid ${draft.length + 1}`
+    setDraft([...draft, config.GenerationJob.createFrom({
+      id: uniqueJobId(name, draft),
+      name,
+      displayName: '',
+      provider: '',
+      period: '',
+      positions: [],
+      templateId: '',
+    })])
+  }
+
+  const handleSave = async () => {
+    setError(null)
+    try {
+      await onSave(draft)
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  return (
+    <div className="jobs-editor">
+      {draft.length === 0 && (
+        <div className="card-hint">No jobs: one "Heroes Meta" grid is generated with the profile settings</div>
+      )}
+      {draft.map((job, index) => (
+        <div key={job.id} className="job-row">
+          <input
+            className="select select-sm"
+            value={job.name}
+            onChange={(e) => updateJob(index, { name: e.target.value })}
+            title={`Grid name (id: ${job.id})`}
+          />
+          <input
+            className="select select-sm"
+            value={job.displayName}
+            placeholder={`[D2T:${job.id}] ${job.name} {date}`}
+            onChange={(e) => updateJob(index, { displayName: e.target.value })}
+            title="Config name shown in Dota, {date} is replaced with the update date"
+          />
+          <select
+            className="select select-sm"
+            value={job.provider}
+            onChange={(e) => updateJob(index, { provider: e.target.value })}
+            title="Heroes provider"
+          >
+            <option value="">Default provider</option>
+            {providerNames.map((name) => (
+              <option key={name} value={name}>{name}</option>
+            ))}
+          </select>
+          <select
+            className="select select-sm"
+            value={job.period}
+            onChange={(e) => updateJob(index, { period: e.target.value })}
+          >
+            {periodOptions.map((option) => (
+              <option key={option.value} value={option.value}>{option.label}</option>
+            ))}
+          </select>
+          <select
+            className="select select-sm"
+            value={job.templateId}
+            onChange={(e) => updateJob(index, { templateId: e.target.value })}
+            title="Layout template"
+          >
+            <option value="">Target template</option>
+            {templates.map((template) => (
+              <option key={template.id} value={template.id}>{template.name}</option>
+            ))}
+          </select>
+          <div className="job-positions" title="Positions, none selected uses the profile positions">
+            {positionIds.map((positionId) => (
+              <button
+                key={positionId}
+                className={`btn btn-sm ${(job.positions ?? []).includes(positionId) ? 'btn-primary' : 'btn-secondary'}`}
+                onClick={() => togglePosition(index, positionId)}
+              >
+                {positionId}
+              </button>
+            ))}
+          </div>
+          <button
+            className="btn btn-icon btn-danger"
+            onClick={() => setDraft(draft.filter((_, i) => i !== index))}
+            title="Remove job"
+          >
+            <TrashIcon />
+          </button>
+        </div>
+      ))}
+      {error && <div className="file-error">{error}</div>}
+      <div className="template-actions">
+        <button className="btn btn-secondary btn-sm" onClick={handleAdd}>
+          <PlusIcon />
+          <span>Add grid</span>
+        </button>
+        <button className="btn btn-primary btn-sm" onClick={handleSave}>Save jobs</button>
+      </div>
+    </div>
+  )
+}
+
+export default GenerationJobsEditor
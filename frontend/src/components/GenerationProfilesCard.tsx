@@ -0,0 +1,99 @@
+import { useEffect, useState } from 'react'
+import { RemoveGenerationProfile, SaveGenerationProfile } from '../../wailsjs/go/main/App'
+import { config } from '../../wailsjs/go/models'
+
+interface GenerationProfilesCardProps {
+  profiles: config.GenerationProfile[]
+  onChanged: () => void
+}
+
+function GenerationProfilesCard({ profiles, onChanged }: GenerationProfilesCardProps) {
+  const [selectedId, setSelectedId] = useState('default')
+  const [draft, setDraft] = useState('')
+  const [error, setError] = useState<string | null>(null)
+  const [isSaving, setIsSaving] = useState(false)
+
+  const selected = profiles.find(p => p.id === selectedId) ?? profiles[0]
+
+  useEffect(() => {
+    if (selected) {
+      setDraft(JSON.stringify(selected, null, 2))
+      setError(null)
+    }
+  }, [selected])
+
+  const handleNew = () => {
+    if (!selected) return
+    const copy = { ...selected, id: `profile-${Date.now()}`, name: `${selected.name} (copy)`, builtIn: false }
+    setDraft(JSON.stringify(copy, null, 2))
+    setError(null)
+  }
+
+  const handleSave = async () => {
+    setIsSaving(true)
+    setError(null)
+    try {
+      const profile = config.GenerationProfile.createFrom(JSON.parse(draft))
+      await SaveGenerationProfile(profile)
+      setSelectedId(profile.id)
+      onChanged()
+    } catch (err) {
+      setError(`${err}`)
+    } finally {
+      setIsSaving(false)
+    }
+  }
+
+  const handleDelete = async () => {
+    if (!selected || selected.builtIn) return
+    try {
+      await RemoveGenerationProfile(selected.id)
+      setSelectedId('default')
+      onChanged()
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Generation Profiles</h2>
+      </div>
+      <div className="card-body">
+        <div className="setting-row">
+          <div className="setting-info">
+            <div className="setting-label">Profile</div>
+            <div className="setting-description">
+              Positions, heroes per row, period, template and hero lists used for an account or file
+            </div>
+          </div>
+          <select className="select" value={selected?.id} onChange={(e) => setSelectedId(e.target.value)}>
+            {profiles.map((profile) => (
+              <option key={profile.id} value={profile.id}>{profile.name}</option>
+            ))}
+          </select>
+        </div>
+        <textarea
+          className="template-editor"
+          spellCheck={false}
+          value={draft}
+          onChange={(e) => setDraft(e.target.value)}
+        />
+        {error && <div className="file-error">{error}</div>}
+        <div className="template-actions">
+          <button className="btn btn-secondary btn-sm" onClick={handleNew}>Duplicate</button>
+          <button className="btn btn-danger btn-sm" onClick={handleDelete} disabled={!selected || selected.builtIn}>Delete</button>
+          <button className="btn btn-primary btn-sm" onClick={handleSave} disabled={isSaving}>
+            {isSaving ? 'Saving...' : 'Save'}
+          </button>
+        </div>
+        <div className="card-hint">
+          The "Global settings" profile mirrors the settings on this page and the Providers page; duplicate it to give an account its own positions (e.g. mid only). Period is "8" or "patch". An empty templateId uses the account template.
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default GenerationProfilesCard
@@ -0,0 +1,76 @@
+import { useState } from 'react'
+import { RepairHeroesLayoutFile, RepairSteamAccountGrid, ValidateHeroesLayoutFile, ValidateSteamAccountGrid } from '../../wailsjs/go/main/App'
+import { heroesLayout } from '../../wailsjs/go/models'
+
+// The grid file of a Steam account or a custom config file is checked
+interface GridCheckPanelProps {
+  steamId64?: string
+  filePath?: string
+  onRepaired: () => void
+}
+
+function GridCheckPanel({ steamId64, filePath, onRepaired }: GridCheckPanelProps) {
+  const [report, setReport] = useState<heroesLayout.GridValidationReport | null>(null)
+  const [repair, setRepair] = useState<heroesLayout.GridRepairResult | null>(null)
+  const [message, setMessage] = useState<string | null>(null)
+  const [isRepairing, setIsRepairing] = useState(false)
+
+  const handleCheck = async () => {
+    setMessage(null)
+    try {
+      setReport(await (steamId64 ? ValidateSteamAccountGrid(steamId64) : ValidateHeroesLayoutFile(filePath ?? '')))
+    } catch (error) {
+      console.error('Error checking grid file:', error)
+      setMessage(`Failed to check file: ${error}`)
+    }
+  }
+
+  const handleRepair = async () => {
+    setIsRepairing(true)
+    setMessage(null)
+    try {
+      setRepair(await (steamId64 ? RepairSteamAccountGrid(steamId64) : RepairHeroesLayoutFile(filePath ?? '')))
+      await handleCheck()
+      onRepaired()
+    } catch (error) {
+      console.error('Error repairing grid file:', error)
+      setMessage(`Failed to repair file: ${error}`)
+    } finally {
+      setIsRepairing(false)
+    }
+  }
+
+  return (
+    <div className="grid-check">
+      <div className="grid-preview-actions">
+        <button className="btn btn-secondary btn-sm" onClick={handleCheck}>Check File</button>
+        {report?.repairable && (
+          <button className="btn btn-secondary btn-sm" onClick={handleRepair} disabled={isRepairing}>
+            {isRepairing ? 'Repairing...' : 'Repair'}
+          </button>
+        )}
+        {report && (
+          <span className="grid-preview-message">
+            Version {report.version}, {report.configCount} grids{report.issues?.length ? '' : ', no problems found'}
+          </span>
+        )}
+      </div>
+      {report?.issues?.map((issue, i) => (
+        <span key={i} className={issue.severity === 'error' ? 'file-error' : 'file-warning'}>
+          {issue.configName ? `${issue.configName}: ` : ''}{issue.message}
+        </span>
+      ))}
+      {repair && (
+        <>
+          {repair.backupPath && <span className="grid-preview-message">Backup saved to {repair.backupPath}</span>}
+          {repair.changes?.map((change, i) => (
+            <span key={i} className="grid-preview-message">{change}</span>
+          ))}
+        </>
+      )}
+      {message && <span className="grid-preview-message">{message}</span>}
+    </div>
+  )
+}
+
+export default GridCheckPanel
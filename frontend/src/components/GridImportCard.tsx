@@ -0,0 +1,116 @@
+import { useState } from 'react'
+import { ImportGridConfig, OpenGridImportFile } from '../../wailsjs/go/main/App'
+import { config, heroesLayout, steam } from '../../wailsjs/go/models'
+
+interface GridImportCardProps {
+  accounts: steam.SteamAccountView[]
+  files: config.FileConfig[]
+}
+
+const collisionOptions = [
+  { value: 'rename', label: 'Keep both (rename)' },
+  { value: 'replace', label: 'Replace existing' },
+  { value: 'skip', label: 'Skip' },
+]
+
+function GridImportCard({ accounts, files }: GridImportCardProps) {
+  const [data, setData] = useState('')
+  const [steamIds, setSteamIds] = useState<string[]>([])
+  const [filePaths, setFilePaths] = useState<string[]>([])
+  const [collision, setCollision] = useState('rename')
+  const [results, setResults] = useState<heroesLayout.GridImportResult[]>([])
+  const [error, setError] = useState<string | null>(null)
+  const [isImporting, setIsImporting] = useState(false)
+
+  const toggle = (list: string[], value: string, checked: boolean) =>
+    checked ? [...list, value] : list.filter(v => v !== value)
+
+  const handleOpenFile = async () => {
+    try {
+      const content = await OpenGridImportFile()
+      if (content) {
+        setData(content)
+      }
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  const handleImport = async () => {
+    setIsImporting(true)
+    setError(null)
+    setResults([])
+    try {
+      setResults(await ImportGridConfig(data, steamIds, filePaths, collision) ?? [])
+    } catch (err) {
+      setError(`${err}`)
+    } finally {
+      setIsImporting(false)
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Import Grid</h2>
+        <button className="btn btn-secondary btn-sm" onClick={handleOpenFile}>Open File</button>
+      </div>
+      <div className="card-body">
+        <textarea
+          className="template-editor grid-import-data"
+          spellCheck={false}
+          placeholder="Paste a share string or an exported grid"
+          value={data}
+          onChange={(e) => setData(e.target.value)}
+        />
+        <div className="grid-import-targets">
+          {accounts.map((account) => (
+            <label key={account.steamId64} className="grid-import-target">
+              <input
+                type="checkbox"
+                checked={steamIds.includes(account.steamId64)}
+                onChange={(e) => setSteamIds(toggle(steamIds, account.steamId64, e.target.checked))}
+              />
+              {account.personaName || account.accountName || account.steamId64}
+            </label>
+          ))}
+          {files.map((file) => (
+            <label key={file.filePath} className="grid-import-target" title={file.filePath}>
+              <input
+                type="checkbox"
+                checked={filePaths.includes(file.filePath)}
+                onChange={(e) => setFilePaths(toggle(filePaths, file.filePath, e.target.checked))}
+              />
+              {file.filePath}
+            </label>
+          ))}
+        </div>
+        {error && <div className="file-error">{error}</div>}
+        {results.map((result) => (
+          <div key={result.filePath} className={result.error ? 'file-error' : 'grid-preview-message'}>
+            {result.filePath}: {result.error || `${result.status} as "${result.configName}"`}
+          </div>
+        ))}
+        <div className="template-actions">
+          <select className="select" value={collision} onChange={(e) => setCollision(e.target.value)}>
+            {collisionOptions.map((option) => (
+              <option key={option.value} value={option.value}>{option.label}</option>
+            ))}
+          </select>
+          <button
+            className="btn btn-primary btn-sm"
+            onClick={handleImport}
+            disabled={isImporting || !data.trim() || steamIds.length + filePaths.length === 0}
+          >
+            {isImporting ? 'Importing...' : 'Import'}
+          </button>
+        </div>
+        <div className="card-hint">
+          Adds the grid to the selected hero grid files without touching their other grids. When a file already has a different grid with the same name, the selected option decides what happens. Close Dota before importing.
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default GridImportCard
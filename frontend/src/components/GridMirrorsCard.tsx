@@ -0,0 +1,173 @@
+import { useEffect, useState } from 'react'
+import { GetGridMirrors, GetSteamAccountGridNames, RemoveGridMirror, SaveGridMirror, SyncGridMirror } from '../../wailsjs/go/main/App'
+import { config, heroesLayout, steam } from '../../wailsjs/go/models'
+import RelativeTime from './RelativeTime'
+
+interface GridMirrorsCardProps {
+  accounts: steam.SteamAccountView[]
+  onSynced: () => void
+}
+
+function GridMirrorsCard({ accounts, onSynced }: GridMirrorsCardProps) {
+  const [mirrors, setMirrors] = useState<config.GridMirror[]>([])
+  const [sourceId, setSourceId] = useState('')
+  const [gridNames, setGridNames] = useState<string[]>([])
+  const [configName, setConfigName] = useState('')
+  const [autoSync, setAutoSync] = useState(true)
+  const [results, setResults] = useState<Record<string, heroesLayout.MirrorTargetResult[]>>({})
+  const [syncingId, setSyncingId] = useState<string | null>(null)
+  const [error, setError] = useState<string | null>(null)
+
+  const accountName = (steamId64: string) => {
+    const account = accounts.find(a => a.steamId64 === steamId64)
+    return account ? account.personaName || account.accountName || steamId64 : steamId64
+  }
+
+  const loadMirrors = () => {
+    GetGridMirrors().then(m => setMirrors(m ?? [])).catch(console.error)
+  }
+
+  useEffect(loadMirrors, [])
+
+  useEffect(() => {
+    setGridNames([])
+    setConfigName('')
+    if (!sourceId) return
+    GetSteamAccountGridNames(sourceId)
+      // Generated grids are refused as mirror sources
+      .then(names => setGridNames((names ?? []).filter(name => !/^\[D2T(:[A-Za-z0-9_-]+)?\]/.test(name))))
+      .catch(err => setError(`${err}`))
+  }, [sourceId])
+
+  const handleAdd = async () => {
+    setError(null)
+    try {
+      await SaveGridMirror(config.GridMirror.createFrom({
+        id: `mirror-${Date.now()}`,
+        sourceSteamId64: sourceId,
+        configName,
+        autoSync,
+      }))
+      setConfigName('')
+      loadMirrors()
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  const handleToggleAutoSync = async (mirror: config.GridMirror, enabled: boolean) => {
+    try {
+      await SaveGridMirror(config.GridMirror.createFrom({ ...mirror, autoSync: enabled }))
+      loadMirrors()
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  const handleSync = async (id: string, overwriteConflicts: boolean) => {
+    setSyncingId(id)
+    setError(null)
+    try {
+      const synced = await SyncGridMirror(id, overwriteConflicts)
+      setResults(prev => ({ ...prev, [id]: synced ?? [] }))
+      onSynced()
+    } catch (err) {
+      setError(`${err}`)
+    } finally {
+      setSyncingId(null)
+      loadMirrors()
+    }
+  }
+
+  const handleRemove = async (id: string) => {
+    try {
+      await RemoveGridMirror(id)
+      loadMirrors()
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Mirrored Grids</h2>
+      </div>
+      <div className="card-body">
+        {mirrors.map((mirror) => (
+          <div key={mirror.id} className="grid-mirror">
+            <div className="grid-mirror-header">
+              <label className="toggle toggle-sm" title="Sync after every update">
+                <input
+                  type="checkbox"
+                  checked={mirror.autoSync}
+                  onChange={(e) => handleToggleAutoSync(mirror, e.target.checked)}
+                />
+                <span className="toggle-slider"></span>
+              </label>
+              <div className="grid-mirror-name">
+                "{mirror.configName}" from {accountName(mirror.sourceSteamId64)}
+              </div>
+              <button
+                className="btn btn-secondary btn-sm"
+                onClick={() => handleSync(mirror.id, false)}
+                disabled={syncingId !== null}
+              >
+                {syncingId === mirror.id ? 'Syncing...' : 'Sync Now'}
+              </button>
+              {mirror.lastSyncConflicts?.length > 0 && (
+                <button
+                  className="btn btn-secondary btn-sm"
+                  onClick={() => handleSync(mirror.id, true)}
+                  disabled={syncingId !== null}
+                >
+                  Overwrite Conflicts
+                </button>
+              )}
+              <button className="btn btn-secondary btn-sm" onClick={() => handleRemove(mirror.id)}>Remove</button>
+            </div>
+            <RelativeTime timestampMillis={mirror.lastSyncTimestampMillis} prefix="Synced: " neverText="Never synced" />
+            {mirror.lastSyncErrorMessage && <div className="file-error">{mirror.lastSyncErrorMessage}</div>}
+            {mirror.lastSyncConflicts?.map((steamId64) => (
+              <div key={steamId64} className="file-warning">
+                Changed in {accountName(steamId64)}, not overwritten
+              </div>
+            ))}
+            {results[mirror.id]?.map((result) => (
+              <div key={result.steamId64} className={result.error ? 'file-error' : 'grid-preview-message'}>
+                {accountName(result.steamId64)}: {result.error || result.status}
+              </div>
+            ))}
+          </div>
+        ))}
+        {error && <div className="file-error">{error}</div>}
+        <div className="template-actions">
+          <select className="select" value={sourceId} onChange={(e) => setSourceId(e.target.value)}>
+            <option value="">Source account</option>
+            {accounts.map((account) => (
+              <option key={account.steamId64} value={account.steamId64}>{accountName(account.steamId64)}</option>
+            ))}
+          </select>
+          <select className="select" value={configName} onChange={(e) => setConfigName(e.target.value)} disabled={!sourceId}>
+            <option value="">Grid</option>
+            {gridNames.map((name) => (
+              <option key={name} value={name}>{name}</option>
+            ))}
+          </select>
+          <label className="grid-import-target">
+            <input type="checkbox" checked={autoSync} onChange={(e) => setAutoSync(e.target.checked)} />
+            Auto sync
+          </label>
+          <button className="btn btn-primary btn-sm" onClick={handleAdd} disabled={!sourceId || !configName}>
+            Add Mirror
+          </button>
+        </div>
+        <div className="card-hint">
+          Copies a hand-made grid of one account into every other enabled account. A copy edited in a target account is reported as a conflict and only replaced with Overwrite Conflicts. Close Dota before syncing.
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default GridMirrorsCard
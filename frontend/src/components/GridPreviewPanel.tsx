@@ -0,0 +1,111 @@
+import { useEffect, useState } from 'react'
+import { ExportGridConfig, ExportGridPreview, GetGridShareString, PreviewHeroesLayoutFile, PreviewSteamAccountGrid } from '../../wailsjs/go/main/App'
+import { ClipboardSetText } from '../../wailsjs/runtime'
+import { heroesLayout } from '../../wailsjs/go/models'
+import GridCheckPanel from './GridCheckPanel'
+
+// The grids of a Steam account or of a custom config file are previewed
+interface GridPreviewPanelProps {
+  steamId64?: string
+  filePath?: string
+}
+
+function GridPreviewPanel({ steamId64, filePath }: GridPreviewPanelProps) {
+  const [previews, setPreviews] = useState<heroesLayout.GridPreview[]>([])
+  const [selected, setSelected] = useState(0)
+  const [message, setMessage] = useState<string | null>(null)
+  const [reloadCount, setReloadCount] = useState(0)
+
+  useEffect(() => {
+    const load = steamId64 ? PreviewSteamAccountGrid(steamId64) : PreviewHeroesLayoutFile(filePath ?? '')
+    load
+      .then((result) => {
+        setPreviews(result ?? [])
+        setSelected(0)
+        setMessage(result?.length ? null : 'The file has no hero grids yet')
+      })
+      .catch((error) => {
+        console.error('Error loading grid preview:', error)
+        setMessage(`Failed to load preview: ${error}`)
+      })
+  }, [steamId64, filePath, reloadCount])
+
+  const preview = previews[selected]
+
+  const handleExport = async (format: string) => {
+    if (!preview) {
+      return
+    }
+    setMessage(null)
+    try {
+      const path = await ExportGridPreview(preview.filePath, preview.configName, format)
+      if (path) {
+        setMessage(`Exported to ${path}`)
+      }
+    } catch (error) {
+      console.error('Error exporting grid preview:', error)
+      setMessage(`Failed to export preview: ${error}`)
+    }
+  }
+
+  const handleExportGrid = async () => {
+    if (!preview) {
+      return
+    }
+    setMessage(null)
+    try {
+      const path = await ExportGridConfig(preview.filePath, preview.configName)
+      if (path) {
+        setMessage(`Exported to ${path}`)
+      }
+    } catch (error) {
+      console.error('Error exporting grid:', error)
+      setMessage(`Failed to export grid: ${error}`)
+    }
+  }
+
+  const handleCopyShareString = async () => {
+    if (!preview) {
+      return
+    }
+    setMessage(null)
+    try {
+      await ClipboardSetText(await GetGridShareString(preview.filePath, preview.configName))
+      setMessage('Share string copied to the clipboard')
+    } catch (error) {
+      console.error('Error copying share string:', error)
+      setMessage(`Failed to copy share string: ${error}`)
+    }
+  }
+
+  return (
+    <div className="grid-preview">
+      {preview && (
+        <>
+          <div className="grid-preview-actions">
+            <select className="select" value={selected} onChange={(e) => setSelected(Number(e.target.value))}>
+              {previews.map((p, i) => (
+                <option key={p.configName + i} value={i}>{p.configName}</option>
+              ))}
+            </select>
+            <span className="grid-preview-size">{preview.width} x {preview.height}</span>
+            <button className="btn btn-secondary btn-sm" onClick={() => handleExport('svg')}>Export SVG</button>
+            <button className="btn btn-secondary btn-sm" onClick={() => handleExport('png')}>Export PNG</button>
+            <button className="btn btn-secondary btn-sm" onClick={handleExportGrid}>Export Grid</button>
+            <button className="btn btn-secondary btn-sm" onClick={handleCopyShareString}>Copy Share String</button>
+          </div>
+          {preview.warnings?.map((warning) => (
+            <span key={warning} className="file-warning">{warning}</span>
+          ))}
+          <div className="grid-preview-image">
+            <img src={`data:image/svg+xml;charset=utf-8,${encodeURIComponent(preview.svg)}`} alt={preview.configName} />
+          </div>
+        </>
+      )}
+      {message && <span className="grid-preview-message">{message}</span>}
+      <GridCheckPanel steamId64={steamId64} filePath={filePath} onRepaired={() => setReloadCount(reloadCount + 1)} />
+    </div>
+  )
+}
+
+export default GridPreviewPanel
@@ -0,0 +1,158 @@
+import { useEffect, useState } from 'react'
+import { ExportHistory, GetHeroes, GetHeroHistory, GetHistorySources } from '../../wailsjs/go/main/App'
+import { config, history } from '../../wailsjs/go/models'
+
+interface HeroHistoryCardProps {
+  positions: config.PositionConfig[]
+  getPositionName: (id: string) => string
+}
+
+// Range options in days, 0 shows the whole history
+const rangeOptions = [
+  { days: 7, label: 'Last 7 days' },
+  { days: 30, label: 'Last 30 days' },
+  { days: 90, label: 'Last 90 days' },
+  { days: 0, label: 'All history' },
+]
+
+const periodLabels: Record<string, string> = {
+  '8': 'last 8 days',
+  'patch': 'current patch',
+}
+
+const sourceKey = (source: history.Source) => `${source.provider}/${source.period}`
+
+const sourceLabel = (source: history.Source) => `${source.provider}, ${periodLabels[source.period] ?? source.period}`
+
+function HeroHistoryCard({ positions, getPositionName }: HeroHistoryCardProps) {
+  const [heroIds, setHeroIds] = useState<Record<string, number>>({})
+  const [heroQuery, setHeroQuery] = useState('')
+  const [sources, setSources] = useState<history.Source[]>([])
+  const [selectedSource, setSelectedSource] = useState('')
+  const [positionId, setPositionId] = useState('1')
+  const [days, setDays] = useState(30)
+  const [points, setPoints] = useState<history.SeriesPoint[]>([])
+  const [message, setMessage] = useState<string | null>(null)
+
+  useEffect(() => {
+    GetHeroes()
+      .then((heroes) => setHeroIds(Object.fromEntries(heroes.map(h => [h.localizedName.toLowerCase(), h.id]))))
+      .catch(console.error)
+    // The first source is the default provider and period
+    GetHistorySources()
+      .then((list) => {
+        setSources(list ?? [])
+        if (list && list.length > 0) {
+          setSelectedSource(sourceKey(list[0]))
+        }
+      })
+      .catch(console.error)
+  }, [])
+
+  const heroId = heroIds[heroQuery.trim().toLowerCase()]
+  const source = sources.find(s => sourceKey(s) === selectedSource)
+
+  useEffect(() => {
+    if (!heroId || !source) {
+      setPoints([])
+      return
+    }
+    GetHeroHistory(source.provider, source.period, heroId, positionId, days).then(setPoints).catch((error) => {
+      console.error('Error loading hero history:', error)
+      setMessage(`Failed to load history: ${error}`)
+    })
+  }, [heroId, positionId, days, source?.provider, source?.period])
+
+  const handleExport = async (format: string) => {
+    if (!source) return
+    setMessage(null)
+    try {
+      const path = await ExportHistory(source.provider, source.period, format)
+      if (path) {
+        setMessage(`Exported to ${path}`)
+      }
+    } catch (error) {
+      console.error('Error exporting history:', error)
+      setMessage(`Failed to export history: ${error}`)
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Stats History</h2>
+        <div className="history-actions">
+          <button className="btn btn-secondary btn-sm" onClick={() => handleExport('csv')} disabled={!source}>Export CSV</button>
+          <button className="btn btn-secondary btn-sm" onClick={() => handleExport('json')} disabled={!source}>Export JSON</button>
+        </div>
+      </div>
+      <div className="card-body">
+        <datalist id="history-hero-names">
+          {Object.keys(heroIds).sort().map((name) => (
+            <option key={name} value={name} />
+          ))}
+        </datalist>
+        <div className="history-filters">
+          <select
+            className="select"
+            title="Provider and period"
+            value={selectedSource}
+            onChange={(e) => setSelectedSource(e.target.value)}
+          >
+            {sources.map((s) => (
+              <option key={sourceKey(s)} value={sourceKey(s)}>{sourceLabel(s)}</option>
+            ))}
+          </select>
+          <input
+            className="select"
+            list="history-hero-names"
+            placeholder="Hero name"
+            value={heroQuery}
+            onChange={(e) => setHeroQuery(e.target.value)}
+          />
+          <select className="select" value={positionId} onChange={(e) => setPositionId(e.target.value)}>
+            {positions.map((position) => (
+              <option key={position.id} value={position.id}>{getPositionName(position.id)}</option>
+            ))}
+          </select>
+          <select className="select" value={days} onChange={(e) => setDays(Number(e.target.value))}>
+            {rangeOptions.map((option) => (
+              <option key={option.days} value={option.days}>{option.label}</option>
+            ))}
+          </select>
+        </div>
+        {heroId && points.length === 0 && (
+          <div className="empty-state">
+            <p>No history for this hero yet</p>
+            <p className="empty-state-hint">Stats are recorded once a day when grids are updated</p>
+          </div>
+        )}
+        {points.length > 0 && (
+          <table className="history-table">
+            <thead>
+              <tr>
+                <th>Date</th>
+                <th>Rating</th>
+                <th>Winrate</th>
+                <th>Matches</th>
+              </tr>
+            </thead>
+            <tbody>
+              {points.map((point) => (
+                <tr key={point.date}>
+                  <td>{point.date}</td>
+                  <td>{point.rating}</td>
+                  <td>{point.winrate.toFixed(1)}%</td>
+                  <td>{point.matches}</td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        )}
+        {message && <div className="card-hint">{message}</div>}
+      </div>
+    </div>
+  )
+}
+
+export default HeroHistoryCard
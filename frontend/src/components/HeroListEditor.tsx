@@ -0,0 +1,69 @@
+import { useState } from 'react'
+import { PlusIcon, XIcon } from './Icons'
+
+interface HeroListEditorProps {
+  label: string
+  description: string
+  heroIds: number[]
+  heroNames: Record<number, string>
+  datalistId: string // id of a datalist with the hero names to suggest
+  onChange: (heroIds: number[]) => void
+}
+
+const heroLabel = (heroNames: Record<number, string>, id: number) => heroNames[id] || `Hero #${id}`
+
+// Edits a list of heroes as removable chips, adding heroes by name or ID
+function HeroListEditor({ label, description, heroIds, heroNames, datalistId, onChange }: HeroListEditorProps) {
+  const [query, setQuery] = useState('')
+
+  // Accepts a hero name from the suggestions or a raw hero ID
+  const resolveHeroId = (value: string): number | null => {
+    const trimmed = value.trim().toLowerCase()
+    const byName = Object.entries(heroNames).find(([, name]) => name.toLowerCase() === trimmed)
+    if (byName) return Number(byName[0])
+    const id = parseInt(trimmed, 10)
+    return !isNaN(id) && id > 0 ? id : null
+  }
+
+  const handleAdd = () => {
+    const id = resolveHeroId(query)
+    if (id !== null && !heroIds.includes(id)) {
+      onChange([...heroIds, id])
+    }
+    setQuery('')
+  }
+
+  return (
+    <div className="hero-list">
+      <div className="setting-info">
+        <div className="setting-label">{label}</div>
+        <div className="setting-description">{description}</div>
+      </div>
+      <div className="hero-chips">
+        {heroIds.map((id) => (
+          <span key={id} className="hero-chip">
+            {heroLabel(heroNames, id)}
+            <button className="hero-chip-remove" onClick={() => onChange(heroIds.filter(h => h !== id))} title="Remove">
+              <XIcon />
+            </button>
+          </span>
+        ))}
+      </div>
+      <div className="hero-list-add">
+        <input
+          className="select"
+          list={datalistId}
+          placeholder="Hero name"
+          value={query}
+          onChange={(e) => setQuery(e.target.value)}
+          onKeyDown={(e) => e.key === 'Enter' && handleAdd()}
+        />
+        <button className="btn btn-secondary btn-sm" onClick={handleAdd} disabled={!query.trim()}>
+          <PlusIcon />
+        </button>
+      </div>
+    </div>
+  )
+}
+
+export default HeroListEditor
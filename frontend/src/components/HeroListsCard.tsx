@@ -0,0 +1,91 @@
+import { useEffect, useState } from 'react'
+import { GetHeroLists, GetHeroes, SetHeroLists } from '../../wailsjs/go/main/App'
+import { config } from '../../wailsjs/go/models'
+import HeroListEditor from './HeroListEditor'
+
+interface HeroListsCardProps {
+  positions: config.PositionConfig[]
+  getPositionName: (id: string) => string
+  onChanged: () => void
+}
+
+function HeroListsCard({ positions, getPositionName, onChanged }: HeroListsCardProps) {
+  const [lists, setLists] = useState<config.HeroListsConfig | null>(null)
+  const [heroNames, setHeroNames] = useState<Record<number, string>>({})
+  const [pinPosition, setPinPosition] = useState('1')
+
+  useEffect(() => {
+    GetHeroLists().then(setLists).catch(console.error)
+    GetHeroes()
+      .then((heroes) => setHeroNames(Object.fromEntries(heroes.map(h => [h.id, h.localizedName]))))
+      .catch(console.error)
+  }, [])
+
+  const update = async (changes: Partial<config.HeroListsConfig>) => {
+    if (!lists) return
+    const updated = config.HeroListsConfig.createFrom({ ...lists, ...changes })
+    try {
+      await SetHeroLists(updated)
+      setLists(await GetHeroLists())
+      onChanged()
+    } catch (error) {
+      console.error('Error saving hero lists:', error)
+    }
+  }
+
+  if (!lists) return null
+
+  const sortedNames = Object.values(heroNames).sort()
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Hero Lists</h2>
+      </div>
+      <div className="card-body">
+        <datalist id="hero-names">
+          {sortedNames.map((name) => (
+            <option key={name} value={name} />
+          ))}
+        </datalist>
+        <HeroListEditor
+          label="Excluded"
+          description="Heroes never shown in generated grids"
+          heroIds={lists.exclude}
+          heroNames={heroNames}
+          datalistId="hero-names"
+          onChange={(exclude) => update({ exclude })}
+        />
+        <HeroListEditor
+          label="Hero Pool"
+          description="When not empty, only these heroes are shown"
+          heroIds={lists.include}
+          heroNames={heroNames}
+          datalistId="hero-names"
+          onChange={(include) => update({ include })}
+        />
+        <div className="setting-row">
+          <div className="setting-info">
+            <div className="setting-label">Pinned Position</div>
+            <div className="setting-description">Pinned heroes are shown first in every section of the position</div>
+          </div>
+          <select className="select" value={pinPosition} onChange={(e) => setPinPosition(e.target.value)}>
+            {positions.map((position) => (
+              <option key={position.id} value={position.id}>{getPositionName(position.id)}</option>
+            ))}
+          </select>
+        </div>
+        <HeroListEditor
+          label={`Pinned: ${getPositionName(pinPosition)}`}
+          description="Comfort picks, shown with their current stats"
+          heroIds={lists.pinned[pinPosition] ?? []}
+          heroNames={heroNames}
+          datalistId="hero-names"
+          onChange={(heroIds) => update({ pinned: { ...lists.pinned, [pinPosition]: heroIds } })}
+        />
+      </div>
+    </div>
+  )
+}
+
+export default HeroListsCard
@@ -0,0 +1,154 @@
+import { useEffect, useMemo, useState } from 'react'
+import { GetHeroes, GetRankings, RemoveLayoutTemplate, SaveLayoutTemplate } from '../../wailsjs/go/main/App'
+import { config, providers } from '../../wailsjs/go/models'
+import HeroListEditor from './HeroListEditor'
+
+interface LayoutTemplatesCardProps {
+  templates: config.LayoutTemplate[]
+  onChanged: () => void
+}
+
+const matchupDescriptions: Record<string, string> = {
+  counters: 'Heroes to list counters to, one section each',
+  partners: 'Hero pool to find the best partners for',
+}
+
+function LayoutTemplatesCard({ templates, onChanged }: LayoutTemplatesCardProps) {
+  const [selectedId, setSelectedId] = useState('default')
+  const [draft, setDraft] = useState('')
+  const [error, setError] = useState<string | null>(null)
+  const [isSaving, setIsSaving] = useState(false)
+  const [rankings, setRankings] = useState<providers.Ranking[]>([])
+  const [heroNames, setHeroNames] = useState<Record<number, string>>({})
+
+  useEffect(() => {
+    GetRankings().then(setRankings).catch(console.error)
+    GetHeroes()
+      .then((heroes) => setHeroNames(Object.fromEntries(heroes.map(h => [h.id, h.localizedName]))))
+      .catch(console.error)
+  }, [])
+
+  // Matchup heroes are edited with pickers; they are hidden while the draft isn't valid JSON
+  const parsedDraft = useMemo(() => {
+    try {
+      return JSON.parse(draft)
+    } catch {
+      return null
+    }
+  }, [draft])
+  const sections: config.LayoutSection[] = Array.isArray(parsedDraft?.sections) ? parsedDraft.sections : []
+
+  const setMatchupHeroes = (index: number, heroIds: number[]) => {
+    const updatedSections = sections.map((section, i) => i === index ? { ...section, matchupHeroes: heroIds } : section)
+    setDraft(JSON.stringify({ ...parsedDraft, sections: updatedSections }, null, 2))
+  }
+
+  const selected = templates.find(t => t.id === selectedId) ?? templates[0]
+
+  useEffect(() => {
+    if (selected) {
+      setDraft(JSON.stringify(selected, null, 2))
+      setError(null)
+    }
+  }, [selected])
+
+  const handleNew = () => {
+    if (!selected) return
+    const copy = { ...selected, id: `template-${Date.now()}`, name: `${selected.name} (copy)`, builtIn: false }
+    setDraft(JSON.stringify(copy, null, 2))
+    setError(null)
+  }
+
+  const handleSave = async () => {
+    setIsSaving(true)
+    setError(null)
+    try {
+      const template = config.LayoutTemplate.createFrom(JSON.parse(draft))
+      await SaveLayoutTemplate(template)
+      setSelectedId(template.id)
+      onChanged()
+    } catch (err) {
+      setError(`${err}`)
+    } finally {
+      setIsSaving(false)
+    }
+  }
+
+  const handleDelete = async () => {
+    if (!selected || selected.builtIn) return
+    try {
+      await RemoveLayoutTemplate(selected.id)
+      setSelectedId('default')
+      onChanged()
+    } catch (err) {
+      setError(`${err}`)
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Layout Templates</h2>
+      </div>
+      <div className="card-body">
+        <div className="setting-row">
+          <div className="setting-info">
+            <div className="setting-label">Template</div>
+            <div className="setting-description">
+              Sections, sort order, filters and labels of the generated grid
+            </div>
+          </div>
+          <select className="select" value={selected?.id} onChange={(e) => setSelectedId(e.target.value)}>
+            {templates.map((template) => (
+              <option key={template.id} value={template.id}>{template.name}</option>
+            ))}
+          </select>
+        </div>
+        <textarea
+          className="template-editor"
+          spellCheck={false}
+          value={draft}
+          onChange={(e) => setDraft(e.target.value)}
+        />
+        {error && <div className="file-error">{error}</div>}
+        <datalist id="template-hero-names">
+          {Object.values(heroNames).sort().map((name) => (
+            <option key={name} value={name} />
+          ))}
+        </datalist>
+        {sections.map((section, index) => section.matchup && (
+          <HeroListEditor
+            key={index}
+            label={`Matchup heroes: ${section.title || `section ${index + 1}`}`}
+            description={matchupDescriptions[section.matchup] ?? 'Heroes of the matchup section'}
+            heroIds={section.matchupHeroes ?? []}
+            heroNames={heroNames}
+            datalistId="template-hero-names"
+            onChange={(heroIds) => setMatchupHeroes(index, heroIds)}
+          />
+        ))}
+        {rankings.length > 0 && (
+          <ul className="template-rankings">
+            {rankings.map((ranking) => (
+              <li key={ranking.id} title={ranking.description}>
+                <code>{ranking.id}</code> {ranking.name} — {ranking.description}
+              </li>
+            ))}
+          </ul>
+        )}
+        <div className="template-actions">
+          <button className="btn btn-secondary btn-sm" onClick={handleNew}>Duplicate</button>
+          <button className="btn btn-danger btn-sm" onClick={handleDelete} disabled={!selected || selected.builtIn}>Delete</button>
+          <button className="btn btn-primary btn-sm" onClick={handleSave} disabled={isSaving}>
+            {isSaving ? 'Saving...' : 'Save'}
+          </button>
+        </div>
+        <div className="card-hint">
+          Label placeholders: {'{winrate}'}, {'{matches}'}, {'{rating}'}, {'{winrateDelta}'}, {'{ratingDelta}'}, {'{rank}'}, {'{facet}'}, {'{facetWinrate}'}. Section titles accept {'{position}'}. Set hideRowHeaders to true to drop the label headers at the start of each row. Set autoFit to "heroesPerRow" or "scale" to shrink grids wider or taller than Dota's hero grid canvas. Set groupBy to "attribute" or "attackType" to split a section into columns. Sort by "ratingChange" to list rising heroes over trendDays (7 by default). Set facets to "best" to rank every hero by its best facet, or "split" to list each facet on its own; by default the facets of a hero are merged. Set matchup to "counters" or "partners" and pick its matchup heroes below the editor to list counters to each of those heroes or the best partners for them; their titles accept {'{hero}'}. The built-in template can be duplicated but not edited.
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default LayoutTemplatesCard
@@ -0,0 +1,27 @@
+import { config } from '../../wailsjs/go/models'
+
+interface ProfileSelectProps {
+  profiles: config.GenerationProfile[]
+  value: string
+  onChange: (profileId: string) => void
+}
+
+// Unknown or empty IDs fall back to the global settings in the backend, so show them as selected
+function ProfileSelect({ profiles, value, onChange }: ProfileSelectProps) {
+  const selected = profiles.some(p => p.id === value) ? value : 'default'
+
+  return (
+    <select
+      className="select select-sm"
+      value={selected}
+      onChange={(e) => onChange(e.target.value)}
+      title="Generation profile"
+    >
+      {profiles.map((profile) => (
+        <option key={profile.id} value={profile.id}>{profile.name}</option>
+      ))}
+    </select>
+  )
+}
+
+export default ProfileSelect
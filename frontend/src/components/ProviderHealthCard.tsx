@@ -0,0 +1,60 @@
+import { useEffect, useState } from 'react'
+import { GetProviderHealth } from '../../wailsjs/go/main/App'
+import { heroesLayout } from '../../wailsjs/go/models'
+import RelativeTime from './RelativeTime'
+import { describeDiagnostics } from './ProviderTestPanel'
+
+// Requests shown per provider, the most recent ones
+const SHOWN_REQUESTS = 5
+
+function ProviderHealthCard() {
+  const [health, setHealth] = useState<heroesLayout.ProviderHealth[]>([])
+
+  const loadHealth = () => {
+    GetProviderHealth().then(h => setHealth(h ?? [])).catch(console.error)
+  }
+
+  useEffect(loadHealth, [])
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <h2 className="card-title">Provider Health</h2>
+        <button className="btn btn-secondary btn-sm" onClick={loadHealth}>Refresh</button>
+      </div>
+      <div className="card-body">
+        {health.map((provider) => {
+          const last = provider.diagnostics?.[0]
+          const state = !last ? 'unused' : last.error ? 'failing' : last.warnings?.length ? 'suspicious' : 'ok'
+          return (
+            <div key={provider.provider} className="provider-health">
+              <div className="grid-mirror-header">
+                <div className="grid-mirror-name">
+                  {provider.provider} ({provider.kind})
+                </div>
+                <span className={`provider-health-state provider-health-${state}`}>{state}</span>
+              </div>
+              {provider.diagnostics?.slice(0, SHOWN_REQUESTS).map((diagnostics, i) => (
+                <div key={i} className="provider-health-request">
+                  <div className="grid-preview-message" title={diagnostics.url}>
+                    <RelativeTime timestampMillis={diagnostics.timestampMillis} className="provider-health-time" />
+                    {' '}{diagnostics.request}: {describeDiagnostics(diagnostics)}
+                  </div>
+                  {diagnostics.error && <div className="file-error">{diagnostics.error}</div>}
+                  {diagnostics.warnings?.map((warning, j) => (
+                    <div key={j} className="file-warning">{warning}</div>
+                  ))}
+                </div>
+              ))}
+            </div>
+          )
+        })}
+        <div className="card-hint">
+          The last requests of each provider since D2Tool started, with their status, latency, size and whether they came from the cache. Responses without heroes or with every rating at 0 are flagged as suspicious. Hover a request to see its URL.
+        </div>
+      </div>
+    </div>
+  )
+}
+
+export default ProviderHealthCard
@@ -0,0 +1,103 @@
+import { useState } from 'react'
+import { TestHeroesProvider } from '../../wailsjs/go/main/App'
+import { heroesLayout, providers } from '../../wailsjs/go/models'
+
+const TEST_HEROES = 20
+
+const positionIds = ['1', '2', '3', '4', '5']
+
+// Summary of one request: status, latency, payload size and cache use
+export const describeDiagnostics = (diagnostics: providers.FetchDiagnostics) => {
+  const parts = [
+    diagnostics.cacheHit ? 'cached' : diagnostics.statusCode ? `HTTP ${diagnostics.statusCode}` : 'no response',
+    `${diagnostics.latencyMillis} ms`,
+  ]
+  if (diagnostics.payloadBytes) parts.push(`${(diagnostics.payloadBytes / 1024).toFixed(1)} KB`)
+  if (!diagnostics.error) parts.push(`${diagnostics.heroCount} entries`)
+  return parts.join(', ')
+}
+
+interface ProviderTestPanelProps {
+  provider: string
+  disabled?: boolean
+}
+
+// Fetches one position from a provider and shows the request diagnostics and the best rated heroes,
+// with the sources of composite stats
+function ProviderTestPanel({ provider, disabled }: ProviderTestPanelProps) {
+  const [positionId, setPositionId] = useState('1')
+  const [result, setResult] = useState<heroesLayout.ProviderTestResult | null>(null)
+  const [isTesting, setIsTesting] = useState(false)
+  const [message, setMessage] = useState<string | null>(null)
+
+  const handleTest = async () => {
+    setIsTesting(true)
+    setMessage(null)
+    try {
+      setResult(await TestHeroesProvider(provider, positionId))
+    } catch (error) {
+      console.error('Error testing provider:', error)
+      setResult(null)
+      setMessage(`Failed to test provider: ${error}`)
+    } finally {
+      setIsTesting(false)
+    }
+  }
+
+  const heroes = result?.heroes ?? []
+  const hasSources = heroes.some(hero => hero.sources?.length)
+  const diagnostics = result?.diagnostics
+
+  return (
+    <>
+      <div className="history-filters">
+        <select className="select" value={positionId} onChange={(e) => setPositionId(e.target.value)}>
+          {positionIds.map((id) => (
+            <option key={id} value={id}>Position {id}</option>
+          ))}
+        </select>
+        <button className="btn btn-secondary btn-sm" onClick={handleTest} disabled={disabled || isTesting}>
+          {isTesting ? 'Testing...' : 'Test Provider'}
+        </button>
+      </div>
+      {diagnostics && (
+        <div className="grid-preview-message" title={diagnostics.url}>
+          {describeDiagnostics(diagnostics)}{diagnostics.url ? ` from ${diagnostics.url}` : ''}
+        </div>
+      )}
+      {result?.error && <div className="file-error">{result.error}</div>}
+      {diagnostics?.warnings?.map((warning, i) => (
+        <div key={i} className="file-warning">{warning}</div>
+      ))}
+      {heroes.length > 0 && (
+        <table className="history-table">
+          <thead>
+            <tr>
+              <th>Hero</th>
+              <th>Rating</th>
+              <th>Matches</th>
+              {hasSources && <th>Sources</th>}
+            </tr>
+          </thead>
+          <tbody>
+            {heroes.slice(0, TEST_HEROES).map((hero) => (
+              <tr key={hero.hero_id}>
+                <td>{hero.hero_name}</td>
+                <td>{hero.d2pt_rating}</td>
+                <td>{hero.matches > 0 ? hero.matches : '-'}</td>
+                {hasSources && (
+                  <td>
+                    {hero.sources?.map(source => `${source.provider}: ${source.d2pt_rating}${source.matches > 0 ? ` (${source.matches})` : ''}`).join(', ')}
+                  </td>
+                )}
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      )}
+      {message && <div className="file-error">{message}</div>}
+    </>
+  )
+}
+
+export default ProviderTestPanel
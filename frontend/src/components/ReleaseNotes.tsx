@@ -0,0 +1,81 @@
+import type { ReactNode } from 'react'
+import { BrowserOpenURL } from '../../wailsjs/runtime'
+
+interface ReleaseNotesProps {
+  markdown: string
+}
+
+const linkPattern = /\[([^\]]+)\]\((https?:\/\/[^)\s]+)\)/g
+
+// The backend escapes raw HTML in release notes; React escapes text itself, so show it as written
+function unescapeHtml(text: string): string {
+  return text.replace(/&lt;/g, '<').replace(/&gt;/g, '>').replace(/&amp;/g, '&')
+}
+
+function plainText(text: string): string {
+  return unescapeHtml(text.replace(/\*\*/g, ''))
+}
+
+// Renders inline markdown links as clickable text; everything else stays plain text
+function renderInline(text: string): ReactNode[] {
+  const nodes: ReactNode[] = []
+  let lastIndex = 0
+  for (const match of text.matchAll(linkPattern)) {
+    const index = match.index ?? 0
+    if (index > lastIndex) {
+      nodes.push(plainText(text.slice(lastIndex, index)))
+    }
+    const url = unescapeHtml(match[2])
+    nodes.push(
+      <a key={index} href="#" onClick={(e) => { e.preventDefault(); BrowserOpenURL(url) }}>
+        {unescapeHtml(match[1])}
+      </a>
+    )
+    lastIndex = index + match[0].length
+  }
+  if (lastIndex < text.length) {
+    nodes.push(plainText(text.slice(lastIndex)))
+  }
+  return nodes
+}
+
+// Minimal markdown renderer for sanitized release notes: headings, bullet lists and paragraphs.
+// All text goes through React, so nothing is injected as raw HTML.
+function ReleaseNotes({ markdown }: ReleaseNotesProps) {
+  const blocks: ReactNode[] = []
+  let listItems: string[] = []
+
+  const flushList = () => {
+    if (listItems.length > 0) {
+      blocks.push(
+        <ul key={`list-${blocks.length}`} className="release-notes-list">
+          {listItems.map((item, i) => <li key={i}>{renderInline(item)}</li>)}
+        </ul>
+      )
+      listItems = []
+    }
+  }
+
+  for (const line of markdown.split('\n')) {
+    const trimmed = line.trim()
+    const heading = trimmed.match(/^#{1,6}\s+(.*)$/)
+    const bullet = trimmed.match(/^[*-]\s+(.*)$/)
+
+    if (bullet) {
+      listItems.push(bullet[1])
+      continue
+    }
+
+    flushList()
+    if (heading) {
+      blocks.push(<div key={blocks.length} className="release-notes-heading">{renderInline(heading[1])}</div>)
+    } else if (trimmed !== '') {
+      blocks.push(<p key={blocks.length} className="release-notes-paragraph">{renderInline(trimmed)}</p>)
+    }
+  }
+  flushList()
+
+  return <div className="release-notes">{blocks}</div>
+}
+
+export default ReleaseNotes
@@ -0,0 +1,27 @@
+import { config } from '../../wailsjs/go/models'
+
+interface TemplateSelectProps {
+  templates: config.LayoutTemplate[]
+  value: string
+  onChange: (templateId: string) => void
+}
+
+// Unknown or empty IDs fall back to the built-in template in the backend, so show it as selected
+function TemplateSelect({ templates, value, onChange }: TemplateSelectProps) {
+  const selected = templates.some(t => t.id === value) ? value : 'default'
+
+  return (
+    <select
+      className="select select-sm"
+      value={selected}
+      onChange={(e) => onChange(e.target.value)}
+      title="Layout template"
+    >
+      {templates.map((template) => (
+        <option key={template.id} value={template.id}>{template.name}</option>
+      ))}
+    </select>
+  )
+}
+
+export default TemplateSelect
@@ -2,3 +2,4 @@ export const EventSteamPathChanged = 'steamPathChanged'
 export const EventSteamAccountsChanged = 'steamAccountsChanged'
 export const EventHeroesLayoutDataChanged = 'heroesLayoutDataChanged'
 export const EventAppUpdateDataChanged = 'appUpdateDataChanged'
+export const EventAppUpdateDownloadProgress = 'appUpdateDownloadProgress'
@@ -6,17 +6,37 @@ import {
   AddHeroesLayoutFile,
   RemoveHeroesLayoutFile,
   SetHeroesLayoutFileEnabled,
+  SetHeroesLayoutFileTemplate,
+  SetHeroesLayoutFileJobs,
+  SetHeroesLayoutFileProfile,
   OpenFileDialog,
   GetPositions,
   SetPositions,
   SetPositionEnabled,
   GetHeroesPerRow,
   SetHeroesPerRow,
+  GetHistoryRetentionDays,
+  SetHistoryRetentionDays,
   GetSteamAccounts,
+  SetSteamAccountLayoutTemplate,
+  SetSteamAccountJobs,
+  SetSteamAccountProfile,
+  GetLayoutTemplates,
+  GetGenerationProfiles,
 } from '../../wailsjs/go/main/App'
 import { config, steam } from '../../wailsjs/go/models'
 import { EventHeroesLayoutDataChanged, EventSteamAccountsChanged } from '../events'
 import AccountCard from '../components/AccountCard'
+import LayoutTemplatesCard from '../components/LayoutTemplatesCard'
+import HeroListsCard from '../components/HeroListsCard'
+import HeroHistoryCard from '../components/HeroHistoryCard'
+import TemplateSelect from '../components/TemplateSelect'
+import ProfileSelect from '../components/ProfileSelect'
+import GenerationProfilesCard from '../components/GenerationProfilesCard'
+import GenerationJobsEditor from '../components/GenerationJobsEditor'
+import GridPreviewPanel from '../components/GridPreviewPanel'
+import GridImportCard from '../components/GridImportCard'
+import GridMirrorsCard from '../components/GridMirrorsCard'
 import RelativeTime from '../components/RelativeTime'
 import { AlertCircleIcon, GripIcon, MoreIcon, RefreshIcon, TrashIcon, XIcon } from '../components/Icons'
 import { useGridAutoUpdate } from '../components/GridAutoUpdateProvider'
@@ -26,6 +46,11 @@ const MIN_HEROES_PER_ROW = 1
 const MAX_HEROES_PER_ROW = 50
 const DEFAULT_HEROES_PER_ROW = 15
 
+// History retention constraints
+const MIN_RETENTION_DAYS = 1
+const MAX_RETENTION_DAYS = 365
+const DEFAULT_RETENTION_DAYS = 30
+
 // Position name mapping
 const positionNames: Record<string, string> = {
   '1': 'Carry',
@@ -53,6 +78,12 @@ function HeroesLayoutPage() {
   // Steam accounts state
   const [steamAccounts, setSteamAccounts] = useState<steam.SteamAccountView[]>([])
 
+  // Layout templates state
+  const [templates, setTemplates] = useState<config.LayoutTemplate[]>([])
+
+  // Generation profiles state
+  const [profiles, setProfiles] = useState<config.GenerationProfile[]>([])
+
   // Positions state
   const [positions, setPositions] = useState<config.PositionConfig[]>([])
 
@@ -66,6 +97,14 @@ function HeroesLayoutPage() {
   const [heroesPerRow, setHeroesPerRowState] = useState<number>(DEFAULT_HEROES_PER_ROW)
   const [heroesPerRowInput, setHeroesPerRowInput] = useState<string>(DEFAULT_HEROES_PER_ROW.toString())
 
+  // History retention state
+  const [retentionDays, setRetentionDaysState] = useState<number>(DEFAULT_RETENTION_DAYS)
+  const [retentionDaysInput, setRetentionDaysInput] = useState<string>(DEFAULT_RETENTION_DAYS.toString())
+
+  // File whose generation jobs are being edited
+  const [jobsFilePath, setJobsFilePath] = useState<string | null>(null)
+  const [previewFilePath, setPreviewFilePath] = useState<string | null>(null)
+
   // Menu state
   const [menuOpen, setMenuOpen] = useState(false)
   const menuRef = useRef<HTMLDivElement>(null)
@@ -81,12 +120,20 @@ function HeroesLayoutPage() {
         setHeroesPerRowState(value)
         setHeroesPerRowInput(value.toString())
       }),
+      GetHistoryRetentionDays().then((value: number) => {
+        setRetentionDaysState(value)
+        setRetentionDaysInput(value.toString())
+      }),
       GetSteamAccounts().then(setSteamAccounts),
+      GetLayoutTemplates().then(setTemplates),
+      GetGenerationProfiles().then(setProfiles),
     ]).catch(console.error).finally(() => setIsLoading(false))
 
     // Listen for background update notifications
     const offDataChanged = EventsOn(EventHeroesLayoutDataChanged, () => {
       GetHeroesLayoutFiles().then(setFiles).catch(console.error)
+      GetLayoutTemplates().then(setTemplates).catch(console.error)
+      GetGenerationProfiles().then(setProfiles).catch(console.error)
     })
 
     const offSteamChanged = EventsOn(EventSteamAccountsChanged, () => {
@@ -177,6 +224,31 @@ function HeroesLayoutPage() {
     }
   }
 
+  const handleRetentionDaysChange = async (value: string) => {
+    setRetentionDaysInput(value)
+
+    // Only update backend if value is valid
+    const numValue = parseInt(value, 10)
+    if (!isNaN(numValue) && numValue >= MIN_RETENTION_DAYS && numValue <= MAX_RETENTION_DAYS) {
+      try {
+        await SetHistoryRetentionDays(numValue)
+        setRetentionDaysState(numValue)
+        setError(null)
+      } catch (err) {
+        console.error('Error setting history retention:', err)
+        setError(`Failed to set history retention: ${err}`)
+      }
+    }
+  }
+
+  const handleRetentionDaysBlur = () => {
+    // On blur, reset to last valid value if input is invalid
+    const numValue = parseInt(retentionDaysInput, 10)
+    if (isNaN(numValue) || numValue < MIN_RETENTION_DAYS || numValue > MAX_RETENTION_DAYS) {
+      setRetentionDaysInput(retentionDays.toString())
+    }
+  }
+
   const handleRemoveFile = async (filePath: string) => {
     try {
       await RemoveHeroesLayoutFile(filePath)
@@ -199,6 +271,81 @@ function HeroesLayoutPage() {
     }
   }
 
+  const handleFileTemplateChange = async (filePath: string, templateId: string) => {
+    try {
+      await SetHeroesLayoutFileTemplate(filePath, templateId)
+      const updatedFiles = await GetHeroesLayoutFiles()
+      setFiles(updatedFiles)
+      scheduleGridUpdate()
+    } catch (error) {
+      console.error('Error setting file template:', error)
+    }
+  }
+
+  const handleAccountTemplateChange = async (steamId64: string, templateId: string) => {
+    try {
+      await SetSteamAccountLayoutTemplate(steamId64, templateId)
+      const updatedAccounts = await GetSteamAccounts()
+      setSteamAccounts(updatedAccounts)
+      scheduleGridUpdate()
+    } catch (error) {
+      console.error('Error setting account template:', error)
+    }
+  }
+
+  const handleFileProfileChange = async (filePath: string, profileId: string) => {
+    try {
+      await SetHeroesLayoutFileProfile(filePath, profileId)
+      const updatedFiles = await GetHeroesLayoutFiles()
+      setFiles(updatedFiles)
+      scheduleGridUpdate()
+    } catch (error) {
+      console.error('Error setting file profile:', error)
+    }
+  }
+
+  const handleAccountProfileChange = async (steamId64: string, profileId: string) => {
+    try {
+      await SetSteamAccountProfile(steamId64, profileId)
+      const updatedAccounts = await GetSteamAccounts()
+      setSteamAccounts(updatedAccounts)
+      scheduleGridUpdate()
+    } catch (error) {
+      console.error('Error setting account profile:', error)
+    }
+  }
+
+  const handleProfilesChanged = async () => {
+    try {
+      setProfiles(await GetGenerationProfiles())
+      scheduleGridUpdate()
+    } catch (error) {
+      console.error('Error loading generation profiles:', error)
+    }
+  }
+
+  // Errors are rethrown so the jobs editor can show validation messages
+  const handleFileJobsSave = async (filePath: string, jobs: config.GenerationJob[]) => {
+    await SetHeroesLayoutFileJobs(filePath, jobs)
+    setFiles(await GetHeroesLayoutFiles())
+    scheduleGridUpdate()
+  }
+
+  const handleAccountJobsSave = async (steamId64: string, jobs: config.GenerationJob[]) => {
+    await SetSteamAccountJobs(steamId64, jobs)
+    setSteamAccounts(await GetSteamAccounts())
+    scheduleGridUpdate()
+  }
+
+  const handleTemplatesChanged = async () => {
+    try {
+      setTemplates(await GetLayoutTemplates())
+      scheduleGridUpdate()
+    } catch (error) {
+      console.error('Error loading layout templates:', error)
+    }
+  }
+
   const handleTogglePositionEnabled = async (id: string, enabled: boolean) => {
     try {
       await SetPositionEnabled(id, enabled)
@@ -352,7 +499,19 @@ function HeroesLayoutPage() {
             ) : (
               <div className="file-list">
                 {enabledAccounts.map((account) => (
-                  <AccountCard key={account.steamId64} account={account} />
+                  <AccountCard
+                    key={account.steamId64}
+                    account={account}
+                    templates={{
+                      options: templates,
+                      onChange: (templateId) => handleAccountTemplateChange(account.steamId64, templateId),
+                      onSaveJobs: (jobs) => handleAccountJobsSave(account.steamId64, jobs),
+                    }}
+                    profiles={{
+                      options: profiles,
+                      onChange: (profileId) => handleAccountProfileChange(account.steamId64, profileId),
+                    }}
+                  />
                 ))}
                 {files.map((file) => (
                   <div key={file.filePath} className={`file-card ${!file.enabled ? 'disabled' : ''} ${file.lastUpdateErrorMessage ? 'has-error' : ''}`}>
@@ -368,6 +527,30 @@ function HeroesLayoutPage() {
                       <div className="file-card-title">
                         <span className="file-path" title={file.filePath}>{file.filePath}</span>
                       </div>
+                      <ProfileSelect
+                        profiles={profiles}
+                        value={file.profileId}
+                        onChange={(profileId) => handleFileProfileChange(file.filePath, profileId)}
+                      />
+                      <TemplateSelect
+                        templates={templates}
+                        value={file.templateId}
+                        onChange={(templateId) => handleFileTemplateChange(file.filePath, templateId)}
+                      />
+                      <button
+                        className="btn btn-secondary btn-sm"
+                        onClick={() => setJobsFilePath(jobsFilePath === file.filePath ? null : file.filePath)}
+                        title="Generated grids"
+                      >
+                        Grids ({file.jobs?.length || 1})
+                      </button>
+                      <button
+                        className="btn btn-secondary btn-sm"
+                        onClick={() => setPreviewFilePath(previewFilePath === file.filePath ? null : file.filePath)}
+                        title="Preview the hero grids"
+                      >
+                        Preview
+                      </button>
                       <button
                         className="btn btn-icon btn-danger"
                         onClick={() => handleRemoveFile(file.filePath)}
@@ -376,11 +559,22 @@ function HeroesLayoutPage() {
                         <TrashIcon />
                       </button>
                     </div>
+                    {jobsFilePath === file.filePath && (
+                      <GenerationJobsEditor
+                        jobs={file.jobs}
+                        templates={templates}
+                        onSave={(jobs) => handleFileJobsSave(file.filePath, jobs)}
+                      />
+                    )}
+                    {previewFilePath === file.filePath && <GridPreviewPanel filePath={file.filePath} />}
                     <div className="file-card-footer">
                       <RelativeTime timestampMillis={file.lastUpdateTimestampMillis} prefix="Updated: " />
                       {file.lastUpdateErrorMessage && (
                         <span className="file-error">{file.lastUpdateErrorMessage}</span>
                       )}
+                      {file.lastUpdateWarnings?.map((warning) => (
+                        <span key={warning} className="file-warning">{warning}</span>
+                      ))}
                     </div>
                   </div>
                 ))}
@@ -460,8 +654,38 @@ function HeroesLayoutPage() {
                 onBlur={handleHeroesPerRowBlur}
               />
             </div>
+            <div className="setting-row">
+              <div className="setting-info">
+                <div className="setting-label">Stats History</div>
+                <div className="setting-description">
+                  Days of daily hero stats kept for trends ({MIN_RETENTION_DAYS}-{MAX_RETENTION_DAYS})
+                </div>
+              </div>
+              <input
+                type="number"
+                className="select"
+                min={MIN_RETENTION_DAYS}
+                max={MAX_RETENTION_DAYS}
+                required
+                value={retentionDaysInput}
+                onChange={(e) => handleRetentionDaysChange(e.target.value)}
+                onBlur={handleRetentionDaysBlur}
+              />
+            </div>
           </div>
         </div>
+
+        <GridImportCard accounts={enabledAccounts} files={files} />
+
+        <GridMirrorsCard accounts={enabledAccounts} onSynced={() => GetSteamAccounts().then(setSteamAccounts).catch(console.error)} />
+
+        <HeroListsCard positions={positions} getPositionName={getPositionName} onChanged={scheduleGridUpdate} />
+
+        <GenerationProfilesCard profiles={profiles} onChanged={handleProfilesChanged} />
+
+        <LayoutTemplatesCard templates={templates} onChanged={handleTemplatesChanged} />
+
+        <HeroHistoryCard positions={positions} getPositionName={getPositionName} />
       </div>
     </div>
   )
@@ -3,6 +3,10 @@ import { GetD2PTConfig, SetD2PTPeriod } from '../../wailsjs/go/main/App'
 import { config } from '../../wailsjs/go/models'
 import { AlertCircleIcon, XIcon } from '../components/Icons'
 import { useGridAutoUpdate } from '../components/GridAutoUpdateProvider'
+import CompositeProviderCard from '../components/CompositeProviderCard'
+import FileProviderCard from '../components/FileProviderCard'
+import ProviderHealthCard from '../components/ProviderHealthCard'
+import ProviderTestPanel from '../components/ProviderTestPanel'
 
 // Period options for D2PT provider
 const periodOptions = [
@@ -75,7 +79,7 @@ function ProvidersPage() {
       <div className="page-header">
         <div className="page-header-text">
           <h1 className="page-title">Providers</h1>
-          <p className="page-description">Configure Dota 2 hero statistics data source</p>
+          <p className="page-description">Configure Dota 2 hero statistics data sources</p>
         </div>
       </div>
 
@@ -118,8 +122,15 @@ function ProvidersPage() {
                 ))}
               </select>
             </div>
+            <ProviderTestPanel provider="d2pt" />
           </div>
         </div>
+
+        <FileProviderCard onSaved={scheduleGridUpdate} />
+
+        <CompositeProviderCard onSaved={scheduleGridUpdate} />
+
+        <ProviderHealthCard />
       </div>
     </div>
   )
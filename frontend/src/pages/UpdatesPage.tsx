@@ -1,19 +1,31 @@
-import {useState} from 'react'
+import {useEffect, useState} from 'react'
 import {
+    CancelAppUpdateDownload,
     CheckForAppUpdate,
     DownloadAppUpdate,
     OpenAppDirectory,
+    RestartApp,
 } from '../../wailsjs/go/main/App'
-import {Quit} from '../../wailsjs/runtime'
+import {BrowserOpenURL, EventsOn, Quit} from '../../wailsjs/runtime'
 import {main} from "../../wailsjs/go/models.ts";
-import { XIcon, AlertCircleIcon, DownloadIcon, SearchIcon, CheckCircleIcon, ClockIcon, FolderIcon } from '../components/Icons'
+import { XIcon, AlertCircleIcon, DownloadIcon, SearchIcon, CheckCircleIcon, ClockIcon, FolderIcon, RefreshIcon } from '../components/Icons'
 import RelativeTime from '../components/RelativeTime'
+import ReleaseNotes from '../components/ReleaseNotes'
+import { EventAppUpdateDownloadProgress } from '../events'
+import { formatBytes } from '../utils/format'
 
 interface DownloadResult {
     success: boolean
     message: string
 }
 
+// Payload of EventAppUpdateDownloadProgress
+interface DownloadProgress {
+    bytesDone: number
+    totalBytes: number
+    bytesPerSecond: number
+}
+
 interface UpdatesPageProps {
     state: main.AppUpdateState | null
     onStateChange: () => void
@@ -24,6 +36,19 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
     const [isDownloading, setIsDownloading] = useState(false)
     const [downloadResult, setDownloadResult] = useState<DownloadResult | null>(null)
     const [checkError, setCheckError] = useState<string | null>(null)
+    const [downloadProgress, setDownloadProgress] = useState<DownloadProgress | null>(null)
+    const [isRestarting, setIsRestarting] = useState(false)
+    const [updatedBannerDismissed, setUpdatedBannerDismissed] = useState(false)
+
+    useEffect(() => {
+        const offProgress = EventsOn(EventAppUpdateDownloadProgress, (progress: DownloadProgress) => {
+            setDownloadProgress(progress)
+        })
+
+        return () => {
+            offProgress()
+        }
+    }, [])
 
     const handleCheckForUpdates = async () => {
         setIsChecking(true)
@@ -46,12 +71,14 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
     const handleDownloadUpdate = async () => {
         setIsDownloading(true)
         setDownloadResult(null)
+        setDownloadProgress(null)
         try {
             await DownloadAppUpdate()
             setDownloadResult({
                 success: true,
-                message: 'Update downloaded successfully. Open the app directory and run the new version.'
+                message: 'Update installed successfully. Restart now to switch to the new version.'
             })
+            onStateChange()
         } catch (error) {
             console.error('Error downloading update:', error)
             setDownloadResult({
@@ -60,6 +87,15 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
             })
         } finally {
             setIsDownloading(false)
+            setDownloadProgress(null)
+        }
+    }
+
+    const handleCancelDownload = async () => {
+        try {
+            await CancelAppUpdateDownload()
+        } catch (error) {
+            console.error('Error cancelling download:', error)
         }
     }
 
@@ -72,6 +108,20 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
         Quit()
     }
 
+    const handleRestart = async () => {
+        setIsRestarting(true)
+        try {
+            await RestartApp()
+        } catch (error) {
+            console.error('Error restarting app:', error)
+            setDownloadResult({
+                success: false,
+                message: `Error restarting into the new version: ${error}. Open the app directory and run it manually.`
+            })
+            setIsRestarting(false)
+        }
+    }
+
     const handleOpenDirectory = async () => {
         try {
             await OpenAppDirectory()
@@ -84,7 +134,7 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
         setDownloadResult(null)
     }
 
-    const isLoading = isChecking || isDownloading
+    const isLoading = isChecking || isDownloading || isRestarting
 
     return (
         <div className="page">
@@ -96,6 +146,23 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
             </div>
 
             <div className="page-content">
+                {/* Post-update confirmation */}
+                {state?.updatedFromVersion && !updatedBannerDismissed && (
+                    <div className="download-result download-result-success">
+                        <div className="download-result-content">
+                            <div className="download-result-icon">
+                                <CheckCircleIcon/>
+                            </div>
+                            <div className="download-result-text">
+                                <p>Updated from {state.updatedFromVersion} to {state.currentVersion}.</p>
+                            </div>
+                        </div>
+                        <button className="download-result-dismiss" onClick={() => setUpdatedBannerDismissed(true)}>
+                            <XIcon/>
+                        </button>
+                    </div>
+                )}
+
                 {/* Error Banner */}
                 {checkError && (
                     <div className="error-banner">
@@ -162,6 +229,26 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
                     </div>
                 </div>
 
+                {/* Release Notes Card */}
+                {state?.updateAvailable && state.releaseNotes && (
+                    <div className="card">
+                        <div className="card-header">
+                            <h2 className="card-title">What's New</h2>
+                            {state.releaseUrl && (
+                                <button
+                                    className="btn btn-secondary btn-sm"
+                                    onClick={() => BrowserOpenURL(state.releaseUrl)}
+                                >
+                                    View on GitHub
+                                </button>
+                            )}
+                        </div>
+                        <div className="card-body">
+                            <ReleaseNotes markdown={state.releaseNotes}/>
+                        </div>
+                    </div>
+                )}
+
                 {/* Update Status Card */}
                 <div className="card">
                     <div className="card-header">
@@ -201,7 +288,18 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
                                 <span>{isChecking ? 'Checking...' : 'Check for Updates'}</span>
                             </button>
 
-                            {state?.updateAvailable && (
+                            {state?.updateInstalled && (
+                                <button
+                                    className="btn btn-primary"
+                                    onClick={handleRestart}
+                                    disabled={isLoading}
+                                >
+                                    <RefreshIcon/>
+                                    <span>{isRestarting ? 'Restarting...' : 'Restart Now'}</span>
+                                </button>
+                            )}
+
+                            {state?.updateAvailable && !state.updateInstalled && (
                                 <button
                                     className="btn btn-primary"
                                     onClick={handleDownloadUpdate}
@@ -211,14 +309,40 @@ function UpdatesPage({ state, onStateChange }: UpdatesPageProps) {
                                     <span>{isDownloading ? 'Downloading...' : 'Download Update'}</span>
                                 </button>
                             )}
+
+                            {isDownloading && (
+                                <button
+                                    className="btn btn-secondary"
+                                    onClick={handleCancelDownload}
+                                >
+                                    <XIcon/>
+                                    <span>Cancel</span>
+                                </button>
+                            )}
                         </div>
 
-                        {isLoading && (
+                        {isLoading && !downloadProgress && (
                             <div className="progress-bar mt-16">
                                 <div className="progress-bar-inner"></div>
                             </div>
                         )}
 
+                        {downloadProgress && (
+                            <div className="download-progress mt-16">
+                                <div className="progress-bar">
+                                    <div
+                                        className="progress-bar-determinate"
+                                        style={{width: `${downloadProgress.totalBytes > 0 ? (downloadProgress.bytesDone / downloadProgress.totalBytes) * 100 : 0}%`}}
+                                    ></div>
+                                </div>
+                                <div className="download-progress-text">
+                                    {formatBytes(downloadProgress.bytesDone)} / {formatBytes(downloadProgress.totalBytes)}
+                                    {' \u00b7 '}
+                                    {formatBytes(downloadProgress.bytesPerSecond)}/s
+                                </div>
+                            </div>
+                        )}
+
                         {downloadResult && (
                             <div className={`download-result mt-16 ${downloadResult.success ? 'download-result-success' : 'download-result-error'}`}>
                                 <div className="download-result-content">
@@ -40,3 +40,12 @@ export function getRefreshInterval(timestampMillis: number): number {
   if (seconds < 604800) return 300_000   // "N days ago" — refresh every 5min
   return 0                               // Full date — no refresh needed
 }
+
+export function formatBytes(bytes: number): string {
+  if (bytes < 1024) return `${Math.round(bytes)} B`
+  const kb = bytes / 1024
+  if (kb < 1024) return `${kb.toFixed(1)} KB`
+  const mb = kb / 1024
+  if (mb < 1024) return `${mb.toFixed(1)} MB`
+  return `${(mb / 1024).toFixed(2)} GB`
+}
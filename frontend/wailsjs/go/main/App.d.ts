@@ -3,29 +3,73 @@
 import {main} from '../models';
 import {config} from '../models';
 import {steam} from '../models';
+import {heroes} from '../models';
+import {heroesLayout} from '../models';
+import {history} from '../models';
+import {providers} from '../models';
 
 export function AddHeroesLayoutFile(arg1:string):Promise<void>;
 
+export function CancelAppUpdateDownload():Promise<void>;
+
 export function CheckForAppUpdate():Promise<void>;
 
 export function DownloadAppUpdate():Promise<void>;
 
+export function ExportGridConfig(arg1:string,arg2:string):Promise<string>;
+
+export function ExportGridPreview(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function ExportHistory(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function GetAppUpdateState():Promise<main.AppUpdateState>;
 
+export function GetCompositeConfig():Promise<config.CompositeConfig>;
+
 export function GetD2PTConfig():Promise<config.D2PTConfig>;
 
+export function GetFileProviderConfig():Promise<config.FileProviderConfig>;
+
+export function GetGenerationProfiles():Promise<Array<config.GenerationProfile>>;
+
+export function GetGridMirrors():Promise<Array<config.GridMirror>>;
+
+export function GetGridShareString(arg1:string,arg2:string):Promise<string>;
+
+export function GetHeroHistory(arg1:string,arg2:string,arg3:number,arg4:string,arg5:number):Promise<Array<history.SeriesPoint>>;
+
+export function GetHeroLists():Promise<config.HeroListsConfig>;
+
+export function GetHeroes():Promise<Array<heroes.Hero>>;
+
 export function GetHeroesLayoutFiles():Promise<Array<config.FileConfig>>;
 
 export function GetHeroesPerRow():Promise<number>;
 
+export function GetHeroesProviderNames():Promise<Array<string>>;
+
+export function GetHistoryRetentionDays():Promise<number>;
+
+export function GetHistorySources():Promise<Array<history.Source>>;
+
+export function GetLayoutTemplates():Promise<Array<config.LayoutTemplate>>;
+
 export function GetPositions():Promise<Array<config.PositionConfig>>;
 
+export function GetProviderHealth():Promise<Array<heroesLayout.ProviderHealth>>;
+
+export function GetRankings():Promise<Array<providers.Ranking>>;
+
 export function GetStartupEnabled():Promise<boolean>;
 
+export function GetSteamAccountGridNames(arg1:string):Promise<Array<string>>;
+
 export function GetSteamAccounts():Promise<Array<steam.SteamAccountView>>;
 
 export function GetSteamConfig():Promise<config.SteamConfig>;
 
+export function ImportGridConfig(arg1:string,arg2:Array<string>,arg3:Array<string>,arg4:string):Promise<Array<heroesLayout.GridImportResult>>;
+
 export function IsStartupSupported():Promise<boolean>;
 
 export function IsSteamPathValid():Promise<boolean>;
@@ -36,18 +80,58 @@ export function OpenDirectoryDialog():Promise<string>;
 
 export function OpenFileDialog():Promise<string>;
 
+export function OpenGridImportFile():Promise<string>;
+
+export function PreviewHeroesLayoutFile(arg1:string):Promise<Array<heroesLayout.GridPreview>>;
+
+export function PreviewSteamAccountGrid(arg1:string):Promise<Array<heroesLayout.GridPreview>>;
+
+export function RemoveGenerationProfile(arg1:string):Promise<void>;
+
+export function RemoveGridMirror(arg1:string):Promise<void>;
+
 export function RemoveHeroesLayoutFile(arg1:string):Promise<void>;
 
+export function RemoveLayoutTemplate(arg1:string):Promise<void>;
+
+export function RepairHeroesLayoutFile(arg1:string):Promise<heroesLayout.GridRepairResult>;
+
+export function RepairSteamAccountGrid(arg1:string):Promise<heroesLayout.GridRepairResult>;
+
 export function RescanSteamAccounts():Promise<void>;
 
+export function RestartApp():Promise<void>;
+
+export function SaveGenerationProfile(arg1:config.GenerationProfile):Promise<void>;
+
+export function SaveGridMirror(arg1:config.GridMirror):Promise<void>;
+
+export function SaveLayoutTemplate(arg1:config.LayoutTemplate):Promise<void>;
+
+export function SelectFileProviderSource():Promise<string>;
+
 export function SetAutoEnableNewAccounts(arg1:boolean):Promise<void>;
 
+export function SetCompositeConfig(arg1:config.CompositeConfig):Promise<void>;
+
 export function SetD2PTPeriod(arg1:string):Promise<void>;
 
+export function SetFileProviderSource(arg1:string):Promise<void>;
+
+export function SetHeroLists(arg1:config.HeroListsConfig):Promise<void>;
+
 export function SetHeroesLayoutFileEnabled(arg1:string,arg2:boolean):Promise<void>;
 
+export function SetHeroesLayoutFileJobs(arg1:string,arg2:Array<config.GenerationJob>):Promise<void>;
+
+export function SetHeroesLayoutFileProfile(arg1:string,arg2:string):Promise<void>;
+
+export function SetHeroesLayoutFileTemplate(arg1:string,arg2:string):Promise<void>;
+
 export function SetHeroesPerRow(arg1:number):Promise<void>;
 
+export function SetHistoryRetentionDays(arg1:number):Promise<void>;
+
 export function SetPositionEnabled(arg1:string,arg2:boolean):Promise<void>;
 
 export function SetPositions(arg1:Array<config.PositionConfig>):Promise<void>;
@@ -56,6 +140,20 @@ export function SetStartupEnabled(arg1:boolean):Promise<void>;
 
 export function SetSteamAccountEnabled(arg1:string,arg2:boolean):Promise<void>;
 
+export function SetSteamAccountJobs(arg1:string,arg2:Array<config.GenerationJob>):Promise<void>;
+
+export function SetSteamAccountLayoutTemplate(arg1:string,arg2:string):Promise<void>;
+
+export function SetSteamAccountProfile(arg1:string,arg2:string):Promise<void>;
+
 export function SetSteamPath(arg1:string):Promise<void>;
 
+export function SyncGridMirror(arg1:string,arg2:boolean):Promise<Array<heroesLayout.MirrorTargetResult>>;
+
+export function TestHeroesProvider(arg1:string,arg2:string):Promise<heroesLayout.ProviderTestResult>;
+
 export function UpdateHeroesLayout():Promise<void>;
+
+export function ValidateHeroesLayoutFile(arg1:string):Promise<heroesLayout.GridValidationReport>;
+
+export function ValidateSteamAccountGrid(arg1:string):Promise<heroesLayout.GridValidationReport>;
@@ -6,6 +6,10 @@ export function AddHeroesLayoutFile(arg1) {
   return window['go']['main']['App']['AddHeroesLayoutFile'](arg1);
 }
 
+export function CancelAppUpdateDownload() {
+  return window['go']['main']['App']['CancelAppUpdateDownload']();
+}
+
 export function CheckForAppUpdate() {
   return window['go']['main']['App']['CheckForAppUpdate']();
 }
@@ -14,14 +18,58 @@ export function DownloadAppUpdate() {
   return window['go']['main']['App']['DownloadAppUpdate']();
 }
 
+export function ExportGridConfig(arg1, arg2) {
+  return window['go']['main']['App']['ExportGridConfig'](arg1, arg2);
+}
+
+export function ExportGridPreview(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportGridPreview'](arg1, arg2, arg3);
+}
+
+export function ExportHistory(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportHistory'](arg1, arg2, arg3);
+}
+
 export function GetAppUpdateState() {
   return window['go']['main']['App']['GetAppUpdateState']();
 }
 
+export function GetCompositeConfig() {
+  return window['go']['main']['App']['GetCompositeConfig']();
+}
+
 export function GetD2PTConfig() {
   return window['go']['main']['App']['GetD2PTConfig']();
 }
 
+export function GetFileProviderConfig() {
+  return window['go']['main']['App']['GetFileProviderConfig']();
+}
+
+export function GetGenerationProfiles() {
+  return window['go']['main']['App']['GetGenerationProfiles']();
+}
+
+export function GetGridMirrors() {
+  return window['go']['main']['App']['GetGridMirrors']();
+}
+
+export function GetGridShareString(arg1, arg2) {
+  return window['go']['main']['App']['GetGridShareString'](arg1, arg2);
+}
+
+export function GetHeroHistory(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['GetHeroHistory'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function GetHeroLists() {
+  return window['go']['main']['App']['GetHeroLists']();
+}
+
+export function GetHeroes() {
+  return window['go']['main']['App']['GetHeroes']();
+}
+
 export function GetHeroesLayoutFiles() {
   return window['go']['main']['App']['GetHeroesLayoutFiles']();
 }
@@ -30,14 +78,42 @@ export function GetHeroesPerRow() {
   return window['go']['main']['App']['GetHeroesPerRow']();
 }
 
+export function GetHeroesProviderNames() {
+  return window['go']['main']['App']['GetHeroesProviderNames']();
+}
+
+export function GetHistoryRetentionDays() {
+  return window['go']['main']['App']['GetHistoryRetentionDays']();
+}
+
+export function GetHistorySources() {
+  return window['go']['main']['App']['GetHistorySources']();
+}
+
+export function GetLayoutTemplates() {
+  return window['go']['main']['App']['GetLayoutTemplates']();
+}
+
 export function GetPositions() {
   return window['go']['main']['App']['GetPositions']();
 }
 
+export function GetProviderHealth() {
+  return window['go']['main']['App']['GetProviderHealth']();
+}
+
+export function GetRankings() {
+  return window['go']['main']['App']['GetRankings']();
+}
+
 export function GetStartupEnabled() {
   return window['go']['main']['App']['GetStartupEnabled']();
 }
 
+export function GetSteamAccountGridNames(arg1) {
+  return window['go']['main']['App']['GetSteamAccountGridNames'](arg1);
+}
+
 export function GetSteamAccounts() {
   return window['go']['main']['App']['GetSteamAccounts']();
 }
@@ -46,6 +122,10 @@ export function GetSteamConfig() {
   return window['go']['main']['App']['GetSteamConfig']();
 }
 
+export function ImportGridConfig(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ImportGridConfig'](arg1, arg2, arg3, arg4);
+}
+
 export function IsStartupSupported() {
   return window['go']['main']['App']['IsStartupSupported']();
 }
@@ -66,30 +146,110 @@ export function OpenFileDialog() {
   return window['go']['main']['App']['OpenFileDialog']();
 }
 
+export function OpenGridImportFile() {
+  return window['go']['main']['App']['OpenGridImportFile']();
+}
+
+export function PreviewHeroesLayoutFile(arg1) {
+  return window['go']['main']['App']['PreviewHeroesLayoutFile'](arg1);
+}
+
+export function PreviewSteamAccountGrid(arg1) {
+  return window['go']['main']['App']['PreviewSteamAccountGrid'](arg1);
+}
+
+export function RemoveGenerationProfile(arg1) {
+  return window['go']['main']['App']['RemoveGenerationProfile'](arg1);
+}
+
+export function RemoveGridMirror(arg1) {
+  return window['go']['main']['App']['RemoveGridMirror'](arg1);
+}
+
 export function RemoveHeroesLayoutFile(arg1) {
   return window['go']['main']['App']['RemoveHeroesLayoutFile'](arg1);
 }
 
+export function RemoveLayoutTemplate(arg1) {
+  return window['go']['main']['App']['RemoveLayoutTemplate'](arg1);
+}
+
+export function RepairHeroesLayoutFile(arg1) {
+  return window['go']['main']['App']['RepairHeroesLayoutFile'](arg1);
+}
+
+export function RepairSteamAccountGrid(arg1) {
+  return window['go']['main']['App']['RepairSteamAccountGrid'](arg1);
+}
+
 export function RescanSteamAccounts() {
   return window['go']['main']['App']['RescanSteamAccounts']();
 }
 
+export function RestartApp() {
+  return window['go']['main']['App']['RestartApp']();
+}
+
+export function SaveGenerationProfile(arg1) {
+  return window['go']['main']['App']['SaveGenerationProfile'](arg1);
+}
+
+export function SaveGridMirror(arg1) {
+  return window['go']['main']['App']['SaveGridMirror'](arg1);
+}
+
+export function SaveLayoutTemplate(arg1) {
+  return window['go']['main']['App']['SaveLayoutTemplate'](arg1);
+}
+
+export function SelectFileProviderSource() {
+  return window['go']['main']['App']['SelectFileProviderSource']();
+}
+
 export function SetAutoEnableNewAccounts(arg1) {
   return window['go']['main']['App']['SetAutoEnableNewAccounts'](arg1);
 }
 
+export function SetCompositeConfig(arg1) {
+  return window['go']['main']['App']['SetCompositeConfig'](arg1);
+}
+
 export function SetD2PTPeriod(arg1) {
   return window['go']['main']['App']['SetD2PTPeriod'](arg1);
 }
 
+export function SetFileProviderSource(arg1) {
+  return window['go']['main']['App']['SetFileProviderSource'](arg1);
+}
+
+export function SetHeroLists(arg1) {
+  return window['go']['main']['App']['SetHeroLists'](arg1);
+}
+
 export function SetHeroesLayoutFileEnabled(arg1, arg2) {
   return window['go']['main']['App']['SetHeroesLayoutFileEnabled'](arg1, arg2);
 }
 
+export function SetHeroesLayoutFileJobs(arg1, arg2) {
+  return window['go']['main']['App']['SetHeroesLayoutFileJobs'](arg1, arg2);
+}
+
+export function SetHeroesLayoutFileProfile(arg1, arg2) {
+  return window['go']['main']['App']['SetHeroesLayoutFileProfile'](arg1, arg2);
+}
+
+export function SetHeroesLayoutFileTemplate(arg1, arg2) {
+  return window['go']['main']['App']['SetHeroesLayoutFileTemplate'](arg1, arg2);
+}
+
 export function SetHeroesPerRow(arg1) {
   return window['go']['main']['App']['SetHeroesPerRow'](arg1);
 }
 
+export function SetHistoryRetentionDays(arg1) {
+  return window['go']['main']['App']['SetHistoryRetentionDays'](arg1);
+}
+
 export function SetPositionEnabled(arg1, arg2) {
   return window['go']['main']['App']['SetPositionEnabled'](arg1, arg2);
 }
@@ -106,10 +266,38 @@ export function SetSteamAccountEnabled(arg1, arg2) {
   return window['go']['main']['App']['SetSteamAccountEnabled'](arg1, arg2);
 }
 
+export function SetSteamAccountJobs(arg1, arg2) {
+  return window['go']['main']['App']['SetSteamAccountJobs'](arg1, arg2);
+}
+
+export function SetSteamAccountLayoutTemplate(arg1, arg2) {
+  return window['go']['main']['App']['SetSteamAccountLayoutTemplate'](arg1, arg2);
+}
+
+export function SetSteamAccountProfile(arg1, arg2) {
+  return window['go']['main']['App']['SetSteamAccountProfile'](arg1, arg2);
+}
+
 export function SetSteamPath(arg1) {
   return window['go']['main']['App']['SetSteamPath'](arg1);
 }
 
+export function SyncGridMirror(arg1, arg2) {
+  return window['go']['main']['App']['SyncGridMirror'](arg1, arg2);
+}
+
+export function TestHeroesProvider(arg1, arg2) {
+  return window['go']['main']['App']['TestHeroesProvider'](arg1, arg2);
+}
+
 export function UpdateHeroesLayout() {
   return window['go']['main']['App']['UpdateHeroesLayout']();
 }
+
+export function ValidateHeroesLayoutFile(arg1) {
+  return window['go']['main']['App']['ValidateHeroesLayoutFile'](arg1);
+}
+
+export function ValidateSteamAccountGrid(arg1) {
+  return window['go']['main']['App']['ValidateSteamAccountGrid'](arg1);
+}
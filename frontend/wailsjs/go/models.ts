@@ -1,5 +1,51 @@
 export namespace config {
 	
+	export class CompositeConfig {
+	    mode: string;
+	    sources: CompositeSourceConfig[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CompositeConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.sources = this.convertValues(source["sources"], CompositeSourceConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CompositeSourceConfig {
+	    provider: string;
+	    weight: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompositeSourceConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.weight = source["weight"];
+	    }
+	}
 	export class D2PTConfig {
 	    period: string;
 	
@@ -17,6 +63,10 @@ export namespace config {
 	    enabled: boolean;
 	    lastUpdateTimestampMillis: number;
 	    lastUpdateErrorMessage: string;
+	    lastUpdateWarnings: string[];
+	    templateId: string;
+	    profileId: string;
+	    jobs: GenerationJob[];
 	
 	    static createFrom(source: any = {}) {
 	        return new FileConfig(source);
@@ -28,7 +78,283 @@ export namespace config {
 	        this.enabled = source["enabled"];
 	        this.lastUpdateTimestampMillis = source["lastUpdateTimestampMillis"];
 	        this.lastUpdateErrorMessage = source["lastUpdateErrorMessage"];
+	        this.lastUpdateWarnings = source["lastUpdateWarnings"];
+	        this.templateId = source["templateId"];
+	        this.profileId = source["profileId"];
+	        this.jobs = this.convertValues(source["jobs"], GenerationJob);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileProviderConfig {
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileProviderConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	    }
+	}
+	export class GenerationJob {
+	    id: string;
+	    name: string;
+	    displayName: string;
+	    provider: string;
+	    period: string;
+	    positions: string[];
+	    templateId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerationJob(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.displayName = source["displayName"];
+	        this.provider = source["provider"];
+	        this.period = source["period"];
+	        this.positions = source["positions"];
+	        this.templateId = source["templateId"];
+	    }
+	}
+	export class GenerationProfile {
+	    id: string;
+	    name: string;
+	    builtIn: boolean;
+	    positions: PositionConfig[];
+	    heroesPerRow: number;
+	    period: string;
+	    templateId: string;
+	    heroLists: HeroListsConfig;
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerationProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.builtIn = source["builtIn"];
+	        this.positions = this.convertValues(source["positions"], PositionConfig);
+	        this.heroesPerRow = source["heroesPerRow"];
+	        this.period = source["period"];
+	        this.templateId = source["templateId"];
+	        this.heroLists = this.convertValues(source["heroLists"], HeroListsConfig);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class GridMirror {
+	    id: string;
+	    sourceSteamId64: string;
+	    configName: string;
+	    autoSync: boolean;
+	    syncedHashes: {[key: string]: string};
+	    lastSyncTimestampMillis: number;
+	    lastSyncErrorMessage: string;
+	    lastSyncConflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GridMirror(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sourceSteamId64 = source["sourceSteamId64"];
+	        this.configName = source["configName"];
+	        this.autoSync = source["autoSync"];
+	        this.syncedHashes = source["syncedHashes"];
+	        this.lastSyncTimestampMillis = source["lastSyncTimestampMillis"];
+	        this.lastSyncErrorMessage = source["lastSyncErrorMessage"];
+	        this.lastSyncConflicts = source["lastSyncConflicts"];
+	    }
+	}
+	export class HeroListsConfig {
+	    exclude: number[];
+	    include: number[];
+	    pinned: {[key: string]: number[]};
+	
+	    static createFrom(source: any = {}) {
+	        return new HeroListsConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.exclude = source["exclude"];
+	        this.include = source["include"];
+	        this.pinned = source["pinned"];
+	    }
+	}
+	export class LabelFormat {
+	    header: string;
+	    format: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LabelFormat(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.header = source["header"];
+	        this.format = source["format"];
+	    }
+	}
+	export class LayoutGeometry {
+	    heroWidth: number;
+	    heroHeight: number;
+	    headerWidth: number;
+	    infoHeight: number;
+	    labelSpacing: number;
+	    rowSpacing: number;
+	    categorySpacing: number;
+	    columnSpacing: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LayoutGeometry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.heroWidth = source["heroWidth"];
+	        this.heroHeight = source["heroHeight"];
+	        this.headerWidth = source["headerWidth"];
+	        this.infoHeight = source["infoHeight"];
+	        this.labelSpacing = source["labelSpacing"];
+	        this.rowSpacing = source["rowSpacing"];
+	        this.categorySpacing = source["categorySpacing"];
+	        this.columnSpacing = source["columnSpacing"];
+	    }
+	}
+	export class LayoutSection {
+	    title: string;
+	    sortBy: string;
+	    count: number;
+	    filters: SectionFilters;
+	    labels: LabelFormat[];
+	    groupBy: string;
+	    columnHeroesPerRow: number;
+	    trendDays: number;
+	    facets: string;
+	    matchup: string;
+	    matchupHeroes: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LayoutSection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.sortBy = source["sortBy"];
+	        this.count = source["count"];
+	        this.filters = this.convertValues(source["filters"], SectionFilters);
+	        this.labels = this.convertValues(source["labels"], LabelFormat);
+	        this.groupBy = source["groupBy"];
+	        this.columnHeroesPerRow = source["columnHeroesPerRow"];
+	        this.trendDays = source["trendDays"];
+	        this.facets = source["facets"];
+	        this.matchup = source["matchup"];
+	        this.matchupHeroes = source["matchupHeroes"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LayoutTemplate {
+	    id: string;
+	    name: string;
+	    builtIn: boolean;
+	    geometry: LayoutGeometry;
+	    sections: LayoutSection[];
+	    hideRowHeaders: boolean;
+	    autoFit: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LayoutTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.builtIn = source["builtIn"];
+	        this.geometry = this.convertValues(source["geometry"], LayoutGeometry);
+	        this.sections = this.convertValues(source["sections"], LayoutSection);
+	        this.hideRowHeaders = source["hideRowHeaders"];
+	        this.autoFit = source["autoFit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class PositionConfig {
 	    id: string;
@@ -44,11 +370,29 @@ export namespace config {
 	        this.enabled = source["enabled"];
 	    }
 	}
+	export class SectionFilters {
+	    minMatches: number;
+	    minWinrate: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SectionFilters(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.minMatches = source["minMatches"];
+	        this.minWinrate = source["minWinrate"];
+	    }
+	}
 	export class SteamAccountConfig {
 	    steamId64: string;
 	    enabled: boolean;
 	    lastUpdateTimestampMillis: number;
 	    lastUpdateErrorMessage: string;
+	    lastUpdateWarnings: string[];
+	    templateId: string;
+	    profileId: string;
+	    jobs: GenerationJob[];
 	
 	    static createFrom(source: any = {}) {
 	        return new SteamAccountConfig(source);
@@ -60,12 +404,35 @@ export namespace config {
 	        this.enabled = source["enabled"];
 	        this.lastUpdateTimestampMillis = source["lastUpdateTimestampMillis"];
 	        this.lastUpdateErrorMessage = source["lastUpdateErrorMessage"];
+	        this.lastUpdateWarnings = source["lastUpdateWarnings"];
+	        this.templateId = source["templateId"];
+	        this.profileId = source["profileId"];
+	        this.jobs = this.convertValues(source["jobs"], GenerationJob);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 	export class SteamConfig {
 	    steamPath: string;
 	    autoEnableNewAccounts: boolean;
 	    accounts: SteamAccountConfig[];
+	    mirrors: GridMirror[];
 	
 	    static createFrom(source: any = {}) {
 	        return new SteamConfig(source);
@@ -76,6 +443,7 @@ export namespace config {
 	        this.steamPath = source["steamPath"];
 	        this.autoEnableNewAccounts = source["autoEnableNewAccounts"];
 	        this.accounts = this.convertValues(source["accounts"], SteamAccountConfig);
+	        this.mirrors = this.convertValues(source["mirrors"], GridMirror);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -99,13 +467,288 @@ export namespace config {
 
 }
 
+export namespace heroes {
+	
+	export class Hero {
+	    id: number;
+	    shortName: string;
+	    localizedName: string;
+	    primaryAttribute: any;
+	    attackType: any;
+	    roles: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Hero(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.shortName = source["shortName"];
+	        this.localizedName = source["localizedName"];
+	        this.primaryAttribute = source["primaryAttribute"];
+	        this.attackType = source["attackType"];
+	        this.roles = source["roles"];
+	    }
+	}
+
+}
+
+export namespace heroesLayout {
+	
+	export class GridImportResult {
+	    filePath: string;
+	    configName: string;
+	    status: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.configName = source["configName"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	    }
+	}
+	export class GridIssue {
+	    severity: string;
+	    configName: string;
+	    message: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridIssue(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.severity = source["severity"];
+	        this.configName = source["configName"];
+	        this.message = source["message"];
+	    }
+	}
+	export class GridPreview {
+	    filePath: string;
+	    configName: string;
+	    svg: string;
+	    width: number;
+	    height: number;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GridPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.configName = source["configName"];
+	        this.svg = source["svg"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class GridRepairResult {
+	    filePath: string;
+	    backupPath: string;
+	    configs: number;
+	    changes: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GridRepairResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.backupPath = source["backupPath"];
+	        this.configs = source["configs"];
+	        this.changes = source["changes"];
+	    }
+	}
+	export class GridValidationReport {
+	    filePath: string;
+	    version: number;
+	    parseable: boolean;
+	    configCount: number;
+	    issues: GridIssue[];
+	    repairable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GridValidationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filePath = source["filePath"];
+	        this.version = source["version"];
+	        this.parseable = source["parseable"];
+	        this.configCount = source["configCount"];
+	        this.issues = this.convertValues(source["issues"], GridIssue);
+	        this.repairable = source["repairable"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MirrorTargetResult {
+	    steamId64: string;
+	    filePath: string;
+	    status: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MirrorTargetResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steamId64 = source["steamId64"];
+	        this.filePath = source["filePath"];
+	        this.status = source["status"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ProviderHealth {
+	    provider: string;
+	    kind: string;
+	    diagnostics: providers.FetchDiagnostics[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.kind = source["kind"];
+	        this.diagnostics = this.convertValues(source["diagnostics"], providers.FetchDiagnostics);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProviderTestResult {
+	    heroes: providers.Hero[];
+	    diagnostics: providers.FetchDiagnostics;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProviderTestResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.heroes = this.convertValues(source["heroes"], providers.Hero);
+	        this.diagnostics = this.convertValues(source["diagnostics"], providers.FetchDiagnostics);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace history {
+	
+	export class SeriesPoint {
+	    date: string;
+	    matches: number;
+	    wins: number;
+	    winrate: number;
+	    rating: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SeriesPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.matches = source["matches"];
+	        this.wins = source["wins"];
+	        this.winrate = source["winrate"];
+	        this.rating = source["rating"];
+	    }
+	}
+	export class Source {
+	    provider: string;
+	    period: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Source(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.period = source["period"];
+	    }
+	}
+
+}
+
 export namespace main {
 	
 	export class AppUpdateState {
 	    currentVersion: string;
 	    latestVersion: string;
+	    updatedFromVersion: string;
+	    releaseNotes: string;
+	    releaseUrl: string;
 	    lastCheckTimeMillis: number;
 	    updateAvailable: boolean;
+	    updateInstalled: boolean;
 	    appDirectory: string;
 	
 	    static createFrom(source: any = {}) {
@@ -116,14 +759,133 @@ export namespace main {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.currentVersion = source["currentVersion"];
 	        this.latestVersion = source["latestVersion"];
+	        this.updatedFromVersion = source["updatedFromVersion"];
+	        this.releaseNotes = source["releaseNotes"];
+	        this.releaseUrl = source["releaseUrl"];
 	        this.lastCheckTimeMillis = source["lastCheckTimeMillis"];
 	        this.updateAvailable = source["updateAvailable"];
+	        this.updateInstalled = source["updateInstalled"];
 	        this.appDirectory = source["appDirectory"];
 	    }
 	}
 
 }
 
+export namespace providers {
+	
+	export class FetchDiagnostics {
+	    provider: string;
+	    request: string;
+	    url: string;
+	    timestampMillis: number;
+	    latencyMillis: number;
+	    statusCode: number;
+	    payloadBytes: number;
+	    heroCount: number;
+	    cacheHit: boolean;
+	    error: string;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FetchDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.request = source["request"];
+	        this.url = source["url"];
+	        this.timestampMillis = source["timestampMillis"];
+	        this.latencyMillis = source["latencyMillis"];
+	        this.statusCode = source["statusCode"];
+	        this.payloadBytes = source["payloadBytes"];
+	        this.heroCount = source["heroCount"];
+	        this.cacheHit = source["cacheHit"];
+	        this.error = source["error"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class Hero {
+	    hero_id: number;
+	    matches: number;
+	    wins: number;
+	    hero_name: string;
+	    d2pt_rating: number;
+	    facet: number;
+	    sources?: HeroSource[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Hero(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hero_id = source["hero_id"];
+	        this.matches = source["matches"];
+	        this.wins = source["wins"];
+	        this.hero_name = source["hero_name"];
+	        this.d2pt_rating = source["d2pt_rating"];
+	        this.facet = source["facet"];
+	        this.sources = this.convertValues(source["sources"], HeroSource);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HeroSource {
+	    provider: string;
+	    weight: number;
+	    matches: number;
+	    wins: number;
+	    d2pt_rating: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeroSource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.weight = source["weight"];
+	        this.matches = source["matches"];
+	        this.wins = source["wins"];
+	        this.d2pt_rating = source["d2pt_rating"];
+	    }
+	}
+	export class Ranking {
+	    id: string;
+	    name: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Ranking(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	    }
+	}
+
+}
+
 export namespace steam {
 	
 	export class SteamAccountView {
@@ -133,8 +895,12 @@ export namespace steam {
 	    personaName: string;
 	    avatarBase64: string;
 	    enabled: boolean;
+	    templateId: string;
+	    profileId: string;
+	    jobs: config.GenerationJob[];
 	    lastUpdateTimestampMillis: number;
 	    lastUpdateErrorMessage: string;
+	    lastUpdateWarnings: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new SteamAccountView(source);
@@ -148,9 +914,31 @@ export namespace steam {
 	        this.personaName = source["personaName"];
 	        this.avatarBase64 = source["avatarBase64"];
 	        this.enabled = source["enabled"];
+	        this.templateId = source["templateId"];
+	        this.profileId = source["profileId"];
+	        this.jobs = this.convertValues(source["jobs"], config.GenerationJob);
 	        this.lastUpdateTimestampMillis = source["lastUpdateTimestampMillis"];
 	        this.lastUpdateErrorMessage = source["lastUpdateErrorMessage"];
+	        this.lastUpdateWarnings = source["lastUpdateWarnings"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
@@ -13,11 +13,15 @@ const (
 	apiGithubUrl = "https://api.github.com"
 	repoOwner    = "MillQK"
 	repoName     = "d2tool"
+
+	releasesPerPage = 100
 )
 
 type Client interface {
 	// GetLatestRelease fetches the latest release
 	GetLatestRelease() (*Release, error)
+	// GetReleases fetches the most recent releases, newest first
+	GetReleases() ([]Release, error)
 }
 
 type HttpClient struct {
@@ -44,34 +48,48 @@ func NewHttpClient(apiUrl string) *HttpClient {
 func (c *HttpClient) GetLatestRelease() (*Release, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", c.apiUrl, repoOwner, repoName)
 
+	var release Release
+	if err := c.getJSON(url, &release); err != nil {
+		return nil, err
+	}
+
+	return &release, nil
+}
+
+func (c *HttpClient) GetReleases() ([]Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d", c.apiUrl, repoOwner, repoName, releasesPerPage)
+
+	var releases []Release
+	if err := c.getJSON(url, &releases); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+func (c *HttpClient) getJSON(url string, target any) error {
 	request, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	request.Header.Set("Accept", "application/vnd.github+json")
 
 	response, err := c.httpClient.Do(request)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d: %s", response.StatusCode, response.Status)
+		return fmt.Errorf("GitHub API returned status %d: %s", response.StatusCode, response.Status)
 	}
 
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var release Release
-	err = json.Unmarshal(responseBody, &release)
-	if err != nil {
-		return nil, err
-	}
-
-	return &release, nil
+	return json.Unmarshal(responseBody, target)
 }
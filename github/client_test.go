@@ -340,6 +340,51 @@ func TestGetLatestRelease_MultipleAssets(t *testing.T) {
 	}
 }
 
+func TestGetReleases_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/MillQK/d2tool/releases" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("expected per_page=100, got %q", r.URL.Query().Get("per_page"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"tag_name": "release/0.0.7", "name": "0.0.7", "body": "Second"},
+			{"tag_name": "release/0.0.6", "name": "0.0.6", "body": "First"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(server.URL)
+	releases, err := client.GetReleases()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("expected 2 releases, got %d", len(releases))
+	}
+	if releases[0].Name != "0.0.7" || releases[1].Body != "First" {
+		t.Errorf("unexpected releases: %+v", releases)
+	}
+}
+
+func TestGetReleases_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(server.URL)
+	_, err := client.GetReleases()
+
+	if err == nil {
+		t.Error("expected error for 500 response")
+	}
+}
+
 func TestHttpClient_HasTimeout(t *testing.T) {
 	client := NewHttpClient("https://example.com")
 
@@ -8,6 +8,7 @@ require (
 	github.com/wailsapp/wails/v2 v2.11.0
 	golang.org/x/sys v0.38.0
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,199 @@
+package heroes
+
+import (
+	"cmp"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// OverrideFileName is the dataset file next to the executable that replaces the
+// embedded one, so new heroes can be added without a release
+const OverrideFileName = "d2tool_heroes.json"
+
+//go:embed heroes.json
+var embeddedDataset []byte
+
+// Attribute is the primary attribute of a hero
+type Attribute string
+
+const (
+	AttributeStrength     Attribute = "str"
+	AttributeAgility      Attribute = "agi"
+	AttributeIntelligence Attribute = "int"
+	AttributeUniversal    Attribute = "all"
+)
+
+// Attributes lists the primary attributes in the order of the in-game hero grid
+var Attributes = []Attribute{AttributeStrength, AttributeAgility, AttributeIntelligence, AttributeUniversal}
+
+// AttackType is the attack type of a hero
+type AttackType string
+
+const (
+	AttackTypeMelee  AttackType = "Melee"
+	AttackTypeRanged AttackType = "Ranged"
+)
+
+// Hero contains the static metadata of a Dota 2 hero
+type Hero struct {
+	ID               int        `json:"id"`
+	ShortName        string     `json:"shortName"` // internal name without the npc_dota_hero_ prefix
+	LocalizedName    string     `json:"localizedName"`
+	PrimaryAttribute Attribute  `json:"primaryAttribute"`
+	AttackType       AttackType `json:"attackType"`
+	Roles            []string   `json:"roles"`
+}
+
+// dataset is the JSON format of the hero data
+type dataset struct {
+	Version string `json:"version"`
+	Heroes  []Hero `json:"heroes"`
+}
+
+// Registry looks up hero metadata by ID or short name
+type Registry struct {
+	mu          sync.RWMutex
+	version     string
+	byID        map[int]Hero
+	byShortName map[string]Hero
+}
+
+var (
+	defaultRegistry     *Registry
+	defaultRegistryOnce sync.Once
+)
+
+// Default returns the registry loaded from the embedded dataset
+func Default() *Registry {
+	defaultRegistryOnce.Do(func() {
+		registry, err := ParseRegistry(embeddedDataset)
+		if err != nil {
+			panic(fmt.Sprintf("invalid embedded heroes dataset: %v", err))
+		}
+		defaultRegistry = registry
+	})
+	return defaultRegistry
+}
+
+// ParseRegistry creates a registry from a JSON dataset
+func ParseRegistry(data []byte) (*Registry, error) {
+	registry := &Registry{}
+	if err := registry.load(data); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// RefreshFromFile replaces the registry contents with the dataset in the given file.
+// The current contents are kept if the file can't be read or is invalid.
+func (r *Registry) RefreshFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading heroes dataset: %w", err)
+	}
+	return r.load(data)
+}
+
+func (r *Registry) load(data []byte) error {
+	var parsed dataset
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("error parsing heroes dataset: %w", err)
+	}
+	if parsed.Version == "" {
+		return fmt.Errorf("heroes dataset has no version")
+	}
+	if len(parsed.Heroes) == 0 {
+		return fmt.Errorf("heroes dataset has no heroes")
+	}
+
+	byID := make(map[int]Hero, len(parsed.Heroes))
+	byShortName := make(map[string]Hero, len(parsed.Heroes))
+	for _, hero := range parsed.Heroes {
+		if err := validateHero(hero); err != nil {
+			return fmt.Errorf("invalid hero %d in dataset: %w", hero.ID, err)
+		}
+		if _, ok := byID[hero.ID]; ok {
+			return fmt.Errorf("duplicate hero id %d in dataset", hero.ID)
+		}
+		byID[hero.ID] = hero
+		byShortName[hero.ShortName] = hero
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.version = parsed.Version
+	r.byID = byID
+	r.byShortName = byShortName
+	return nil
+}
+
+func validateHero(hero Hero) error {
+	if hero.ID <= 0 {
+		return fmt.Errorf("id must be positive")
+	}
+	if hero.LocalizedName == "" || hero.ShortName == "" {
+		return fmt.Errorf("name and short name are required")
+	}
+	if !slices.Contains(Attributes, hero.PrimaryAttribute) {
+		return fmt.Errorf("unknown primary attribute %q", hero.PrimaryAttribute)
+	}
+	if hero.AttackType != AttackTypeMelee && hero.AttackType != AttackTypeRanged {
+		return fmt.Errorf("unknown attack type %q", hero.AttackType)
+	}
+	return nil
+}
+
+// Version returns the version of the loaded dataset
+func (r *Registry) Version() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// Get returns the hero with the given ID
+func (r *Registry) Get(id int) (Hero, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hero, ok := r.byID[id]
+	return cloneHero(hero), ok
+}
+
+// GetByShortName returns the hero with the given short name, with or without the npc_dota_hero_ prefix
+func (r *Registry) GetByShortName(shortName string) (Hero, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hero, ok := r.byShortName[strings.TrimPrefix(shortName, "npc_dota_hero_")]
+	return cloneHero(hero), ok
+}
+
+// Name returns the localized name of a hero, or an empty string for unknown IDs
+func (r *Registry) Name(id int) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byID[id].LocalizedName
+}
+
+// All returns every hero sorted by ID
+func (r *Registry) All() []Hero {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Hero, 0, len(r.byID))
+	for _, hero := range r.byID {
+		result = append(result, cloneHero(hero))
+	}
+	slices.SortFunc(result, func(a, b Hero) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+func cloneHero(hero Hero) Hero {
+	hero.Roles = slices.Clone(hero.Roles)
+	return hero
+}
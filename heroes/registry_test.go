@@ -0,0 +1,118 @@
+package heroes
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDefault_EmbeddedDataset(t *testing.T) {
+	registry := Default()
+
+	if registry.Version() == "" {
+		t.Error("expected embedded dataset to have a version")
+	}
+
+	all := registry.All()
+	if len(all) < 120 {
+		t.Errorf("expected every hero in the embedded dataset, got %d", len(all))
+	}
+	if !slices.IsSortedFunc(all, func(a, b Hero) int { return a.ID - b.ID }) {
+		t.Error("expected heroes sorted by ID")
+	}
+
+	hero, ok := registry.Get(1)
+	if !ok {
+		t.Fatal("expected hero 1 to exist")
+	}
+	if hero.LocalizedName != "Anti-Mage" || hero.PrimaryAttribute != AttributeAgility || hero.AttackType != AttackTypeMelee {
+		t.Errorf("unexpected hero 1: %+v", hero)
+	}
+}
+
+func TestRegistry_Lookups(t *testing.T) {
+	registry := Default()
+
+	if _, ok := registry.Get(24); ok {
+		t.Error("expected unused hero ID 24 to be unknown")
+	}
+	if registry.Name(24) != "" {
+		t.Error("expected empty name for unknown hero")
+	}
+	if registry.Name(11) != "Shadow Fiend" {
+		t.Errorf("expected Shadow Fiend, got %q", registry.Name(11))
+	}
+
+	for _, shortName := range []string{"nevermore", "npc_dota_hero_nevermore"} {
+		hero, ok := registry.GetByShortName(shortName)
+		if !ok || hero.ID != 11 {
+			t.Errorf("%s: expected hero 11, got %+v", shortName, hero)
+		}
+	}
+}
+
+func TestRegistry_ReturnsCopies(t *testing.T) {
+	registry := Default()
+
+	hero, _ := registry.Get(1)
+	hero.Roles[0] = "changed"
+
+	again, _ := registry.Get(1)
+	if again.Roles[0] == "changed" {
+		t.Error("modifying returned hero should not affect registry")
+	}
+}
+
+func TestRegistry_RefreshFromFile(t *testing.T) {
+	registry, err := ParseRegistry([]byte(`{"version":"1","heroes":[{"id":1,"shortName":"antimage","localizedName":"Anti-Mage","primaryAttribute":"agi","attackType":"Melee"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), OverrideFileName)
+	os.WriteFile(path, []byte(`{"version":"2","heroes":[{"id":200,"shortName":"new_hero","localizedName":"New Hero","primaryAttribute":"all","attackType":"Ranged","roles":["Support"]}]}`), 0644)
+
+	if err := registry.RefreshFromFile(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry.Version() != "2" || registry.Name(200) != "New Hero" {
+		t.Errorf("expected refreshed dataset, got version %q", registry.Version())
+	}
+	if _, ok := registry.Get(1); ok {
+		t.Error("expected heroes of the previous dataset to be replaced")
+	}
+}
+
+func TestRegistry_RefreshKeepsDataOnError(t *testing.T) {
+	registry, _ := ParseRegistry([]byte(`{"version":"1","heroes":[{"id":1,"shortName":"antimage","localizedName":"Anti-Mage","primaryAttribute":"agi","attackType":"Melee"}]}`))
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"invalid json", `not json`},
+		{"no version", `{"heroes":[{"id":2,"shortName":"axe","localizedName":"Axe","primaryAttribute":"str","attackType":"Melee"}]}`},
+		{"no heroes", `{"version":"2","heroes":[]}`},
+		{"unknown attribute", `{"version":"2","heroes":[{"id":2,"shortName":"axe","localizedName":"Axe","primaryAttribute":"strength","attackType":"Melee"}]}`},
+		{"duplicate id", `{"version":"2","heroes":[{"id":2,"shortName":"axe","localizedName":"Axe","primaryAttribute":"str","attackType":"Melee"},{"id":2,"shortName":"axe2","localizedName":"Axe","primaryAttribute":"str","attackType":"Melee"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), OverrideFileName)
+			os.WriteFile(path, []byte(tt.content), 0644)
+
+			if err := registry.RefreshFromFile(path); err == nil {
+				t.Error("expected error")
+			}
+			if registry.Version() != "1" || registry.Name(1) != "Anti-Mage" {
+				t.Error("expected previous dataset to be kept")
+			}
+		})
+	}
+
+	if err := registry.RefreshFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,110 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"fmt"
+	"math"
+)
+
+// Size of the area shown by Dota's hero grid editor. Categories past the width are cut off;
+// the editor scrolls vertically, but not far enough to reach categories past the height.
+const (
+	gridCanvasWidth  = 1200
+	gridCanvasHeight = 4000
+)
+
+const (
+	// minAutoFitPercent keeps scaled hero cards recognisable
+	minAutoFitPercent  = 40
+	autoFitPercentStep = 5
+)
+
+// gridBounds is the bounding box of a generated grid, from the origin to the furthest category edge
+type gridBounds struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// layoutBounds returns the bounding box of the categories of a config
+func layoutBounds(categories []heroGridPosition) gridBounds {
+	var bounds gridBounds
+	for _, cat := range categories {
+		bounds.Width = max(bounds.Width, int(math.Ceil(cat.XPosition+cat.Width)))
+		bounds.Height = max(bounds.Height, int(math.Ceil(cat.YPosition+cat.Height)))
+	}
+	return bounds
+}
+
+// fits reports whether the grid is entirely inside the canvas
+func (b gridBounds) fits() bool {
+	return b.Width <= gridCanvasWidth && b.Height <= gridCanvasHeight
+}
+
+// overflowWarnings describes how far a grid extends past the canvas, nil when it fits
+func (b gridBounds) overflowWarnings() []string {
+	var warnings []string
+	if b.Width > gridCanvasWidth {
+		warnings = append(warnings, fmt.Sprintf("grid is %dpx wide, %dpx more than the %dpx hero grid canvas", b.Width, b.Width-gridCanvasWidth, gridCanvasWidth))
+	}
+	if b.Height > gridCanvasHeight {
+		warnings = append(warnings, fmt.Sprintf("grid is %dpx high, %dpx more than the %dpx hero grid canvas", b.Height, b.Height-gridCanvasHeight, gridCanvasHeight))
+	}
+	return warnings
+}
+
+// generateFittedConfig generates the config of a layout and, when it overflows the canvas, applies
+// the template's auto-fit mode. The warnings tell what auto-fit changed and what still doesn't fit.
+func generateFittedConfig(configNamePrefix string, layout layoutData, template config.LayoutTemplate) (heroGridCategory, []string) {
+	generated := generateHeroesLayoutConfigs(configNamePrefix, layout, template)[0]
+	bounds := layoutBounds(generated.Categories)
+	if bounds.fits() {
+		return generated, nil
+	}
+
+	var warnings []string
+	switch template.AutoFit {
+	case config.AutoFitHeroesPerRow:
+		// Fewer heroes per row only makes the grid narrower, so stop as soon as the width fits
+		fitted := layout
+		for fitted.heroesPerRow > 1 && bounds.Width > gridCanvasWidth {
+			fitted.heroesPerRow--
+			generated = generateHeroesLayoutConfigs(configNamePrefix, fitted, template)[0]
+			bounds = layoutBounds(generated.Categories)
+		}
+		if fitted.heroesPerRow != layout.heroesPerRow {
+			warnings = append(warnings, fmt.Sprintf("heroes per row reduced from %d to %d to fit the hero grid canvas", layout.heroesPerRow, fitted.heroesPerRow))
+		}
+
+	case config.AutoFitScale:
+		// Text doesn't shrink with the cards, so the first estimate may need a few more steps
+		ratio := min(float64(gridCanvasWidth)/float64(bounds.Width), float64(gridCanvasHeight)/float64(bounds.Height))
+		percent := max(int(ratio*100)/autoFitPercentStep*autoFitPercentStep, minAutoFitPercent)
+		for ; percent >= minAutoFitPercent; percent -= autoFitPercentStep {
+			scaled := template
+			scaled.Geometry = scaleGeometry(template.Geometry, float64(percent)/100)
+			generated = generateHeroesLayoutConfigs(configNamePrefix, layout, scaled)[0]
+			bounds = layoutBounds(generated.Categories)
+			if bounds.fits() || percent == minAutoFitPercent {
+				break
+			}
+		}
+		warnings = append(warnings, fmt.Sprintf("hero cards scaled to %d%% to fit the hero grid canvas", percent))
+	}
+
+	return generated, append(warnings, bounds.overflowWarnings()...)
+}
+
+// scaleGeometry scales the hero cards and the spacings between them. Header and label sizes hold text
+// and are kept.
+func scaleGeometry(geometry config.LayoutGeometry, scale float64) config.LayoutGeometry {
+	scaleSize := func(size int) int {
+		return int(math.Floor(float64(size) * scale))
+	}
+
+	geometry.HeroWidth = max(scaleSize(geometry.HeroWidth), 1)
+	geometry.HeroHeight = max(scaleSize(geometry.HeroHeight), 1)
+	geometry.RowSpacing = scaleSize(geometry.RowSpacing)
+	geometry.CategorySpacing = scaleSize(geometry.CategorySpacing)
+	geometry.ColumnSpacing = scaleSize(geometry.ColumnSpacing)
+	return geometry
+}
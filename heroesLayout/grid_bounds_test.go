@@ -0,0 +1,93 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/providers"
+	"strings"
+	"testing"
+)
+
+// wideLayout returns one position with 40 heroes laid out 30 per row, wider than the canvas
+func wideLayout() layoutData {
+	var heroes []providers.Hero
+	for i := 0; i < 40; i++ {
+		heroes = append(heroes, providers.Hero{HeroID: i + 1, Matches: 1000 - i, Wins: 500, D2PTRating: 1000 - i})
+	}
+	return layoutData{
+		positions:        []string{"pos 1"},
+		positionToHeroes: map[string][]providers.Hero{"pos 1": heroes},
+		heroesPerRow:     30,
+	}
+}
+
+func TestLayoutBounds(t *testing.T) {
+	bounds := layoutBounds([]heroGridPosition{
+		{XPosition: 0, YPosition: 0},
+		{XPosition: 100, YPosition: 30, Width: 70, Height: 110},
+		{XPosition: 170, YPosition: 30, Width: 70.5, Height: 110},
+	})
+
+	if bounds != (gridBounds{Width: 241, Height: 140}) {
+		t.Errorf("unexpected bounds %+v", bounds)
+	}
+}
+
+func TestGenerateFittedConfig(t *testing.T) {
+	tests := []struct {
+		name            string
+		autoFit         string
+		heroesPerRow    int
+		expectWarnings  []string
+		expectFits      bool
+		expectHeroWidth float64
+		expectFirstRow  int
+	}{
+		{name: "fits", heroesPerRow: 15, expectFits: true, expectHeroWidth: 70, expectFirstRow: 15},
+		{name: "overflow without auto-fit", heroesPerRow: 30, expectWarnings: []string{"2200px wide"}, expectHeroWidth: 70, expectFirstRow: 30},
+		{name: "heroes per row", autoFit: config.AutoFitHeroesPerRow, heroesPerRow: 30, expectWarnings: []string{"from 30 to 15"}, expectFits: true, expectHeroWidth: 70, expectFirstRow: 15},
+		{name: "scale", autoFit: config.AutoFitScale, heroesPerRow: 30, expectWarnings: []string{"scaled to 50%"}, expectFits: true, expectHeroWidth: 35, expectFirstRow: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := wideLayout()
+			layout.heroesPerRow = tt.heroesPerRow
+			template := config.DefaultLayoutTemplate()
+			template.Sections = template.Sections[1:]
+			template.AutoFit = tt.autoFit
+
+			generated, warnings := generateFittedConfig(d2tPrefix, layout, template)
+
+			if fits := layoutBounds(generated.Categories).fits(); fits != tt.expectFits {
+				t.Errorf("expected fits=%v, got bounds %+v", tt.expectFits, layoutBounds(generated.Categories))
+			}
+			if len(warnings) != len(tt.expectWarnings) {
+				t.Fatalf("expected %d warnings, got %v", len(tt.expectWarnings), warnings)
+			}
+			for i, expected := range tt.expectWarnings {
+				if !strings.Contains(warnings[i], expected) {
+					t.Errorf("expected warning to mention %q, got %q", expected, warnings[i])
+				}
+			}
+
+			var heroCards []heroGridPosition
+			for _, cat := range generated.Categories {
+				if len(cat.HeroIDs) > 0 {
+					heroCards = append(heroCards, cat)
+				}
+			}
+			if heroCards[0].Width != tt.expectHeroWidth {
+				t.Errorf("expected hero width %v, got %v", tt.expectHeroWidth, heroCards[0].Width)
+			}
+			firstRow := 0
+			for _, card := range heroCards {
+				if card.YPosition == heroCards[0].YPosition {
+					firstRow++
+				}
+			}
+			if firstRow != tt.expectFirstRow {
+				t.Errorf("expected %d heroes in the first row, got %d", tt.expectFirstRow, firstRow)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package heroesLayout
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// readHeroGridConfig reads and parses a hero_grid_config.json file
+func readHeroGridConfig(path string) (heroGridConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return heroGridConfig{}, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	gridConfig := heroGridConfig{
+		Version: currentGridVersion,
+	}
+	if err := json.Unmarshal(data, &gridConfig); err != nil {
+		return heroGridConfig{}, fmt.Errorf("error parsing config file: %w", err)
+	}
+	return gridConfig, nil
+}
+
+// modifyHeroGridFile reads a hero grid file, applies modify and writes the result back in the file's
+// version. Nothing is written when modify fails or the file has an unknown version, and the file is
+// replaced in one step, so Dota never reads a half-written grid.
+func modifyHeroGridFile(path string, modify func(gridConfig *heroGridConfig) error) error {
+	gridConfig, err := readHeroGridConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if err := checkGridVersion(gridConfig.Version); err != nil {
+		return err
+	}
+
+	if err := modify(&gridConfig); err != nil {
+		return err
+	}
+
+	updatedData, err := json.MarshalIndent(gridConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling updated config: %w", err)
+	}
+	if err := writeFileAtomic(path, updatedData); err != nil {
+		return fmt.Errorf("error writing updated config: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,100 @@
+package heroesLayout
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	manifestVersion    = 1
+	manifestFileSuffix = ".d2tool.json"
+)
+
+// gridManifest records the exact configs d2tool wrote into a hero grid file, so its own configs are
+// recognised by name and content rather than by a name prefix. It is stored next to the grid file.
+type gridManifest struct {
+	Version int             `json:"version"`
+	Configs []manifestEntry `json:"configs"`
+}
+
+// manifestEntry identifies one generated config
+type manifestEntry struct {
+	JobID      string    `json:"jobId"`
+	ConfigName string    `json:"configName"`
+	Hash       string    `json:"hash"` // sha256 of the config content as written
+	WrittenAt  time.Time `json:"writtenAt"`
+}
+
+// manifestPath returns the sidecar path of a grid file: hero_grid_config.json -> hero_grid_config.d2tool.json
+func manifestPath(configPath string) string {
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + manifestFileSuffix
+}
+
+// loadGridManifest reads the manifest of a grid file; ok is false when the file has none yet
+func loadGridManifest(configPath string) (gridManifest, bool, error) {
+	data, err := os.ReadFile(manifestPath(configPath))
+	if os.IsNotExist(err) {
+		return gridManifest{}, false, nil
+	}
+	if err != nil {
+		return gridManifest{}, false, fmt.Errorf("error reading manifest: %w", err)
+	}
+
+	var manifest gridManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return gridManifest{}, false, fmt.Errorf("error parsing manifest: %w", err)
+	}
+	return manifest, true, nil
+}
+
+// saveGridManifest replaces the manifest of a grid file; a failed write leaves the previous one intact
+func saveGridManifest(configPath string, manifest gridManifest) error {
+	manifest.Version = manifestVersion
+	if manifest.Configs == nil {
+		manifest.Configs = []manifestEntry{}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling manifest: %w", err)
+	}
+	if err := writeFileAtomic(manifestPath(configPath), data); err != nil {
+		return fmt.Errorf("error writing manifest: %w", err)
+	}
+	return nil
+}
+
+// hashGridConfig hashes the content of a config; the name is part of it, so renamed copies don't match
+func hashGridConfig(cfg heroGridCategory) string {
+	data, _ := json.Marshal(cfg) // plain structs can't fail to marshal
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// owner returns the manifest entry of a config written by d2tool and left untouched since.
+// A config with a recorded name but different content was edited by the user and is not owned.
+func (m gridManifest) owner(cfg heroGridCategory) (manifestEntry, bool) {
+	hash := hashGridConfig(cfg)
+	for _, entry := range m.Configs {
+		if entry.ConfigName == cfg.ConfigName && entry.Hash == hash {
+			return entry, true
+		}
+	}
+	return manifestEntry{}, false
+}
+
+// recordsName reports whether the manifest has an entry with the given config name
+func (m gridManifest) recordsName(configName string) bool {
+	for _, entry := range m.Configs {
+		if entry.ConfigName == configName {
+			return true
+		}
+	}
+	return false
+}
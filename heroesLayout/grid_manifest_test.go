@@ -0,0 +1,171 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/providers"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeGridConfig(t *testing.T, path string, configs ...heroGridCategory) {
+	t.Helper()
+	data, _ := json.MarshalIndent(heroGridConfig{Version: 3, Configs: configs}, "", "  ")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readGridConfig(t *testing.T, path string) heroGridConfig {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result heroGridConfig
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func manifestTestLayout() layoutData {
+	return layoutData{
+		positions:        []string{"1"},
+		positionToHeroes: map[string][]providers.Hero{"1": {{HeroID: 1, D2PTRating: 100, Matches: 100, Wins: 50}}},
+		heroesPerRow:     15,
+	}
+}
+
+func configNames(gridConfig heroGridConfig) []string {
+	var names []string
+	for _, cfg := range gridConfig.Configs {
+		names = append(names, cfg.ConfigName)
+	}
+	return names
+}
+
+func TestManifestPath(t *testing.T) {
+	got := manifestPath(filepath.Join("cfg", "hero_grid_config.json"))
+	if got != filepath.Join("cfg", "hero_grid_config.d2tool.json") {
+		t.Errorf("unexpected manifest path %q", got)
+	}
+}
+
+func TestProcessHeroesLayoutConfig_WritesManifest(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "hero_grid_config.json")
+	writeGridConfig(t, configPath, heroGridCategory{ConfigName: "My Custom Grid", Categories: []heroGridPosition{}})
+
+	if err := processHeroesLayoutConfig(configPath, defaultJobConfigs(manifestTestLayout()), defaultJobIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	manifest, ok, err := loadGridManifest(configPath)
+	if err != nil || !ok {
+		t.Fatalf("expected manifest, got ok=%v err=%v", ok, err)
+	}
+	if len(manifest.Configs) != 1 || manifest.Configs[0].JobID != config.DefaultGenerationJobID {
+		t.Fatalf("expected one entry for the default job, got %+v", manifest.Configs)
+	}
+
+	written := readGridConfig(t, configPath).Configs[1]
+	if manifest.Configs[0].ConfigName != written.ConfigName || manifest.Configs[0].Hash != hashGridConfig(written) {
+		t.Errorf("manifest entry doesn't match the written config: %+v", manifest.Configs[0])
+	}
+}
+
+func TestProcessHeroesLayoutConfig_LeavesUserChangesAlone(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *heroGridCategory)
+	}{
+		{"renamed", func(cfg *heroGridCategory) { cfg.ConfigName = "My Meta" }},
+		{"edited", func(cfg *heroGridCategory) { cfg.Categories[len(cfg.Categories)-1].HeroIDs = []int{42} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "hero_grid_config.json")
+			writeGridConfig(t, configPath)
+
+			if err := processHeroesLayoutConfig(configPath, defaultJobConfigs(manifestTestLayout()), defaultJobIDs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// The user changes the generated config in game
+			gridConfig := readGridConfig(t, configPath)
+			userCopy := gridConfig.Configs[0]
+			tt.modify(&userCopy)
+			writeGridConfig(t, configPath, userCopy)
+
+			if err := processHeroesLayoutConfig(configPath, defaultJobConfigs(manifestTestLayout()), defaultJobIDs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result := readGridConfig(t, configPath)
+			if len(result.Configs) != 2 {
+				t.Fatalf("expected the user copy and a new generated config, got %v", configNames(result))
+			}
+			// A copy that kept the generated name is renamed, so Dota shows both configs
+			kept := result.Configs[0]
+			if kept.ConfigName == result.Configs[1].ConfigName {
+				t.Errorf("expected the user copy and the generated config to have different names, got %v", configNames(result))
+			}
+			if kept.ConfigName != userCopy.ConfigName && kept.ConfigName != userCopy.ConfigName+" (edited)" {
+				t.Errorf("expected the user copy to keep its name or get the edited suffix, got %q", kept.ConfigName)
+			}
+			kept.ConfigName = userCopy.ConfigName
+			if hashGridConfig(kept) != hashGridConfig(userCopy) {
+				t.Errorf("expected the user copy to be left unchanged")
+			}
+
+			manifest, _, _ := loadGridManifest(configPath)
+			if len(manifest.Configs) != 1 || manifest.Configs[0].Hash != hashGridConfig(result.Configs[1]) {
+				t.Errorf("expected the manifest to track only the new config, got %+v", manifest.Configs)
+			}
+		})
+	}
+}
+
+func TestProcessHeroesLayoutConfig_KeepsUserConfigsWithMarker(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "hero_grid_config.json")
+	writeGridConfig(t, configPath)
+
+	if err := processHeroesLayoutConfig(configPath, defaultJobConfigs(manifestTestLayout()), defaultJobIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Once the file has a manifest, the name marker no longer makes a config ours
+	gridConfig := readGridConfig(t, configPath)
+	gridConfig.Configs = append(gridConfig.Configs, heroGridCategory{ConfigName: "[D2T] My own grid", Categories: []heroGridPosition{}})
+	writeGridConfig(t, configPath, gridConfig.Configs...)
+
+	if err := processHeroesLayoutConfig(configPath, defaultJobConfigs(manifestTestLayout()), defaultJobIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := configNames(readGridConfig(t, configPath))
+	if len(names) != 2 || names[0] != "[D2T] My own grid" || !strings.HasPrefix(names[1], "[D2T] Heroes Meta ") {
+		t.Errorf("expected the user config to be kept and the generated one replaced, got %v", names)
+	}
+}
+
+func TestJobConfigName(t *testing.T) {
+	tests := []struct {
+		job  config.GenerationJob
+		want string
+	}{
+		{config.DefaultGenerationJob(), "[D2T] Heroes Meta 2024-05-01"},
+		{config.GenerationJob{ID: "patch", Name: "This Patch"}, "[D2T:patch] This Patch 2024-05-01"},
+		{config.GenerationJob{ID: "patch", Name: "This Patch", DisplayName: "Patch meta ({date})"}, "Patch meta (2024-05-01)"},
+		{config.GenerationJob{ID: "mid", Name: "Mid", DisplayName: "Mid heroes"}, "Mid heroes"},
+	}
+
+	for _, tt := range tests {
+		if got := jobConfigName(tt.job, "2024-05-01"); got != tt.want {
+			t.Errorf("jobConfigName(%+v) = %q, want %q", tt.job, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package heroesLayout
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MirrorStatusConflict reports a target whose copy of the mirrored config was edited there
+const MirrorStatusConflict = "conflict"
+
+// MirrorTargetResult is the outcome of mirroring a config into one Steam account
+type MirrorTargetResult struct {
+	SteamID64 string `json:"steamId64"`
+	FilePath  string `json:"filePath"`
+	Status    string `json:"status"` // one of the import statuses or MirrorStatusConflict
+	Error     string `json:"error"`
+}
+
+// mirrorGridConfig writes the source config into a target grid file. A same-named config is replaced
+// when it is the copy written by the last sync (its hash is syncedHash) or when overwrite is set;
+// otherwise it was changed in the target and is left alone as a conflict.
+func mirrorGridConfig(targetPath string, source heroGridCategory, syncedHash string, overwrite bool) (string, error) {
+	status := ImportStatusAdded
+
+	err := modifyHeroGridFile(targetPath, func(gridConfig *heroGridConfig) error {
+		for i, existing := range gridConfig.Configs {
+			if existing.ConfigName != source.ConfigName {
+				continue
+			}
+
+			switch {
+			case reflect.DeepEqual(existing.Categories, source.Categories):
+				status = ImportStatusUnchanged
+				return errUnchanged
+			case overwrite || hashGridConfig(existing) == syncedHash:
+				gridConfig.Configs[i] = source
+				status = ImportStatusReplaced
+				return nil
+			default:
+				status = MirrorStatusConflict
+				return errUnchanged
+			}
+		}
+
+		gridConfig.Configs = append(gridConfig.Configs, source)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errUnchanged) {
+		return ImportStatusFailed, err
+	}
+	return status, nil
+}
+
+// mirrorSourceConfig returns the config a mirror copies. Generated configs are refused: every account
+// generates its own, and a copy would be replaced by the target's next update.
+func mirrorSourceConfig(sourcePath string, configName string) (heroGridCategory, error) {
+	if _, generated := configJobID(configName); generated {
+		return heroGridCategory{}, fmt.Errorf("config %q is generated by d2tool, configure grids of the target accounts instead", configName)
+	}
+	return findGridConfig(sourcePath, configName)
+}
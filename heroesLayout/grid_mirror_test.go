@@ -0,0 +1,62 @@
+package heroesLayout
+
+import (
+	"testing"
+)
+
+func TestMirrorGridConfig(t *testing.T) {
+	source := shareTestConfig("My grid", 2)
+	oldCopy := shareTestConfig("My grid", 1)
+
+	tests := []struct {
+		name         string
+		target       []heroGridCategory
+		syncedHash   string
+		overwrite    bool
+		expectStatus string
+		expectHero   int // hero of "My grid" in the target afterwards
+	}{
+		{name: "missing", target: []heroGridCategory{shareTestConfig("Other", 5)}, expectStatus: ImportStatusAdded, expectHero: 2},
+		{name: "up to date", target: []heroGridCategory{source}, expectStatus: ImportStatusUnchanged, expectHero: 2},
+		{name: "previous copy", target: []heroGridCategory{oldCopy}, syncedHash: hashGridConfig(oldCopy), expectStatus: ImportStatusReplaced, expectHero: 2},
+		{name: "edited in target", target: []heroGridCategory{oldCopy}, syncedHash: "other", expectStatus: MirrorStatusConflict, expectHero: 1},
+		{name: "never synced", target: []heroGridCategory{oldCopy}, expectStatus: MirrorStatusConflict, expectHero: 1},
+		{name: "overwrite conflict", target: []heroGridCategory{oldCopy}, overwrite: true, expectStatus: ImportStatusReplaced, expectHero: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writePreviewGridFile(t, tt.target...)
+
+			status, err := mirrorGridConfig(path, source, tt.syncedHash, tt.overwrite)
+			if err != nil {
+				t.Fatalf("mirrorGridConfig failed: %v", err)
+			}
+			if status != tt.expectStatus {
+				t.Errorf("expected status %s, got %s", tt.expectStatus, status)
+			}
+
+			cfg, err := findGridConfig(path, "My grid")
+			if err != nil {
+				t.Fatalf("expected the mirrored config in the target: %v", err)
+			}
+			if cfg.Categories[0].HeroIDs[0] != tt.expectHero {
+				t.Errorf("expected hero %d, got %d", tt.expectHero, cfg.Categories[0].HeroIDs[0])
+			}
+		})
+	}
+}
+
+func TestMirrorSourceConfig(t *testing.T) {
+	path := writePreviewGridFile(t, shareTestConfig("My grid", 1), shareTestConfig("[D2T] Heroes Meta 2026-10-18", 2))
+
+	if _, err := mirrorSourceConfig(path, "My grid"); err != nil {
+		t.Errorf("expected a hand-made config to be mirrored, got %v", err)
+	}
+	if _, err := mirrorSourceConfig(path, "[D2T] Heroes Meta 2026-10-18"); err == nil {
+		t.Error("expected generated configs to be refused")
+	}
+	if _, err := mirrorSourceConfig(path, "Missing"); err == nil {
+		t.Error("expected an error for a missing config")
+	}
+}
@@ -0,0 +1,326 @@
+package heroesLayout
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Preview image formats
+const (
+	PreviewFormatSVG = "svg"
+	PreviewFormatPNG = "png"
+)
+
+const (
+	previewPadding = 10
+	textPadding    = 2
+	// heroSlotWidth is the smallest slot of a category holding several heroes
+	heroSlotWidth = 50
+	// previewOverflowMargin is how much of a config past the hero grid canvas is drawn. The rest is
+	// cut, so categories placed far away can't make a huge image; the canvas edge marker shows it.
+	previewOverflowMargin = 100
+
+	maxPreviewWidth  = gridCanvasWidth + previewOverflowMargin + 2*previewPadding
+	maxPreviewHeight = gridCanvasHeight + previewOverflowMargin + 2*previewPadding
+)
+
+var (
+	previewBackground   = color.RGBA{R: 10, G: 10, B: 10, A: 255}
+	previewCategoryFill = color.RGBA{R: 31, G: 41, B: 55, A: 255}
+	previewBorder       = color.RGBA{R: 75, G: 85, B: 99, A: 255}
+	previewHeroFill     = color.RGBA{R: 55, G: 65, B: 81, A: 255}
+	previewTextColor    = color.RGBA{R: 229, G: 231, B: 235, A: 255}
+	previewOverflow     = color.RGBA{R: 239, G: 68, B: 68, A: 255}
+)
+
+// GridPreview is one rendered config of a hero grid file
+type GridPreview struct {
+	FilePath   string   `json:"filePath"`
+	ConfigName string   `json:"configName"`
+	SVG        string   `json:"svg"`
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
+	Warnings   []string `json:"warnings"` // parts of the grid outside the hero grid canvas
+}
+
+// previewRect is a filled rectangle; a zero border color draws no border
+type previewRect struct {
+	x, y, width, height int
+	fill, border        color.RGBA
+}
+
+// previewLabel is a single line of text with its top left corner at x, y
+type previewLabel struct {
+	x, y int
+	text string
+}
+
+// previewScene is a rendered config in image coordinates, drawn the same way as SVG and PNG
+type previewScene struct {
+	width, height int
+	rects         []previewRect
+	labels        []previewLabel
+}
+
+// buildPreviewScene lays out the categories of a config. Sized categories are drawn as boxes with
+// their name at the top and their heroes in slots below; empty ones are plain text like in the game.
+// The edge of the hero grid canvas is marked when the config extends past it, and the scene is
+// cut a margin past the canvas.
+func buildPreviewScene(cfg heroGridCategory, heroName func(int) string) previewScene {
+	var scene previewScene
+	extend := func(x int, y int) {
+		scene.width = min(max(scene.width, x+previewPadding), maxPreviewWidth)
+		scene.height = min(max(scene.height, y+previewPadding), maxPreviewHeight)
+	}
+	addRect := func(rect previewRect) {
+		scene.rects = append(scene.rects, rect)
+		extend(rect.x+rect.width, rect.y+rect.height)
+	}
+	addLabel := func(x int, y int, text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		scene.labels = append(scene.labels, previewLabel{x: x, y: y, text: text})
+		extend(x+textWidth(text), y+lineHeight)
+	}
+
+	for _, cat := range cfg.Categories {
+		x := int(cat.XPosition) + previewPadding
+		y := int(cat.YPosition) + previewPadding
+		width, height := int(cat.Width), int(cat.Height)
+
+		if width <= 0 || height <= 0 {
+			addLabel(x, y, cat.CategoryName)
+			continue
+		}
+
+		addRect(previewRect{x: x, y: y, width: width, height: height, fill: previewCategoryFill, border: previewBorder})
+		top := y + textPadding
+		if strings.TrimSpace(cat.CategoryName) != "" {
+			addLabel(x+textPadding, top, fitText(cat.CategoryName, width-2*textPadding))
+			top += lineHeight
+		}
+		addHeroSlots(addRect, addLabel, cat.HeroIDs, x+textPadding, top, width-2*textPadding, y+height-textPadding-top, heroName)
+	}
+
+	bounds := layoutBounds(cfg.Categories)
+	if bounds.Width > gridCanvasWidth {
+		addRect(previewRect{x: gridCanvasWidth + previewPadding, y: 0, width: 1, height: scene.height, fill: previewOverflow})
+	}
+	if bounds.Height > gridCanvasHeight {
+		addRect(previewRect{x: 0, y: gridCanvasHeight + previewPadding, width: scene.width, height: 1, fill: previewOverflow})
+	}
+
+	scene.width = max(scene.width, 2*previewPadding)
+	scene.height = max(scene.height, 2*previewPadding)
+	return scene
+}
+
+// addHeroSlots splits an area into equal slots, one per hero, and writes the hero name in each
+func addHeroSlots(addRect func(previewRect), addLabel func(int, int, string), heroIDs []int, x int, y int, width int, height int, heroName func(int) string) {
+	if len(heroIDs) == 0 || width <= 0 || height <= 0 {
+		return
+	}
+
+	columns := min(max(width/heroSlotWidth, 1), len(heroIDs))
+	rows := (len(heroIDs) + columns - 1) / columns
+	slotWidth, slotHeight := width/columns, height/rows
+
+	for i, heroID := range heroIDs {
+		slotX := x + i%columns*slotWidth
+		slotY := y + i/columns*slotHeight
+		addRect(previewRect{x: slotX, y: slotY, width: slotWidth - 1, height: slotHeight - 1, fill: previewHeroFill, border: previewBorder})
+
+		name := heroName(heroID)
+		if name == "" {
+			name = "#" + strconv.Itoa(heroID)
+		}
+		maxLines := max((slotHeight-2*textPadding)/lineHeight, 1)
+		for line, text := range wrapText(name, slotWidth-2*textPadding, maxLines) {
+			addLabel(slotX+textPadding, slotY+textPadding+line*lineHeight, text)
+		}
+	}
+}
+
+// textWidth returns the width of a line of text in pixels
+func textWidth(text string) int {
+	return len([]rune(text)) * glyphAdvance
+}
+
+// fitText cuts text to the given width in pixels
+func fitText(text string, width int) string {
+	runes := []rune(text)
+	maxRunes := max(width/glyphAdvance, 1)
+	if len(runes) <= maxRunes {
+		return text
+	}
+	return string(runes[:maxRunes])
+}
+
+// wrapText splits text into at most maxLines lines by words; words wider than a line and the
+// text past the last line are cut
+func wrapText(text string, width int, maxLines int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		if current != "" && textWidth(current+" "+word) <= width {
+			current += " " + word
+			continue
+		}
+		if current != "" {
+			lines = append(lines, current)
+		}
+		current = fitText(word, width)
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines[:min(len(lines), maxLines)]
+}
+
+// renderPreviewSVG writes a scene as an SVG document
+func renderPreviewSVG(w io.Writer, scene previewScene) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", scene.width, scene.height, scene.width, scene.height)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgColor(previewBackground))
+	for _, rect := range scene.rects {
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"`, rect.x, rect.y, rect.width, rect.height, svgColor(rect.fill))
+		if rect.border.A != 0 {
+			fmt.Fprintf(&buf, ` stroke="%s" stroke-width="1"`, svgColor(rect.border))
+		}
+		buf.WriteString("/>\n")
+	}
+
+	// A 10px monospace font is about as wide as the PNG glyphs, so both formats wrap the same way
+	fmt.Fprintf(&buf, `<g font-family="monospace" font-size="10" fill="%s" dominant-baseline="hanging">`+"\n", svgColor(previewTextColor))
+	for _, label := range scene.labels {
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" xml:space="preserve">%s</text>`+"\n", label.x, label.y, html.EscapeString(label.text))
+	}
+	buf.WriteString("</g>\n</svg>\n")
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("error writing SVG preview: %w", err)
+	}
+	return nil
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// renderPreviewPNG rasterises a scene and writes it as a PNG image
+func renderPreviewPNG(w io.Writer, scene previewScene) error {
+	img := image.NewRGBA(image.Rect(0, 0, scene.width, scene.height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: previewBackground}, image.Point{}, draw.Src)
+
+	for _, rect := range scene.rects {
+		area := image.Rect(rect.x, rect.y, rect.x+rect.width, rect.y+rect.height)
+		if !area.Overlaps(img.Bounds()) {
+			continue
+		}
+		draw.Draw(img, area, &image.Uniform{C: rect.fill}, image.Point{}, draw.Src)
+		if rect.border.A != 0 {
+			drawBorder(img, area, rect.border)
+		}
+	}
+	for _, label := range scene.labels {
+		drawText(img, label.x, label.y, label.text, previewTextColor)
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("error writing PNG preview: %w", err)
+	}
+	return nil
+}
+
+// drawBorder draws the edges of area that are inside the image
+func drawBorder(img *image.RGBA, area image.Rectangle, c color.RGBA) {
+	visible := area.Intersect(img.Bounds())
+	for x := visible.Min.X; x < visible.Max.X; x++ {
+		img.SetRGBA(x, area.Min.Y, c)
+		img.SetRGBA(x, area.Max.Y-1, c)
+	}
+	for y := visible.Min.Y; y < visible.Max.Y; y++ {
+		img.SetRGBA(area.Min.X, y, c)
+		img.SetRGBA(area.Max.X-1, y, c)
+	}
+}
+
+// PreviewFormatFromPath returns the preview format matching the extension of a file
+func PreviewFormatFromPath(path string) (string, error) {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))
+	if format != PreviewFormatSVG && format != PreviewFormatPNG {
+		return "", fmt.Errorf("unknown preview format %q (expected .%s or .%s)", filepath.Ext(path), PreviewFormatSVG, PreviewFormatPNG)
+	}
+	return format, nil
+}
+
+// PreviewGridFile renders every config of a hero grid file as SVG
+func PreviewGridFile(gridPath string, heroName func(int) string) ([]GridPreview, error) {
+	gridConfig, err := readHeroGridConfig(gridPath)
+	if err != nil {
+		return nil, err
+	}
+
+	previews := make([]GridPreview, 0, len(gridConfig.Configs))
+	for _, cfg := range gridConfig.Configs {
+		scene := buildPreviewScene(cfg, heroName)
+
+		var svg strings.Builder
+		if err := renderPreviewSVG(&svg, scene); err != nil {
+			return nil, err
+		}
+
+		previews = append(previews, GridPreview{
+			FilePath:   gridPath,
+			ConfigName: cfg.ConfigName,
+			SVG:        svg.String(),
+			Width:      scene.width,
+			Height:     scene.height,
+			Warnings:   layoutBounds(cfg.Categories).overflowWarnings(),
+		})
+	}
+	return previews, nil
+}
+
+// WriteGridPreview renders one config of a hero grid file as SVG or PNG.
+// An empty configName selects the first config of the file.
+func WriteGridPreview(w io.Writer, gridPath string, configName string, format string, heroName func(int) string) error {
+	gridConfig, err := readHeroGridConfig(gridPath)
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, cfg := range gridConfig.Configs {
+		if configName == "" || cfg.ConfigName == configName {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		if configName == "" {
+			return fmt.Errorf("config file has no hero grids")
+		}
+		return fmt.Errorf("config file has no hero grid named %q", configName)
+	}
+
+	scene := buildPreviewScene(gridConfig.Configs[index], heroName)
+	switch format {
+	case PreviewFormatSVG:
+		return renderPreviewSVG(w, scene)
+	case PreviewFormatPNG:
+		return renderPreviewPNG(w, scene)
+	default:
+		return fmt.Errorf("unknown preview format %q", format)
+	}
+}
@@ -0,0 +1,171 @@
+package heroesLayout
+
+import (
+	"bytes"
+	"d2tool/heroes"
+	"encoding/json"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writePreviewGridFile(t *testing.T, configs ...heroGridCategory) string {
+	t.Helper()
+
+	data, err := json.Marshal(heroGridConfig{Version: 3, Configs: configs})
+	if err != nil {
+		t.Fatalf("failed to marshal grid config: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "hero_grid_config.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write grid config: %v", err)
+	}
+	return path
+}
+
+func previewTestConfig() heroGridCategory {
+	return heroGridCategory{
+		ConfigName: "[D2T] Heroes Meta 2026-10-18",
+		Categories: []heroGridPosition{
+			{CategoryName: "pos 1 - Top Rating Heroes", XPosition: 0, YPosition: 0},
+			{CategoryName: "  55.0%", XPosition: 100, YPosition: 30, Width: 70, Height: 110, HeroIDs: []int{1}},
+			{CategoryName: "Carries", XPosition: 200, YPosition: 30, Width: 120, Height: 110, HeroIDs: []int{1, 2, 3}},
+		},
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		maxLines int
+		expected []string
+	}{
+		{text: "Axe", width: 60, maxLines: 2, expected: []string{"Axe"}},
+		{text: "Nature's Prophet", width: 60, maxLines: 2, expected: []string{"Nature's", "Prophet"}},
+		{text: "Keeper of the Light", width: 60, maxLines: 2, expected: []string{"Keeper of", "the Light"}},
+		{text: "Keeper of the Light", width: 60, maxLines: 1, expected: []string{"Keeper of"}},
+		{text: "Shadowshaman", width: 30, maxLines: 2, expected: []string{"Shado"}},
+	}
+
+	for _, tt := range tests {
+		if got := wrapText(tt.text, tt.width, tt.maxLines); !slices.Equal(got, tt.expected) {
+			t.Errorf("wrapText(%q, %d, %d) = %q, expected %q", tt.text, tt.width, tt.maxLines, got, tt.expected)
+		}
+	}
+}
+
+func TestPreviewGridFile(t *testing.T) {
+	path := writePreviewGridFile(t, previewTestConfig())
+
+	previews, err := PreviewGridFile(path, heroes.Default().Name)
+	if err != nil {
+		t.Fatalf("PreviewGridFile failed: %v", err)
+	}
+	if len(previews) != 1 {
+		t.Fatalf("expected 1 preview, got %d", len(previews))
+	}
+
+	preview := previews[0]
+	if preview.ConfigName != "[D2T] Heroes Meta 2026-10-18" || preview.FilePath != path {
+		t.Errorf("unexpected preview source %q in %q", preview.ConfigName, preview.FilePath)
+	}
+	if preview.Width != 340 || preview.Height != 160 {
+		t.Errorf("expected 340x160 preview, got %dx%d", preview.Width, preview.Height)
+	}
+	if len(preview.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", preview.Warnings)
+	}
+	for _, expected := range []string{"<svg", "pos 1 - Top Rating Heroes", "55.0%", heroes.Default().Name(1)} {
+		if !strings.Contains(preview.SVG, expected) {
+			t.Errorf("expected SVG to contain %q", expected)
+		}
+	}
+}
+
+func TestPreviewGridFile_Overflow(t *testing.T) {
+	cfg := previewTestConfig()
+	cfg.Categories = append(cfg.Categories, heroGridPosition{CategoryName: "far", XPosition: 1250, YPosition: 0, Width: 70, Height: 110, HeroIDs: []int{4}})
+	path := writePreviewGridFile(t, cfg)
+
+	previews, err := PreviewGridFile(path, heroes.Default().Name)
+	if err != nil {
+		t.Fatalf("PreviewGridFile failed: %v", err)
+	}
+	if len(previews[0].Warnings) != 1 || !strings.Contains(previews[0].Warnings[0], "1320px wide") {
+		t.Errorf("expected a width overflow warning, got %v", previews[0].Warnings)
+	}
+	if !strings.Contains(previews[0].SVG, svgColor(previewOverflow)) {
+		t.Error("expected the canvas edge to be marked")
+	}
+}
+
+func TestWriteGridPreview_FarAwayCategoryIsCut(t *testing.T) {
+	cfg := previewTestConfig()
+	cfg.Categories = append(cfg.Categories,
+		heroGridPosition{CategoryName: "far", XPosition: 1e9, YPosition: 1e9, Width: 1e9, Height: 70, HeroIDs: []int{4}},
+		heroGridPosition{CategoryName: "huge", XPosition: 0, YPosition: 200, Width: 5e8, Height: 5e8},
+	)
+	path := writePreviewGridFile(t, cfg)
+
+	var buf bytes.Buffer
+	if err := WriteGridPreview(&buf, path, "", PreviewFormatPNG, heroes.Default().Name); err != nil {
+		t.Fatalf("WriteGridPreview failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected a PNG image: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != maxPreviewWidth || size.Y != maxPreviewHeight {
+		t.Errorf("expected the image to be cut to %dx%d, got %v", maxPreviewWidth, maxPreviewHeight, size)
+	}
+	if got := img.At(gridCanvasWidth+previewPadding, 5); got != previewOverflow {
+		t.Errorf("expected the canvas edge marker, got %v", got)
+	}
+}
+
+func TestWriteGridPreview(t *testing.T) {
+	other := heroGridCategory{ConfigName: "My grid", Categories: []heroGridPosition{{CategoryName: "Mine", Width: 300, Height: 200, HeroIDs: []int{5}}}}
+	path := writePreviewGridFile(t, previewTestConfig(), other)
+
+	var buf bytes.Buffer
+	if err := WriteGridPreview(&buf, path, "My grid", PreviewFormatPNG, heroes.Default().Name); err != nil {
+		t.Fatalf("WriteGridPreview failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("expected a PNG image: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != 320 || size.Y != 220 {
+		t.Errorf("expected 320x220 image, got %v", size)
+	}
+
+	buf.Reset()
+	if err := WriteGridPreview(&buf, path, "", PreviewFormatSVG, heroes.Default().Name); err != nil {
+		t.Fatalf("WriteGridPreview failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "pos 1 - Top Rating Heroes") {
+		t.Error("expected the first config to be rendered without a config name")
+	}
+
+	if err := WriteGridPreview(&buf, path, "Missing", PreviewFormatSVG, heroes.Default().Name); err == nil {
+		t.Error("expected an error for an unknown config name")
+	}
+	if err := WriteGridPreview(&buf, path, "", "gif", heroes.Default().Name); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestPreviewFormatFromPath(t *testing.T) {
+	for path, expected := range map[string]string{"grid.svg": PreviewFormatSVG, "out/grid.PNG": PreviewFormatPNG} {
+		if format, err := PreviewFormatFromPath(path); err != nil || format != expected {
+			t.Errorf("PreviewFormatFromPath(%q) = %q, %v; expected %q", path, format, err, expected)
+		}
+	}
+	if _, err := PreviewFormatFromPath("grid.jpg"); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+}
@@ -0,0 +1,228 @@
+package heroesLayout
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const (
+	sharedGridFormat  = "d2tool-grid"
+	sharedGridVersion = 1
+	// shareStringPrefix starts a share string: the config as gzipped JSON in URL-safe base64
+	shareStringPrefix = "D2TGRID1:"
+)
+
+// Ways to import a config whose name already exists in the target file with a different content
+const (
+	ImportCollisionRename  = "rename"  // add it as "<name> (2)"
+	ImportCollisionReplace = "replace" // overwrite the existing config
+	ImportCollisionSkip    = "skip"    // keep the existing config
+)
+
+// Outcomes of importing a config into one grid file
+const (
+	ImportStatusAdded     = "added"
+	ImportStatusRenamed   = "renamed"
+	ImportStatusReplaced  = "replaced"
+	ImportStatusSkipped   = "skipped"
+	ImportStatusUnchanged = "unchanged" // the file already holds the same config
+	ImportStatusFailed    = "failed"
+)
+
+// importedGridName names imported configs that have nothing but a generator marker in their name
+const importedGridName = "Imported grid"
+
+// errUnchanged stops modifyHeroGridFile without writing when the file needs no change
+var errUnchanged = errors.New("grid file unchanged")
+
+// sharedGrid is the standalone file a config is exported to
+type sharedGrid struct {
+	Format  string           `json:"format"`
+	Version int              `json:"version"`
+	Config  heroGridCategory `json:"config"`
+}
+
+// GridImportResult is the outcome of importing a config into one grid file
+type GridImportResult struct {
+	FilePath   string `json:"filePath"`
+	ConfigName string `json:"configName"` // name the config got in the file
+	Status     string `json:"status"`
+	Error      string `json:"error"`
+}
+
+// findGridConfig returns the config of a hero grid file with the given name
+func findGridConfig(gridPath string, configName string) (heroGridCategory, error) {
+	gridConfig, err := readHeroGridConfig(gridPath)
+	if err != nil {
+		return heroGridCategory{}, err
+	}
+	for _, cfg := range gridConfig.Configs {
+		if cfg.ConfigName == configName {
+			return cfg, nil
+		}
+	}
+	return heroGridCategory{}, fmt.Errorf("config file has no hero grid named %q", configName)
+}
+
+// gridConfigNames returns the names of the configs of a hero grid file
+func gridConfigNames(gridPath string) ([]string, error) {
+	gridConfig, err := readHeroGridConfig(gridPath)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(gridConfig.Configs))
+	for _, cfg := range gridConfig.Configs {
+		names = append(names, cfg.ConfigName)
+	}
+	return names, nil
+}
+
+// marshalSharedGrid returns the standalone JSON file of a config
+func marshalSharedGrid(cfg heroGridCategory) ([]byte, error) {
+	data, err := json.MarshalIndent(sharedGrid{Format: sharedGridFormat, Version: sharedGridVersion, Config: cfg}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling shared grid: %w", err)
+	}
+	return data, nil
+}
+
+// encodeShareString returns the compact share string of a config
+func encodeShareString(cfg heroGridCategory) (string, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling shared grid: %w", err)
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return "", fmt.Errorf("error compressing shared grid: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("error compressing shared grid: %w", err)
+	}
+
+	return shareStringPrefix + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// parseSharedGrid reads a config from a share string, an exported grid file or a bare config JSON
+func parseSharedGrid(data string) (heroGridCategory, error) {
+	data = strings.TrimSpace(data)
+
+	var jsonData []byte
+	if encoded, ok := strings.CutPrefix(data, shareStringPrefix); ok {
+		compressed, err := base64.RawURLEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+		if err != nil {
+			return heroGridCategory{}, fmt.Errorf("invalid share string: %w", err)
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return heroGridCategory{}, fmt.Errorf("invalid share string: %w", err)
+		}
+		jsonData, err = io.ReadAll(reader)
+		if err != nil {
+			return heroGridCategory{}, fmt.Errorf("invalid share string: %w", err)
+		}
+	} else {
+		jsonData = []byte(data)
+	}
+
+	var shared sharedGrid
+	if err := json.Unmarshal(jsonData, &shared); err != nil {
+		return heroGridCategory{}, fmt.Errorf("error parsing shared grid: %w", err)
+	}
+
+	cfg := shared.Config
+	if shared.Format == "" {
+		// Share strings and hand-copied snippets hold the bare config
+		if err := json.Unmarshal(jsonData, &cfg); err != nil {
+			return heroGridCategory{}, fmt.Errorf("error parsing shared grid: %w", err)
+		}
+	} else if shared.Format != sharedGridFormat || shared.Version > sharedGridVersion {
+		return heroGridCategory{}, fmt.Errorf("unsupported shared grid format %q version %d", shared.Format, shared.Version)
+	}
+
+	if len(cfg.Categories) == 0 {
+		return heroGridCategory{}, fmt.Errorf("shared grid has no categories")
+	}
+	return cfg, nil
+}
+
+// importedConfigName drops the generator marker from the name of an imported config, so the
+// generator never mistakes it for one of its own and replaces it
+func importedConfigName(name string) string {
+	name = strings.TrimSpace(d2tMarkerRegex.ReplaceAllString(strings.TrimSpace(name), ""))
+	if name == "" {
+		return importedGridName
+	}
+	return name
+}
+
+// importGridConfig adds a config to a hero grid file, resolving a name collision with a different
+// config as the collision mode says. It returns the status and the name the config got.
+func importGridConfig(gridPath string, cfg heroGridCategory, collision string) (string, string, error) {
+	cfg.ConfigName = importedConfigName(cfg.ConfigName)
+	status := ImportStatusAdded
+
+	err := modifyHeroGridFile(gridPath, func(gridConfig *heroGridConfig) error {
+		index := -1
+		for i, existing := range gridConfig.Configs {
+			if existing.ConfigName == cfg.ConfigName {
+				index = i
+			}
+			// An earlier import, possibly renamed, makes importing the same grid again a no-op
+			sameName := existing.ConfigName == cfg.ConfigName || strings.HasPrefix(existing.ConfigName, cfg.ConfigName+" (")
+			if sameName && reflect.DeepEqual(existing.Categories, cfg.Categories) {
+				cfg.ConfigName = existing.ConfigName
+				status = ImportStatusUnchanged
+				return errUnchanged
+			}
+		}
+
+		switch {
+		case index < 0:
+			gridConfig.Configs = append(gridConfig.Configs, cfg)
+		case collision == ImportCollisionReplace:
+			gridConfig.Configs[index] = cfg
+			status = ImportStatusReplaced
+		case collision == ImportCollisionSkip:
+			status = ImportStatusSkipped
+			return errUnchanged
+		case collision == ImportCollisionRename:
+			cfg.ConfigName = uniqueConfigName(gridConfig.Configs, cfg.ConfigName)
+			gridConfig.Configs = append(gridConfig.Configs, cfg)
+			status = ImportStatusRenamed
+		default:
+			return fmt.Errorf("unknown collision mode %q", collision)
+		}
+		return nil
+	})
+	if errors.Is(err, errUnchanged) {
+		err = nil
+	}
+	if err != nil {
+		return ImportStatusFailed, cfg.ConfigName, err
+	}
+	return status, cfg.ConfigName, nil
+}
+
+// uniqueConfigName appends " (2)", " (3)"... to name until no config of the file has it
+func uniqueConfigName(configs []heroGridCategory, name string) string {
+	taken := make(map[string]bool, len(configs))
+	for _, cfg := range configs {
+		taken[cfg.ConfigName] = true
+	}
+
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (%d)", name, i)
+	}
+	return candidate
+}
@@ -0,0 +1,163 @@
+package heroesLayout
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func shareTestConfig(name string, heroID int) heroGridCategory {
+	return heroGridCategory{
+		ConfigName: name,
+		Categories: []heroGridPosition{{CategoryName: "Carries", Width: 300, Height: 200, HeroIDs: []int{heroID}}},
+	}
+}
+
+func TestShareString_RoundTrip(t *testing.T) {
+	cfg := shareTestConfig("My grid", 1)
+
+	shareString, err := encodeShareString(cfg)
+	if err != nil {
+		t.Fatalf("encodeShareString failed: %v", err)
+	}
+	if !strings.HasPrefix(shareString, shareStringPrefix) {
+		t.Errorf("expected share string prefix, got %q", shareString)
+	}
+
+	// Chat clients wrap long lines
+	wrapped := shareString[:20] + "\n" + shareString[20:]
+	decoded, err := parseSharedGrid(wrapped)
+	if err != nil {
+		t.Fatalf("parseSharedGrid failed: %v", err)
+	}
+	if decoded.ConfigName != "My grid" || decoded.Categories[0].HeroIDs[0] != 1 {
+		t.Errorf("unexpected decoded config %+v", decoded)
+	}
+}
+
+func TestParseSharedGrid(t *testing.T) {
+	exported, err := marshalSharedGrid(shareTestConfig("Exported", 2))
+	if err != nil {
+		t.Fatalf("marshalSharedGrid failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		data        string
+		expectName  string
+		expectError string
+	}{
+		{name: "exported file", data: string(exported), expectName: "Exported"},
+		{name: "bare config", data: `{"config_name":"Bare","categories":[{"category_name":"A","hero_ids":[3]}]}`, expectName: "Bare"},
+		{name: "unknown format", data: `{"format":"other","version":1,"config":{}}`, expectError: "unsupported"},
+		{name: "newer version", data: `{"format":"d2tool-grid","version":99,"config":{}}`, expectError: "unsupported"},
+		{name: "no categories", data: `{"config_name":"Empty","categories":[]}`, expectError: "no categories"},
+		{name: "invalid share string", data: shareStringPrefix + "!!!", expectError: "invalid share string"},
+		{name: "not json", data: "hello", expectError: "error parsing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseSharedGrid(tt.data)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSharedGrid failed: %v", err)
+			}
+			if cfg.ConfigName != tt.expectName {
+				t.Errorf("expected config %q, got %q", tt.expectName, cfg.ConfigName)
+			}
+		})
+	}
+}
+
+func TestImportGridConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		imported     heroGridCategory
+		collision    string
+		expectStatus string
+		expectName   string
+		expectNames  []string
+	}{
+		{name: "new name", imported: shareTestConfig("Teammate grid", 1), collision: ImportCollisionRename, expectStatus: ImportStatusAdded, expectName: "Teammate grid", expectNames: []string{"My grid", "Teammate grid"}},
+		{name: "same config", imported: shareTestConfig("My grid", 1), collision: ImportCollisionRename, expectStatus: ImportStatusUnchanged, expectName: "My grid", expectNames: []string{"My grid"}},
+		{name: "rename", imported: shareTestConfig("My grid", 2), collision: ImportCollisionRename, expectStatus: ImportStatusRenamed, expectName: "My grid (2)", expectNames: []string{"My grid", "My grid (2)"}},
+		{name: "replace", imported: shareTestConfig("My grid", 2), collision: ImportCollisionReplace, expectStatus: ImportStatusReplaced, expectName: "My grid", expectNames: []string{"My grid"}},
+		{name: "skip", imported: shareTestConfig("My grid", 2), collision: ImportCollisionSkip, expectStatus: ImportStatusSkipped, expectName: "My grid", expectNames: []string{"My grid"}},
+		{name: "generator marker dropped", imported: shareTestConfig("[D2T:patch] This Patch", 1), collision: ImportCollisionRename, expectStatus: ImportStatusAdded, expectName: "This Patch", expectNames: []string{"My grid", "This Patch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writePreviewGridFile(t, shareTestConfig("My grid", 1))
+
+			status, name, err := importGridConfig(path, tt.imported, tt.collision)
+			if err != nil {
+				t.Fatalf("importGridConfig failed: %v", err)
+			}
+			if status != tt.expectStatus || name != tt.expectName {
+				t.Errorf("expected %s as %q, got %s as %q", tt.expectStatus, tt.expectName, status, name)
+			}
+
+			gridConfig, err := readHeroGridConfig(path)
+			if err != nil {
+				t.Fatalf("failed to read grid file: %v", err)
+			}
+			var names []string
+			for _, cfg := range gridConfig.Configs {
+				names = append(names, cfg.ConfigName)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectNames, ",") {
+				t.Errorf("expected configs %v, got %v", tt.expectNames, names)
+			}
+			if tt.expectStatus == ImportStatusReplaced && gridConfig.Configs[0].Categories[0].HeroIDs[0] != 2 {
+				t.Error("expected the existing config to be replaced")
+			}
+		})
+	}
+}
+
+func TestImportGridConfig_ReimportAfterRename(t *testing.T) {
+	path := writePreviewGridFile(t, shareTestConfig("My grid", 1))
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := importGridConfig(path, shareTestConfig("My grid", 2), ImportCollisionRename); err != nil {
+			t.Fatalf("importGridConfig failed: %v", err)
+		}
+	}
+
+	gridConfig, _ := readHeroGridConfig(path)
+	if len(gridConfig.Configs) != 2 {
+		t.Errorf("expected importing the same grid twice to add it once, got %d configs", len(gridConfig.Configs))
+	}
+}
+
+func TestImportGridConfig_MissingFile(t *testing.T) {
+	status, _, err := importGridConfig(t.TempDir()+"/missing.json", shareTestConfig("My grid", 1), ImportCollisionRename)
+	if err == nil || status != ImportStatusFailed {
+		t.Errorf("expected failure for a missing file, got %s, %v", status, err)
+	}
+}
+
+func TestModifyHeroGridFile_KeepsFileOnError(t *testing.T) {
+	path := writePreviewGridFile(t, shareTestConfig("My grid", 1))
+	before, _ := os.ReadFile(path)
+
+	err := modifyHeroGridFile(path, func(gridConfig *heroGridConfig) error {
+		gridConfig.Configs = nil
+		return errUnchanged
+	})
+	if err == nil {
+		t.Fatal("expected the modify error to be returned")
+	}
+
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("expected the file to be left untouched")
+	}
+}
@@ -0,0 +1,243 @@
+package heroesLayout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+)
+
+// Severities of grid validation issues
+const (
+	GridIssueError   = "error"   // Dota may refuse or mangle the grid
+	GridIssueWarning = "warning" // the grid loads but probably isn't what was meant
+)
+
+// configStartRegex finds the start of a config object when salvaging a corrupt file
+var configStartRegex = regexp.MustCompile(`\{\s*"config_name"`)
+
+// GridIssue is one problem found in a hero grid file
+type GridIssue struct {
+	Severity   string `json:"severity"`
+	ConfigName string `json:"configName"` // empty for problems of the whole file
+	Message    string `json:"message"`
+}
+
+// GridValidationReport is the result of checking a hero grid file
+type GridValidationReport struct {
+	FilePath    string      `json:"filePath"`
+	Version     int         `json:"version"`
+	Parseable   bool        `json:"parseable"`   // the file is valid JSON of the hero grid format
+	ConfigCount int         `json:"configCount"` // configs parsed, or salvageable when the file isn't parseable
+	Issues      []GridIssue `json:"issues"`
+	Repairable  bool        `json:"repairable"` // RepairGridFile would change the file
+}
+
+// GridRepairResult is the outcome of repairing a hero grid file
+type GridRepairResult struct {
+	FilePath   string   `json:"filePath"`
+	BackupPath string   `json:"backupPath"`
+	Configs    int      `json:"configs"` // configs written to the repaired file
+	Changes    []string `json:"changes"`
+}
+
+// ValidateGridFile checks a hero grid file for problems that Dota doesn't report: an unknown version,
+// duplicate config names, invalid hero IDs, negative geometry and empty configs or categories.
+// knownHero reports hero IDs of the hero registry; IDs it doesn't know are only warnings, as they can
+// be heroes released after the registry. An error is only returned when the file can't be read.
+func ValidateGridFile(gridPath string, knownHero func(int) bool) (GridValidationReport, error) {
+	data, err := os.ReadFile(gridPath)
+	if err != nil {
+		return GridValidationReport{}, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	report := GridValidationReport{FilePath: gridPath, Version: currentGridVersion}
+	addIssue := func(severity string, configName string, format string, args ...any) {
+		report.Issues = append(report.Issues, GridIssue{Severity: severity, ConfigName: configName, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var header struct {
+		Version *int `json:"version"`
+	}
+	var gridConfig heroGridConfig
+	if err := json.Unmarshal(data, &gridConfig); err != nil {
+		addIssue(GridIssueError, "", "file is not a valid hero grid config: %v", err)
+		salvaged := salvageGridConfigs(data)
+		report.ConfigCount = len(salvaged)
+		report.Repairable = len(salvaged) > 0
+		if report.Repairable {
+			addIssue(GridIssueWarning, "", "%d grids can be salvaged by repairing the file", len(salvaged))
+		}
+		return report, nil
+	}
+	report.Parseable = true
+	report.ConfigCount = len(gridConfig.Configs)
+
+	if err := json.Unmarshal(data, &header); err == nil && header.Version != nil {
+		report.Version = *header.Version
+	} else {
+		addIssue(GridIssueWarning, "", "file has no version, version %d is assumed", currentGridVersion)
+	}
+	if severity, message := gridVersionIssue(report.Version); message != "" {
+		addIssue(severity, "", "%s", message)
+	}
+
+	seen := make(map[string]bool, len(gridConfig.Configs))
+	for _, cfg := range gridConfig.Configs {
+		if seen[cfg.ConfigName] {
+			addIssue(GridIssueError, cfg.ConfigName, "duplicate config name; Dota shows only one of them")
+		}
+		seen[cfg.ConfigName] = true
+
+		if len(cfg.Categories) == 0 {
+			addIssue(GridIssueWarning, cfg.ConfigName, "config has no categories")
+		}
+		for _, cat := range cfg.Categories {
+			for _, message := range categoryProblems(cat, knownHero) {
+				addIssue(message.severity, cfg.ConfigName, "category %q: %s", cat.CategoryName, message.text)
+			}
+		}
+	}
+
+	_, changes := repairGridConfigs(gridConfig.Configs)
+	report.Repairable = len(changes) > 0 && knownGridVersion(report.Version)
+	return report, nil
+}
+
+type categoryProblem struct {
+	severity string
+	text     string
+}
+
+// categoryProblems lists the problems of one category
+func categoryProblems(cat heroGridPosition, knownHero func(int) bool) []categoryProblem {
+	var problems []categoryProblem
+	if cat.XPosition < 0 || cat.YPosition < 0 {
+		problems = append(problems, categoryProblem{GridIssueError, fmt.Sprintf("negative position %gx%g", cat.XPosition, cat.YPosition)})
+	}
+	if cat.Width < 0 || cat.Height < 0 {
+		problems = append(problems, categoryProblem{GridIssueError, fmt.Sprintf("negative size %gx%g", cat.Width, cat.Height)})
+	}
+	// Categories without size are text labels; a sized one without heroes is an empty box
+	if cat.Width > 0 && cat.Height > 0 && len(cat.HeroIDs) == 0 {
+		problems = append(problems, categoryProblem{GridIssueWarning, "category has no heroes"})
+	}
+	for _, heroID := range cat.HeroIDs {
+		switch {
+		case heroID <= 0:
+			problems = append(problems, categoryProblem{GridIssueError, fmt.Sprintf("invalid hero ID %d", heroID)})
+		case knownHero != nil && !knownHero(heroID):
+			problems = append(problems, categoryProblem{GridIssueWarning, fmt.Sprintf("unknown hero ID %d, it may be a hero newer than D2Tool's hero list", heroID)})
+		}
+	}
+	return problems
+}
+
+// salvageGridConfigs reads every config of a corrupt hero grid file that still parses on its own.
+// After a broken config the scan resumes at the next config start, so one bad entry doesn't lose the rest.
+func salvageGridConfigs(data []byte) []heroGridCategory {
+	var configs []heroGridCategory
+	pos := 0
+	for {
+		loc := configStartRegex.FindIndex(data[pos:])
+		if loc == nil {
+			return configs
+		}
+		start := pos + loc[0]
+
+		var cfg heroGridCategory
+		decoder := json.NewDecoder(bytes.NewReader(data[start:]))
+		if err := decoder.Decode(&cfg); err != nil {
+			pos = start + 1
+			continue
+		}
+		configs = append(configs, cfg)
+		pos = start + int(decoder.InputOffset())
+	}
+}
+
+// repairGridConfigs fixes the errors ValidateGridFile reports as far as possible without guessing:
+// duplicate names get a suffix, hero IDs of 0 or less are removed and negative geometry is clamped to
+// zero. Warnings are left alone: empty configs are kept and unknown hero IDs may be new heroes.
+// It returns the repaired configs and what was changed.
+func repairGridConfigs(configs []heroGridCategory) ([]heroGridCategory, []string) {
+	var changes []string
+	repaired := make([]heroGridCategory, 0, len(configs))
+	for _, cfg := range configs {
+		name := uniqueConfigName(repaired, cfg.ConfigName)
+		if name != cfg.ConfigName {
+			changes = append(changes, fmt.Sprintf("renamed duplicate config %q to %q", cfg.ConfigName, name))
+		}
+
+		categories := make([]heroGridPosition, len(cfg.Categories))
+		for i, cat := range cfg.Categories {
+			if cat.XPosition < 0 || cat.YPosition < 0 || cat.Width < 0 || cat.Height < 0 {
+				cat.XPosition, cat.YPosition = max(cat.XPosition, 0), max(cat.YPosition, 0)
+				cat.Width, cat.Height = max(cat.Width, 0), max(cat.Height, 0)
+				changes = append(changes, fmt.Sprintf("%s: clamped negative geometry of category %q", name, cat.CategoryName))
+			}
+
+			heroIDs := make([]int, 0, len(cat.HeroIDs))
+			for _, heroID := range cat.HeroIDs {
+				if heroID > 0 {
+					heroIDs = append(heroIDs, heroID)
+				}
+			}
+			if removed := len(cat.HeroIDs) - len(heroIDs); removed > 0 {
+				changes = append(changes, fmt.Sprintf("%s: removed %d invalid hero IDs from category %q", name, removed, cat.CategoryName))
+			}
+			cat.HeroIDs = heroIDs
+			categories[i] = cat
+		}
+
+		repaired = append(repaired, heroGridCategory{ConfigName: name, Categories: categories})
+	}
+	return repaired, changes
+}
+
+// RepairGridFile backs a hero grid file up next to it and rewrites it with the configs that could be
+// read and repaired. A file that isn't valid JSON keeps the configs salvageGridConfigs finds.
+func RepairGridFile(gridPath string) (GridRepairResult, error) {
+	data, err := os.ReadFile(gridPath)
+	if err != nil {
+		return GridRepairResult{}, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	result := GridRepairResult{FilePath: gridPath}
+	gridConfig := heroGridConfig{Version: currentGridVersion}
+	if err := json.Unmarshal(data, &gridConfig); err != nil {
+		gridConfig = heroGridConfig{Version: currentGridVersion, Configs: salvageGridConfigs(data)}
+		if len(gridConfig.Configs) == 0 {
+			return GridRepairResult{}, fmt.Errorf("no grids could be salvaged from the config file: %w", err)
+		}
+		result.Changes = append(result.Changes, fmt.Sprintf("salvaged %d grids from the corrupt file", len(gridConfig.Configs)))
+	}
+
+	if err := checkGridVersion(gridConfig.Version); err != nil {
+		return GridRepairResult{}, err
+	}
+
+	configs, changes := repairGridConfigs(gridConfig.Configs)
+	result.Changes = append(result.Changes, changes...)
+	result.Configs = len(configs)
+	if len(result.Changes) == 0 {
+		return result, nil
+	}
+	gridConfig.Configs = configs
+
+	result.BackupPath = fmt.Sprintf("%s.%s.bak", gridPath, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(result.BackupPath, data, 0644); err != nil {
+		return GridRepairResult{}, fmt.Errorf("error writing backup file: %w", err)
+	}
+
+	repairedData, err := json.MarshalIndent(gridConfig, "", "  ")
+	if err != nil {
+		return GridRepairResult{}, fmt.Errorf("error marshaling repaired config: %w", err)
+	}
+	if err := writeFileAtomic(gridPath, repairedData); err != nil {
+		return GridRepairResult{}, fmt.Errorf("error writing repaired config: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,237 @@
+package heroesLayout
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// knownTestHero knows the heroes 1 to 200
+func knownTestHero(heroID int) bool {
+	return heroID >= 1 && heroID <= 200
+}
+
+func writeRawGridFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "hero_grid_config.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write grid config: %v", err)
+	}
+	return path
+}
+
+func TestValidateGridFile(t *testing.T) {
+	tests := []struct {
+		name             string
+		content          string
+		expectParseable  bool
+		expectRepairable bool
+		expectIssues     []string // substrings of the issue messages, in order
+	}{
+		{
+			name:            "valid",
+			content:         `{"version": 3, "configs": [{"config_name": "A", "categories": [{"category_name": "C", "x_position": 0, "y_position": 0, "width": 100, "height": 100, "hero_ids": [1, 2]}]}]}`,
+			expectParseable: true,
+		},
+		{
+			name:            "missing and unknown version",
+			content:         `{"configs": []}`,
+			expectParseable: true,
+			expectIssues:    []string{"no version"},
+		},
+		{
+			name:            "future version",
+			content:         `{"version": 9, "configs": []}`,
+			expectParseable: true,
+			expectIssues:    []string{"unknown hero grid config version 9"},
+		},
+		{
+			name: "config problems",
+			content: `{"version": 3, "configs": [
+				{"config_name": "A", "categories": [{"category_name": "C", "x_position": -5, "y_position": 0, "width": 100, "height": -1, "hero_ids": [0, 1, 999]}]},
+				{"config_name": "A", "categories": [{"category_name": "Empty", "x_position": 0, "y_position": 0, "width": 100, "height": 100, "hero_ids": []}]},
+				{"config_name": "B", "categories": []}
+			]}`,
+			expectParseable:  true,
+			expectRepairable: true,
+			expectIssues: []string{
+				"negative position", "negative size", "invalid hero ID 0", "unknown hero ID 999",
+				"duplicate config name", "category has no heroes",
+				"config has no categories",
+			},
+		},
+		{
+			name: "only warnings",
+			content: `{"version": 3, "configs": [
+				{"config_name": "A", "categories": [{"category_name": "C", "x_position": 0, "y_position": 0, "width": 100, "height": 100, "hero_ids": [1, 999]}]},
+				{"config_name": "B", "categories": []}
+			]}`,
+			expectParseable: true,
+			expectIssues:    []string{"unknown hero ID 999", "config has no categories"},
+		},
+		{
+			name:             "corrupt",
+			content:          `{"version": 3, "configs": [{"config_name": "A", "categories": []}, {"config_name": "B", "categ`,
+			expectRepairable: true,
+			expectIssues:     []string{"not a valid hero grid config", "1 grids can be salvaged"},
+		},
+		{
+			name:         "not JSON",
+			content:      `garbage`,
+			expectIssues: []string{"not a valid hero grid config"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ValidateGridFile(writeRawGridFile(t, tt.content), knownTestHero)
+			if err != nil {
+				t.Fatalf("ValidateGridFile failed: %v", err)
+			}
+			if report.Parseable != tt.expectParseable {
+				t.Errorf("expected parseable %v, got %v", tt.expectParseable, report.Parseable)
+			}
+			if report.Repairable != tt.expectRepairable {
+				t.Errorf("expected repairable %v, got %v", tt.expectRepairable, report.Repairable)
+			}
+			if len(report.Issues) != len(tt.expectIssues) {
+				t.Fatalf("expected %d issues, got %+v", len(tt.expectIssues), report.Issues)
+			}
+			for i, expected := range tt.expectIssues {
+				if !strings.Contains(report.Issues[i].Message, expected) {
+					t.Errorf("expected issue %d to contain %q, got %q", i, expected, report.Issues[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestRepairGridFile_SalvagesCorruptFile(t *testing.T) {
+	content := `{"version": 3, "configs": [
+		{"config_name": "A", "categories": [{"category_name": "C", "x_position": 0, "y_position": 0, "width": 100, "height": 100, "hero_ids": [1]}]},
+		{"config_name": "Broken", "categories": [{"category_name": "C", "x_position": oops}]},
+		{"config_name": "B", "categories": [{"category_name": "C", "x_position": 0, "y_position": 0, "width": 100, "height": 100, "hero_ids": [2, -1]}]},
+		{"config_name": "Truncated", "categories": [{"categ`
+	path := writeRawGridFile(t, content)
+
+	result, err := RepairGridFile(path)
+	if err != nil {
+		t.Fatalf("RepairGridFile failed: %v", err)
+	}
+	if result.Configs != 2 {
+		t.Errorf("expected 2 repaired configs, got %d (%v)", result.Configs, result.Changes)
+	}
+
+	backup, err := os.ReadFile(result.BackupPath)
+	if err != nil {
+		t.Fatalf("expected a backup file: %v", err)
+	}
+	if string(backup) != content {
+		t.Error("expected the backup to hold the original content")
+	}
+
+	gridConfig, err := readHeroGridConfig(path)
+	if err != nil {
+		t.Fatalf("expected the repaired file to parse: %v", err)
+	}
+	if names := gridConfigNamesOf(gridConfig); strings.Join(names, ",") != "A,B" {
+		t.Errorf("expected configs A and B, got %v", names)
+	}
+	if heroIDs := gridConfig.Configs[1].Categories[0].HeroIDs; len(heroIDs) != 1 || heroIDs[0] != 2 {
+		t.Errorf("expected the invalid hero ID removed, got %v", heroIDs)
+	}
+
+	report, err := ValidateGridFile(path, knownTestHero)
+	if err != nil {
+		t.Fatalf("ValidateGridFile failed: %v", err)
+	}
+	if len(report.Issues) != 0 || report.Repairable {
+		t.Errorf("expected no issues after repair, got %+v", report)
+	}
+}
+
+func TestRepairGridFile(t *testing.T) {
+	t.Run("renames duplicates and clamps geometry", func(t *testing.T) {
+		first := shareTestConfig("A", 1)
+		second := shareTestConfig("A", 2)
+		second.Categories[0].XPosition = -10
+		path := writePreviewGridFile(t, first, second)
+
+		result, err := RepairGridFile(path)
+		if err != nil {
+			t.Fatalf("RepairGridFile failed: %v", err)
+		}
+		if len(result.Changes) != 2 {
+			t.Errorf("expected 2 changes, got %v", result.Changes)
+		}
+
+		gridConfig, err := readHeroGridConfig(path)
+		if err != nil {
+			t.Fatalf("readHeroGridConfig failed: %v", err)
+		}
+		if names := gridConfigNamesOf(gridConfig); strings.Join(names, ",") != "A,A (2)" {
+			t.Errorf("expected the duplicate renamed, got %v", names)
+		}
+		if x := gridConfig.Configs[1].Categories[0].XPosition; x != 0 {
+			t.Errorf("expected negative position clamped, got %v", x)
+		}
+	})
+
+	t.Run("keeps unknown heroes and empty configs", func(t *testing.T) {
+		newHero := shareTestConfig("A", 1)
+		newHero.Categories[0].HeroIDs = []int{1, 999, 0}
+		path := writePreviewGridFile(t, newHero, heroGridCategory{ConfigName: "Empty", Categories: []heroGridPosition{}})
+
+		result, err := RepairGridFile(path)
+		if err != nil {
+			t.Fatalf("RepairGridFile failed: %v", err)
+		}
+		if len(result.Changes) != 1 || result.Configs != 2 {
+			t.Errorf("expected only the hero ID 0 removed, got %+v", result)
+		}
+
+		gridConfig, err := readHeroGridConfig(path)
+		if err != nil {
+			t.Fatalf("readHeroGridConfig failed: %v", err)
+		}
+		if names := gridConfigNamesOf(gridConfig); strings.Join(names, ",") != "A,Empty" {
+			t.Errorf("expected the empty config kept, got %v", names)
+		}
+		if heroIDs := gridConfig.Configs[0].Categories[0].HeroIDs; len(heroIDs) != 2 || heroIDs[1] != 999 {
+			t.Errorf("expected the unknown hero kept, got %v", heroIDs)
+		}
+	})
+
+	t.Run("valid file is left alone", func(t *testing.T) {
+		path := writePreviewGridFile(t, shareTestConfig("A", 1))
+
+		result, err := RepairGridFile(path)
+		if err != nil {
+			t.Fatalf("RepairGridFile failed: %v", err)
+		}
+		if result.BackupPath != "" || len(result.Changes) != 0 {
+			t.Errorf("expected no backup and no changes, got %+v", result)
+		}
+	})
+
+	t.Run("nothing to salvage", func(t *testing.T) {
+		path := writeRawGridFile(t, "garbage")
+
+		if _, err := RepairGridFile(path); err == nil {
+			t.Error("expected an error when no grid can be salvaged")
+		}
+		if data, _ := os.ReadFile(path); string(data) != "garbage" {
+			t.Error("expected the file to be left alone")
+		}
+	})
+}
+
+func gridConfigNamesOf(gridConfig heroGridConfig) []string {
+	names := make([]string, 0, len(gridConfig.Configs))
+	for _, cfg := range gridConfig.Configs {
+		names = append(names, cfg.ConfigName)
+	}
+	return names
+}
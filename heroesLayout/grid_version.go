@@ -0,0 +1,49 @@
+package heroesLayout
+
+import (
+	"errors"
+	"fmt"
+)
+
+// hero_grid_config.json versions d2tool reads and writes.
+//
+// Every known version stores configs and categories with the fields d2tool uses (config_name,
+// categories, category_name, x_position, y_position, width, height and hero_ids), so there is
+// nothing to convert between them: a file is written back in its own version, and the configs
+// d2tool generates into it follow that version. Relabelling an older file as the current version
+// would claim a format change that never happened. The testdata fixture of every known version is
+// rewritten field for field by the tests. A version that changes these fields needs a conversion
+// here, and a fixture, before it is added to the known range.
+const (
+	// currentGridVersion is the version Dota writes today; d2tool assumes it when a file doesn't set one
+	currentGridVersion = 3
+	// oldestGridVersion is the first version stored with the config and category fields d2tool uses
+	oldestGridVersion = 1
+)
+
+// errUnsupportedGridVersion is returned instead of writing into a file of a version d2tool doesn't know
+var errUnsupportedGridVersion = errors.New("unsupported hero grid config version")
+
+// knownGridVersion reports whether d2tool can write a hero grid file of the version. Unknown versions
+// are newer than d2tool or invalid; they are read but never written, as writing them in the known
+// layout could drop data of the newer format.
+func knownGridVersion(version int) bool {
+	return version >= oldestGridVersion && version <= currentGridVersion
+}
+
+// checkGridVersion returns an error for hero grid files d2tool must not write
+func checkGridVersion(version int) error {
+	if !knownGridVersion(version) {
+		return fmt.Errorf("%w %d: d2tool writes versions %d to %d and won't change the file", errUnsupportedGridVersion, version, oldestGridVersion, currentGridVersion)
+	}
+	return nil
+}
+
+// gridVersionIssue returns the severity and message of a validation issue about a file version;
+// both are empty for known versions
+func gridVersionIssue(version int) (string, string) {
+	if knownGridVersion(version) {
+		return "", ""
+	}
+	return GridIssueError, fmt.Sprintf("unknown hero grid config version %d; d2tool won't update the file", version)
+}
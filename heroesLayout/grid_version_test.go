@@ -0,0 +1,194 @@
+package heroesLayout
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// copyGridFixture copies a hero grid file of testdata to a temporary directory
+func copyGridFixture(t *testing.T, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "hero_grid_config.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestKnownGridVersion(t *testing.T) {
+	tests := []struct {
+		version  int
+		expected bool
+	}{
+		{version: 0, expected: false},
+		{version: 1, expected: true},
+		{version: 2, expected: true},
+		{version: 3, expected: true},
+		{version: 4, expected: false},
+		{version: -1, expected: false},
+	}
+
+	for _, tt := range tests {
+		if got := knownGridVersion(tt.version); got != tt.expected {
+			t.Errorf("knownGridVersion(%d) = %v, expected %v", tt.version, got, tt.expected)
+		}
+	}
+}
+
+// The fixtures only differ in their version: the fields d2tool uses are the same in every known
+// version, so a file must keep its version and only unknown versions are refused
+func TestProcessHeroesLayoutConfig_GridVersions(t *testing.T) {
+	tests := []struct {
+		fixture       string
+		expectVersion int // 0 when the file must be refused
+	}{
+		{fixture: "grid_v1.json", expectVersion: 1},
+		{fixture: "grid_v2.json", expectVersion: 2},
+		{fixture: "grid_v3.json", expectVersion: 3},
+		{fixture: "grid_unversioned.json", expectVersion: 3},
+		{fixture: "grid_v4.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			path := copyGridFixture(t, tt.fixture)
+			original, _ := os.ReadFile(path)
+
+			err := processHeroesLayoutConfig(path, defaultJobConfigs(manifestTestLayout()), defaultJobIDs)
+
+			if tt.expectVersion == 0 {
+				if !errors.Is(err, errUnsupportedGridVersion) {
+					t.Fatalf("expected errUnsupportedGridVersion, got %v", err)
+				}
+				if data, _ := os.ReadFile(path); string(data) != string(original) {
+					t.Error("expected the file to be left untouched")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("processHeroesLayoutConfig failed: %v", err)
+			}
+			gridConfig, err := readHeroGridConfig(path)
+			if err != nil {
+				t.Fatalf("readHeroGridConfig failed: %v", err)
+			}
+			if gridConfig.Version != tt.expectVersion {
+				t.Errorf("expected version %d, got %d", tt.expectVersion, gridConfig.Version)
+			}
+			if len(gridConfig.Configs) != 2 || gridConfig.Configs[0].ConfigName != "My grid" {
+				t.Errorf("expected the hand-made grid kept next to the generated one, got %v", gridConfigNamesOf(gridConfig))
+			}
+		})
+	}
+}
+
+// rawGridFile is a hero grid file parsed without d2tool's types, so every field of the file is kept
+type rawGridFile struct {
+	Configs []map[string]any `json:"configs"`
+}
+
+func readRawGridFile(t *testing.T, path string) rawGridFile {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read grid file: %v", err)
+	}
+	var grid rawGridFile
+	if err := json.Unmarshal(data, &grid); err != nil {
+		t.Fatalf("failed to parse grid file: %v", err)
+	}
+	return grid
+}
+
+// configFields returns the sorted fields of a raw config and of its first category
+func configFields(config map[string]any) ([]string, []string) {
+	var categoryFields []string
+	if categories, _ := config["categories"].([]any); len(categories) > 0 {
+		if category, ok := categories[0].(map[string]any); ok {
+			categoryFields = slices.Sorted(maps.Keys(category))
+		}
+	}
+	return slices.Sorted(maps.Keys(config)), categoryFields
+}
+
+// The fixtures back the claim of grid_version.go that the known versions share their config format:
+// d2tool rewrites every field of a hand-made config unchanged, and generates configs with exactly
+// the fields the configs of that version have
+func TestProcessHeroesLayoutConfig_GridVersionFields(t *testing.T) {
+	for _, fixture := range []string{"grid_v1.json", "grid_v2.json", "grid_v3.json"} {
+		t.Run(fixture, func(t *testing.T) {
+			path := copyGridFixture(t, fixture)
+			original := readRawGridFile(t, path)
+
+			if err := processHeroesLayoutConfig(path, defaultJobConfigs(manifestTestLayout()), defaultJobIDs); err != nil {
+				t.Fatalf("processHeroesLayoutConfig failed: %v", err)
+			}
+			written := readRawGridFile(t, path)
+			if len(written.Configs) != 2 {
+				t.Fatalf("expected the hand-made and the generated config, got %d configs", len(written.Configs))
+			}
+
+			if !reflect.DeepEqual(written.Configs[0], original.Configs[0]) {
+				t.Errorf("expected the hand-made config unchanged\noriginal: %v\nwritten:  %v", original.Configs[0], written.Configs[0])
+			}
+
+			expectConfigFields, expectCategoryFields := configFields(original.Configs[0])
+			generatedConfigFields, generatedCategoryFields := configFields(written.Configs[1])
+			if !slices.Equal(generatedConfigFields, expectConfigFields) || !slices.Equal(generatedCategoryFields, expectCategoryFields) {
+				t.Errorf("expected generated fields %v and %v, got %v and %v", expectConfigFields, expectCategoryFields, generatedConfigFields, generatedCategoryFields)
+			}
+		})
+	}
+}
+
+func TestValidateGridFile_Versions(t *testing.T) {
+	tests := []struct {
+		fixture        string
+		expectSeverity string // empty when the version is not reported
+	}{
+		{fixture: "grid_v1.json"},
+		{fixture: "grid_v2.json"},
+		{fixture: "grid_v3.json"},
+		{fixture: "grid_unversioned.json", expectSeverity: GridIssueWarning},
+		{fixture: "grid_v4.json", expectSeverity: GridIssueError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			report, err := ValidateGridFile(copyGridFixture(t, tt.fixture), knownTestHero)
+			if err != nil {
+				t.Fatalf("ValidateGridFile failed: %v", err)
+			}
+			if tt.expectSeverity == "" {
+				if len(report.Issues) != 0 {
+					t.Errorf("expected no issues, got %+v", report.Issues)
+				}
+				return
+			}
+			if len(report.Issues) != 1 || report.Issues[0].Severity != tt.expectSeverity {
+				t.Errorf("expected one %s about the version, got %+v", tt.expectSeverity, report.Issues)
+			}
+		})
+	}
+}
+
+func TestRepairGridFile_RefusesUnknownVersion(t *testing.T) {
+	path := writeRawGridFile(t, `{"version": 4, "configs": [{"config_name": "A", "categories": []}]}`)
+
+	if _, err := RepairGridFile(path); !errors.Is(err, errUnsupportedGridVersion) {
+		t.Errorf("expected errUnsupportedGridVersion, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/heroes"
+	"d2tool/history"
+	"d2tool/providers"
+)
+
+const unknownGroupTitle = "Other"
+
+// heroGroup is one column of a grouped section
+type heroGroup struct {
+	title   string
+	matches func(hero heroes.Hero) bool
+}
+
+var attributeGroups = []heroGroup{
+	{title: "Strength", matches: hasAttribute(heroes.AttributeStrength)},
+	{title: "Agility", matches: hasAttribute(heroes.AttributeAgility)},
+	{title: "Intelligence", matches: hasAttribute(heroes.AttributeIntelligence)},
+	{title: "Universal", matches: hasAttribute(heroes.AttributeUniversal)},
+}
+
+var attackTypeGroups = []heroGroup{
+	{title: "Melee", matches: hasAttackType(heroes.AttackTypeMelee)},
+	{title: "Ranged", matches: hasAttackType(heroes.AttackTypeRanged)},
+}
+
+func hasAttribute(attribute heroes.Attribute) func(hero heroes.Hero) bool {
+	return func(hero heroes.Hero) bool {
+		return hero.PrimaryAttribute == attribute
+	}
+}
+
+func hasAttackType(attackType heroes.AttackType) func(hero heroes.Hero) bool {
+	return func(hero heroes.Hero) bool {
+		return hero.AttackType == attackType
+	}
+}
+
+// groupSectionHeroes splits the heroes of a position by the section grouping and selects the
+// section heroes within every group. All groups are returned, even empty ones, so columns line up
+// between positions; heroes missing from the registry go to a trailing column shown only when needed.
+func groupSectionHeroes(positionHeroes []providers.Hero, pinned []int, section config.LayoutSection, baseline map[int]history.HeroStats, registry *heroes.Registry) []sectionColumn {
+	groups := attributeGroups
+	if section.GroupBy == config.GroupByAttackType {
+		groups = attackTypeGroups
+	}
+
+	groupOf := func(heroID int) int {
+		hero, ok := registry.Get(heroID)
+		if !ok {
+			return len(groups)
+		}
+		for i, group := range groups {
+			if group.matches(hero) {
+				return i
+			}
+		}
+		return len(groups)
+	}
+
+	// One extra bucket for unknown heroes
+	groupHeroes := make([][]providers.Hero, len(groups)+1)
+	groupPinned := make([][]int, len(groups)+1)
+	for _, hero := range positionHeroes {
+		i := groupOf(hero.HeroID)
+		groupHeroes[i] = append(groupHeroes[i], hero)
+	}
+	for _, heroID := range pinned {
+		i := groupOf(heroID)
+		groupPinned[i] = append(groupPinned[i], heroID)
+	}
+
+	columns := make([]sectionColumn, 0, len(groups)+1)
+	for i, group := range groups {
+		columns = append(columns, sectionColumn{
+			title:  group.title,
+			heroes: selectSectionHeroes(groupHeroes[i], groupPinned[i], section, baseline),
+		})
+	}
+
+	unknown := selectSectionHeroes(groupHeroes[len(groups)], groupPinned[len(groups)], section, baseline)
+	if len(unknown) > 0 {
+		columns = append(columns, sectionColumn{title: unknownGroupTitle, heroes: unknown})
+	}
+
+	return columns
+}
@@ -0,0 +1,146 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/heroes"
+	"d2tool/providers"
+	"testing"
+)
+
+func groupedTemplate(groupBy string, count int) config.LayoutTemplate {
+	template := config.DefaultLayoutTemplate()
+	template.Sections = []config.LayoutSection{
+		{
+			Title:   "{position} - By Attribute",
+			SortBy:  config.SortByRating,
+			Count:   count,
+			Labels:  []config.LabelFormat{{Header: "Winrate", Format: "{winrate}"}},
+			GroupBy: groupBy,
+		},
+	}
+	return template
+}
+
+func TestGenerateHeroesLayoutConfigs_AttributeGroupedGolden(t *testing.T) {
+	positions, positionToHeroes := goldenFixture()
+	layout := layoutData{
+		positions:        positions,
+		positionToHeroes: positionToHeroes,
+		heroesPerRow:     8,
+		heroRegistry:     heroes.Default(),
+	}
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layout, groupedTemplate(config.GroupByAttribute, 3))
+
+	assertGolden(t, "attribute_grouped.golden.json", configs[0].Categories)
+}
+
+func TestGroupSectionHeroes_ByAttribute(t *testing.T) {
+	positionHeroes := []providers.Hero{
+		{HeroID: 1, D2PTRating: 100},  // Anti-Mage, agility
+		{HeroID: 2, D2PTRating: 300},  // Axe, strength
+		{HeroID: 8, D2PTRating: 200},  // Juggernaut, agility
+		{HeroID: 44, D2PTRating: 400}, // Phantom Assassin, agility
+		{HeroID: 9999, D2PTRating: 1}, // unknown
+	}
+
+	columns := groupSectionHeroes(positionHeroes, []int{1}, groupedTemplate(config.GroupByAttribute, 2).Sections[0], nil, heroes.Default())
+
+	expected := []struct {
+		title   string
+		heroIDs []int
+	}{
+		{"Strength", []int{2}},
+		{"Agility", []int{1, 44}}, // pinned first, then top by rating
+		{"Intelligence", nil},
+		{"Universal", nil},
+		{unknownGroupTitle, []int{9999}},
+	}
+
+	if len(columns) != len(expected) {
+		t.Fatalf("expected %d columns, got %d", len(expected), len(columns))
+	}
+	for i, column := range columns {
+		if column.title != expected[i].title {
+			t.Errorf("column %d: expected title %q, got %q", i, expected[i].title, column.title)
+		}
+		if len(column.heroes) != len(expected[i].heroIDs) {
+			t.Errorf("column %q: expected %d heroes, got %d", column.title, len(expected[i].heroIDs), len(column.heroes))
+			continue
+		}
+		for j, hero := range column.heroes {
+			if hero.HeroID != expected[i].heroIDs[j] {
+				t.Errorf("column %q: expected hero %d at %d, got %d", column.title, expected[i].heroIDs[j], j, hero.HeroID)
+			}
+		}
+	}
+}
+
+func TestGroupSectionHeroes_ByAttackTypeWithoutUnknown(t *testing.T) {
+	positionHeroes := []providers.Hero{
+		{HeroID: 1, D2PTRating: 100}, // melee
+		{HeroID: 6, D2PTRating: 200}, // Drow Ranger, ranged
+	}
+
+	columns := groupSectionHeroes(positionHeroes, nil, groupedTemplate(config.GroupByAttackType, 5).Sections[0], nil, heroes.Default())
+
+	if len(columns) != 2 || columns[0].title != "Melee" || columns[1].title != "Ranged" {
+		t.Fatalf("expected Melee and Ranged columns only, got %+v", columns)
+	}
+	if columns[0].heroes[0].HeroID != 1 || columns[1].heroes[0].HeroID != 6 {
+		t.Errorf("unexpected grouping: %+v", columns)
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_GroupedColumnsSideBySide(t *testing.T) {
+	layout := layoutData{
+		positions: []string{"pos 1"},
+		positionToHeroes: map[string][]providers.Hero{
+			"pos 1": {{HeroID: 2}, {HeroID: 1}, {HeroID: 8}},
+		},
+		heroesPerRow: 8,
+		heroRegistry: heroes.Default(),
+	}
+
+	template := groupedTemplate(config.GroupByAttackType, 5)
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layout, template)
+
+	geometry := template.Geometry
+	// 8 heroes per row split over 2 columns
+	columnWidth := 4*geometry.HeroWidth + geometry.ColumnSpacing
+
+	var melee, ranged *heroGridPosition
+	for i, cat := range configs[0].Categories {
+		switch cat.CategoryName {
+		case "Melee":
+			melee = &configs[0].Categories[i]
+		case "Ranged":
+			ranged = &configs[0].Categories[i]
+		}
+	}
+	if melee == nil || ranged == nil {
+		t.Fatal("expected column titles")
+	}
+	if melee.XPosition != float64(geometry.HeaderWidth) || ranged.XPosition != float64(geometry.HeaderWidth+columnWidth) {
+		t.Errorf("expected columns at x=%d and x=%d, got %v and %v", geometry.HeaderWidth, geometry.HeaderWidth+columnWidth, melee.XPosition, ranged.XPosition)
+	}
+	if melee.YPosition != ranged.YPosition {
+		t.Error("expected column titles on the same row")
+	}
+}
+
+func TestValidateLayoutTemplate_Grouping(t *testing.T) {
+	template := groupedTemplate("role", 3)
+	template.Sections[0].ColumnHeroesPerRow = -1
+
+	err := ValidateLayoutTemplate(template)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	for _, groupBy := range []string{config.GroupByNone, config.GroupByAttribute, config.GroupByAttackType} {
+		if err := ValidateLayoutTemplate(groupedTemplate(groupBy, 3)); err != nil {
+			t.Errorf("expected grouping %q to be valid, got %v", groupBy, err)
+		}
+	}
+}
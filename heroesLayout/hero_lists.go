@@ -0,0 +1,54 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/providers"
+	"slices"
+)
+
+// applyHeroLists drops excluded heroes and, in hero pool mode, heroes outside the pool.
+// Pinned heroes are kept even when they are outside the pool.
+func applyHeroLists(positionHeroes []providers.Hero, lists config.HeroListsConfig, pinned []int) []providers.Hero {
+	result := make([]providers.Hero, 0, len(positionHeroes))
+	for _, hero := range positionHeroes {
+		if slices.Contains(lists.Exclude, hero.HeroID) {
+			continue
+		}
+		if len(lists.Include) > 0 && !slices.Contains(lists.Include, hero.HeroID) && !slices.Contains(pinned, hero.HeroID) {
+			continue
+		}
+		result = append(result, hero)
+	}
+	return result
+}
+
+// pinnedHeroIDs returns the heroes pinned for a position, without the excluded ones
+func pinnedHeroIDs(lists config.HeroListsConfig, positionID string) []int {
+	var result []int
+	for _, id := range lists.Pinned[positionID] {
+		if !slices.Contains(lists.Exclude, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// pinnedHeroes returns the pinned heroes in pin order with their stats from pool.
+// A pinned hero without stats for the position is still returned, with empty stats.
+func pinnedHeroes(pool []providers.Hero, pinned []int) []providers.Hero {
+	result := make([]providers.Hero, 0, len(pinned))
+	for _, id := range pinned {
+		found := false
+		for _, hero := range pool {
+			// Sections split by facet pin every facet of the hero
+			if hero.HeroID == id {
+				result = append(result, hero)
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, providers.Hero{HeroID: id})
+		}
+	}
+	return result
+}
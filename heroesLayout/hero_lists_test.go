@@ -0,0 +1,141 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/providers"
+	"testing"
+)
+
+func TestApplyHeroLists(t *testing.T) {
+	heroes := []providers.Hero{
+		{HeroID: 1, Matches: 100},
+		{HeroID: 2, Matches: 200},
+		{HeroID: 3, Matches: 300},
+		{HeroID: 4, Matches: 400},
+	}
+
+	tests := []struct {
+		name     string
+		lists    config.HeroListsConfig
+		pinned   []int
+		expected []int
+	}{
+		{"no lists", config.HeroListsConfig{}, nil, []int{1, 2, 3, 4}},
+		{"exclude", config.HeroListsConfig{Exclude: []int{2, 4}}, nil, []int{1, 3}},
+		{"hero pool", config.HeroListsConfig{Include: []int{1, 3}}, nil, []int{1, 3}},
+		{"hero pool keeps pinned", config.HeroListsConfig{Include: []int{1}}, []int{4}, []int{1, 4}},
+		{"exclude wins over pool", config.HeroListsConfig{Include: []int{1, 2}, Exclude: []int{2}}, nil, []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := applyHeroLists(heroes, tt.lists, tt.pinned)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("expected %d heroes, got %d", len(tt.expected), len(result))
+			}
+			for i, hero := range result {
+				if hero.HeroID != tt.expected[i] {
+					t.Errorf("position %d: expected hero %d, got %d", i, tt.expected[i], hero.HeroID)
+				}
+			}
+		})
+	}
+}
+
+func TestPinnedHeroIDs_SkipsExcluded(t *testing.T) {
+	lists := config.HeroListsConfig{
+		Exclude: []int{5},
+		Pinned:  map[string][]int{"1": {7, 5, 3}},
+	}
+
+	pinned := pinnedHeroIDs(lists, "1")
+	if len(pinned) != 2 || pinned[0] != 7 || pinned[1] != 3 {
+		t.Errorf("expected [7 3], got %v", pinned)
+	}
+	if len(pinnedHeroIDs(lists, "2")) != 0 {
+		t.Error("expected no pinned heroes for position without pins")
+	}
+}
+
+func TestSelectSectionHeroes_PinnedFirstWithLiveStats(t *testing.T) {
+	heroes := []providers.Hero{
+		{HeroID: 1, Matches: 500, Wins: 250, D2PTRating: 300},
+		{HeroID: 2, Matches: 400, Wins: 200, D2PTRating: 200},
+		{HeroID: 3, Matches: 20, Wins: 15, D2PTRating: 100},
+	}
+	section := config.LayoutSection{
+		SortBy:  config.SortByRating,
+		Count:   2,
+		Filters: config.SectionFilters{MinMatches: 100},
+	}
+
+	// Hero 3 is below the section minimum and hero 9 has no stats, both are still shown first
+	result := selectSectionHeroes(heroes, []int{3, 9}, section, nil)
+
+	if len(result) != 2 {
+		t.Fatalf("expected pinned heroes to fill the section, got %d heroes", len(result))
+	}
+	if result[0].HeroID != 3 || result[0].Matches != 20 || result[0].Wins != 15 {
+		t.Errorf("expected pinned hero 3 with its stats first, got %+v", result[0])
+	}
+	if result[1].HeroID != 9 || result[1].Matches != 0 {
+		t.Errorf("expected pinned hero 9 without stats second, got %+v", result[1])
+	}
+
+	result = selectSectionHeroes(heroes, []int{2}, config.LayoutSection{SortBy: config.SortByRating, Count: 3}, nil)
+	if len(result) != 3 || result[0].HeroID != 2 || result[1].HeroID != 1 || result[2].HeroID != 3 {
+		t.Errorf("expected [2 1 3] with pinned hero not repeated, got %v", result)
+	}
+}
+
+func TestSelectSectionHeroes_UnknownMatchesPassFilters(t *testing.T) {
+	heroes := []providers.Hero{
+		{HeroID: 1, D2PTRating: 900},
+		{HeroID: 2, Matches: 50, Wins: 20, D2PTRating: 800},
+		{HeroID: 3, Matches: 500, Wins: 300, D2PTRating: 700},
+	}
+	section := config.LayoutSection{
+		SortBy:  config.SortByRating,
+		Count:   3,
+		Filters: config.SectionFilters{MinMatches: 100, MinWinrate: 50},
+	}
+
+	// Hero 2 is below both minimums; hero 1 only has a rating, so the filters can't judge it
+	result := selectSectionHeroes(heroes, nil, section, nil)
+	if len(result) != 2 || result[0].HeroID != 1 || result[1].HeroID != 3 {
+		t.Errorf("expected [1 3], got %+v", result)
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_PinnedHeroLabels(t *testing.T) {
+	positionToHeroes := map[string][]providers.Hero{
+		"pos 1": {
+			{HeroID: 1, Matches: 500, Wins: 300, D2PTRating: 300},
+			{HeroID: 2, Matches: 100, Wins: 55, D2PTRating: 10},
+		},
+	}
+
+	layout := layoutData{
+		positions:        []string{"pos 1"},
+		positionToHeroes: positionToHeroes,
+		positionToPinned: map[string][]int{"pos 1": {2}},
+		heroesPerRow:     10,
+	}
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layout, config.DefaultLayoutTemplate())
+
+	var firstHero, firstLabel *heroGridPosition
+	for i, cat := range configs[0].Categories {
+		if len(cat.HeroIDs) > 0 {
+			firstHero = &configs[0].Categories[i]
+			firstLabel = &configs[0].Categories[i-1]
+			break
+		}
+	}
+
+	if firstHero == nil || firstHero.HeroIDs[0] != 2 {
+		t.Fatalf("expected pinned hero 2 first, got %+v", firstHero)
+	}
+	if firstHero.CategoryName != "  100" || firstLabel.CategoryName != "  55.0%" {
+		t.Errorf("expected live labels for pinned hero, got %q and %q", firstLabel.CategoryName, firstHero.CategoryName)
+	}
+}
@@ -0,0 +1,23 @@
+package heroesLayout
+
+import (
+	"d2tool/heroes"
+	"d2tool/providers"
+	"log/slog"
+)
+
+// completeHeroNames fills in hero names the provider omitted and logs heroes the registry doesn't know,
+// which usually means the bundled heroes dataset is outdated
+func completeHeroNames(heroList []providers.Hero, registry *heroes.Registry) []providers.Hero {
+	for i, hero := range heroList {
+		name := registry.Name(hero.HeroID)
+		if name == "" {
+			slog.Warn("Provider returned unknown hero", "heroId", hero.HeroID, "heroName", hero.HeroName, "datasetVersion", registry.Version())
+			continue
+		}
+		if hero.HeroName == "" {
+			heroList[i].HeroName = name
+		}
+	}
+	return heroList
+}
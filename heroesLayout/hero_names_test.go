@@ -0,0 +1,27 @@
+package heroesLayout
+
+import (
+	"d2tool/heroes"
+	"d2tool/providers"
+	"testing"
+)
+
+func TestCompleteHeroNames(t *testing.T) {
+	heroList := []providers.Hero{
+		{HeroID: 1},
+		{HeroID: 2, HeroName: "Provider Axe"},
+		{HeroID: 9999},
+	}
+
+	result := completeHeroNames(heroList, heroes.Default())
+
+	if result[0].HeroName != "Anti-Mage" {
+		t.Errorf("expected missing name to be filled, got %q", result[0].HeroName)
+	}
+	if result[1].HeroName != "Provider Axe" {
+		t.Errorf("expected provider name to be kept, got %q", result[1].HeroName)
+	}
+	if result[2].HeroName != "" {
+		t.Errorf("expected unknown hero to stay unnamed, got %q", result[2].HeroName)
+	}
+}
@@ -0,0 +1,86 @@
+package heroesLayout
+
+import (
+	"cmp"
+	"d2tool/history"
+	"d2tool/providers"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// defaultTrendDays is the window of a rating change section without TrendDays
+const defaultTrendDays = 7
+
+// previousUpdate asks a trendBaseline for the statistics of the previous update instead of a window
+// of days; the delta placeholders in labels show the change since then
+const previousUpdate = 0
+
+// trendBaseline returns the statistics of the heroes of a position from the snapshot taken
+// the given number of days before the update, or from the previous update for previousUpdate,
+// or nil when there is no such snapshot
+type trendBaseline func(position string, days int) map[int]history.HeroStats
+
+// heroTrend is the change of a hero's statistics against a baseline snapshot
+type heroTrend struct {
+	known        bool // false when the hero is missing from the baseline or the entry is a single facet
+	winrateKnown bool // false when the matches of the hero or its baseline are unknown
+	ratingDelta  int
+	winrateDelta float64 // percentage points
+}
+
+func computeTrend(hero providers.Hero, baseline map[int]history.HeroStats) heroTrend {
+	previous, ok := baseline[hero.HeroID]
+	// The history holds merged stats, which can't be compared with the stats of one facet
+	if !ok || hero.Facet != 0 {
+		return heroTrend{}
+	}
+
+	previousWinrate := providers.Winrate(providers.Hero{Matches: previous.Matches, Wins: previous.Wins}) * 100
+	return heroTrend{
+		known:        true,
+		winrateKnown: hero.Matches > 0 && previous.Matches > 0,
+		ratingDelta:  hero.D2PTRating - previous.Rating,
+		winrateDelta: heroWinrate(hero) - previousWinrate,
+	}
+}
+
+// formatDelta renders a change as "▲1.2", "▼0.4" or "=0.0"; precision is the number of decimals
+func formatDelta(delta float64, precision int) string {
+	rounded := fmt.Sprintf("%.*f", precision, math.Abs(delta))
+	zero := fmt.Sprintf("%.*f", precision, 0.0)
+	switch {
+	case rounded == zero:
+		return "=" + zero
+	case delta > 0:
+		return "▲" + rounded
+	default:
+		return "▼" + rounded
+	}
+}
+
+// rankByRatingChange returns the top N heroes by rating gained since the baseline.
+// Heroes missing from the baseline can't be ranked and are left out.
+func rankByRatingChange(pool []providers.Hero, baseline map[int]history.HeroStats, n int) []providers.Hero {
+	type rankedHero struct {
+		hero  providers.Hero
+		delta int
+	}
+
+	var ranked []rankedHero
+	for _, hero := range pool {
+		if trend := computeTrend(hero, baseline); trend.known {
+			ranked = append(ranked, rankedHero{hero: hero, delta: trend.ratingDelta})
+		}
+	}
+
+	slices.SortStableFunc(ranked, func(a, b rankedHero) int {
+		return cmp.Compare(b.delta, a.delta)
+	})
+
+	result := make([]providers.Hero, 0, min(n, len(ranked)))
+	for _, r := range ranked[:min(n, len(ranked))] {
+		result = append(result, r.hero)
+	}
+	return result
+}
@@ -0,0 +1,129 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/history"
+	"d2tool/providers"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormatDelta(t *testing.T) {
+	tests := []struct {
+		delta     float64
+		precision int
+		want      string
+	}{
+		{1.24, 1, "▲1.2"},
+		{-0.4, 1, "▼0.4"},
+		{0.04, 1, "=0.0"},
+		{-3, 0, "▼3"},
+		{0, 0, "=0"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDelta(tt.delta, tt.precision); got != tt.want {
+			t.Errorf("formatDelta(%v, %d) = %q, want %q", tt.delta, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestFormatHeroLabel_Deltas(t *testing.T) {
+	hero := providers.Hero{HeroID: 1, D2PTRating: 105, Matches: 100, Wins: 55}
+	baseline := map[int]history.HeroStats{1: {HeroID: 1, Matches: 100, Wins: 50, Rating: 110}}
+
+	got := formatHeroLabel("{winrate} {winrateDelta} {ratingDelta}", hero, 1, computeTrend(hero, baseline))
+	if got != "55.0% ▲5.0 ▼5" {
+		t.Errorf("unexpected label %q", got)
+	}
+
+	// Without history the delta placeholders are empty
+	got = formatHeroLabel("{rating}{ratingDelta}", hero, 1, computeTrend(hero, nil))
+	if got != "105" {
+		t.Errorf("expected empty delta without baseline, got %q", got)
+	}
+}
+
+func TestFormatHeroLabel_UnknownMatches(t *testing.T) {
+	// Rating-only lists have no matches: winrates and matches are empty, the rating is shown
+	hero := providers.Hero{HeroID: 1, D2PTRating: 900}
+	baseline := map[int]history.HeroStats{1: {HeroID: 1, Rating: 850}}
+
+	got := formatHeroLabel("{winrate}|{matches}|{winrateDelta}|{rating} {ratingDelta}", hero, 1, computeTrend(hero, baseline))
+	if got != "|||900 ▲50" {
+		t.Errorf("unexpected label %q", got)
+	}
+}
+
+func TestRankByRatingChange(t *testing.T) {
+	heroes := []providers.Hero{
+		{HeroID: 1, D2PTRating: 100},
+		{HeroID: 2, D2PTRating: 90},
+		{HeroID: 3, D2PTRating: 80},
+		{HeroID: 4, D2PTRating: 200}, // no baseline
+	}
+	baseline := map[int]history.HeroStats{
+		1: {HeroID: 1, Rating: 99},
+		2: {HeroID: 2, Rating: 70},
+		3: {HeroID: 3, Rating: 85},
+	}
+
+	ranked := rankByRatingChange(heroes, baseline, 5)
+
+	ids := make([]int, len(ranked))
+	for i, hero := range ranked {
+		ids[i] = hero.HeroID
+	}
+	if !slices.Equal(ids, []int{2, 1, 3}) {
+		t.Errorf("expected heroes ordered by rating change without unknown heroes, got %v", ids)
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_RatingChangeSection(t *testing.T) {
+	positionHeroes := []providers.Hero{
+		{HeroID: 1, D2PTRating: 100, Matches: 100, Wins: 50},
+		{HeroID: 2, D2PTRating: 90, Matches: 100, Wins: 50},
+	}
+
+	var requestedDays []int
+	layout := layoutData{
+		positions:        []string{"pos 1"},
+		positionToHeroes: map[string][]providers.Hero{"pos 1": positionHeroes},
+		heroesPerRow:     10,
+		baseline: func(position string, days int) map[int]history.HeroStats {
+			requestedDays = append(requestedDays, days)
+			return map[int]history.HeroStats{
+				1: {HeroID: 1, Matches: 100, Wins: 50, Rating: 100},
+				2: {HeroID: 2, Matches: 100, Wins: 50, Rating: 60},
+			}
+		},
+	}
+
+	template := config.DefaultLayoutTemplate()
+	template.Sections = []config.LayoutSection{{
+		Title:  "{position} - Rising Heroes",
+		SortBy: config.SortByRatingChange,
+		Count:  1,
+		Labels: []config.LabelFormat{{Header: "Change", Format: "{ratingDelta}"}},
+	}}
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layout, template)
+
+	var heroCategory *heroGridPosition
+	for i, category := range configs[0].Categories {
+		if len(category.HeroIDs) > 0 {
+			heroCategory = &configs[0].Categories[i]
+			break
+		}
+	}
+	if heroCategory == nil || heroCategory.HeroIDs[0] != 2 {
+		t.Fatalf("expected the hero with the largest rating gain, got %+v", heroCategory)
+	}
+	if !strings.Contains(heroCategory.CategoryName, "▲30") {
+		t.Errorf("expected the rating delta since the previous update in the label, got %q", heroCategory.CategoryName)
+	}
+	if !slices.Contains(requestedDays, defaultTrendDays) || !slices.Contains(requestedDays, previousUpdate) {
+		t.Errorf("expected baselines for the section window and the labels, got %v", requestedDays)
+	}
+}
@@ -1,19 +1,30 @@
 package heroesLayout
 
 import (
+	"cmp"
+	"d2tool/config"
+	"d2tool/heroes"
+	"d2tool/history"
 	"d2tool/providers"
-	"encoding/json"
+	"d2tool/utils"
 	"fmt"
-	"os"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	positionPrefix = "pos "
-	d2tPrefix      = "[D2T]"
+	// d2tPrefix marks the config of the default job; named jobs add their ID, e.g. "[D2T:patch]"
+	d2tPrefix = "[D2T]"
 )
 
+// d2tMarkerRegex matches the marker at the start of generated config names and captures the job ID
+var d2tMarkerRegex = regexp.MustCompile(`^\[D2T(?::([A-Za-z0-9_-]+))?\]`)
+
 type UpdateHeroesLayoutConfig struct {
 	ConfigFilePaths []string
 	Positions       []string
@@ -41,173 +52,376 @@ type heroGridPosition struct {
 	HeroIDs      []int   `json:"hero_ids"`
 }
 
-// generateHeroesLayoutConfigs generates new hero grid configs for each role
-func generateHeroesLayoutConfigs(configNamePrefix string, positions []string, positionToHero map[string][]providers.Hero, heroesPerRow int) []heroGridCategory {
+// layoutData contains the statistics and settings of one update that the generator lays out
+type layoutData struct {
+	positions        []string
+	positionToHeroes map[string][]providers.Hero
+	positionToPinned map[string][]int // heroes shown first in every section of a position
+	heroesPerRow     int
+	heroRegistry     *heroes.Registry // hero metadata used by grouped sections
+	baseline         trendBaseline    // previous statistics for trends, nil when there is no history
+	matchups         map[matchupKey][]providers.Matchup
+}
+
+// baselineFor returns the statistics of a position from days before the update, or nil without history
+func (l layoutData) baselineFor(position string, days int) map[int]history.HeroStats {
+	if l.baseline == nil {
+		return nil
+	}
+	return l.baseline(position, days)
+}
+
+// sectionColumn is one group of heroes of a section, laid out side by side with the other groups
+type sectionColumn struct {
+	title  string
+	heroes []providers.Hero
+}
+
+// generateHeroesLayoutConfigs generates new hero grid configs for each role using the layout template
+func generateHeroesLayoutConfigs(configNamePrefix string, layout layoutData, template config.LayoutTemplate) []heroGridCategory {
 	var configs []heroGridCategory
 
 	// Create a single merged config
 	mergedConfig := heroGridCategory{
-		ConfigName: fmt.Sprintf("%s Heroes Meta %s", configNamePrefix, time.Now().Format("2006-01-02")),
+		ConfigName: fmt.Sprintf("%s %s", configNamePrefix, time.Now().Format("2006-01-02")),
 		Categories: []heroGridPosition{},
 	}
 
-	// Set vertical layout with fixed width
-	const heroWidth = 70
-	const heroHeight = 110
-	const categorySpacing = 50
-	const infoHeight = 30
-	const heroWinrateSpacing = 20
-	const heroRowSpacing = 30
-	const headerWidth = 100 // Width for row headers
+	geometry := template.Geometry
+
+	// Without row headers the heroes start at the left edge
+	headerWidth := geometry.HeaderWidth
+	if template.HideRowHeaders {
+		headerWidth = 0
+	}
 
 	// Current Y position for vertical layout
 	currentY := 0
 
-	generateCategoryFunc := func(categoryName string, heroes []providers.Hero) {
-		infoCategory := heroGridPosition{
-			CategoryName: categoryName,
-			XPosition:    0,
-			YPosition:    float64(currentY),
-			Width:        0,
-			Height:       0,
-			HeroIDs:      []int{},
+	addCategory := func(name string, x int, y int, width int, height int, heroIDs []int) {
+		mergedConfig.Categories = append(mergedConfig.Categories, heroGridPosition{
+			CategoryName: name,
+			XPosition:    float64(x),
+			YPosition:    float64(y),
+			Width:        float64(width),
+			Height:       float64(height),
+			HeroIDs:      heroIDs,
+		})
+	}
+
+	generateSectionFunc := func(sectionTitle string, columns []sectionColumn, heroesPerRow int, labels []config.LabelFormat, labelBaseline map[int]history.HeroStats) {
+		if len(labels) == 0 {
+			labels = []config.LabelFormat{{}}
 		}
-		mergedConfig.Categories = append(mergedConfig.Categories, infoCategory)
-		currentY += infoHeight
 
-		// Add each hero as a separate category with winrate and match count in the category name
-		for i, hero := range heroes {
-			// Calculate position in the grid
-			row := i / heroesPerRow
-			col := i % heroesPerRow
+		addCategory(sectionTitle, 0, currentY, 0, 0, []int{})
+		currentY += geometry.InfoHeight
+
+		// Columns are placed after the row headers, each one heroesPerRow heroes wide
+		columnX := make([]int, len(columns))
+		hasColumnTitles := false
+		rows := 0
+		for i, column := range columns {
+			columnX[i] = headerWidth + i*(heroesPerRow*geometry.HeroWidth+geometry.ColumnSpacing)
+			hasColumnTitles = hasColumnTitles || column.title != ""
+			rows = max(rows, (len(column.heroes)+heroesPerRow-1)/heroesPerRow) // Ceiling division
+		}
 
-			// Calculate x and y position (offset by headerWidth to make room for row headers)
-			xPos := headerWidth + col*heroWidth
-			yPos := currentY + row*(heroHeight+heroWinrateSpacing+heroRowSpacing)
+		if hasColumnTitles {
+			for i, column := range columns {
+				addCategory(column.title, columnX[i], currentY, 0, 0, []int{})
+			}
+			currentY += geometry.InfoHeight
+		}
+
+		// Each hero takes a stack of labels with the hero card under the last one
+		blockHeight := geometry.HeroHeight + (len(labels)-1)*geometry.LabelSpacing + geometry.RowSpacing
 
-			labelYPos := yPos
+		for row := 0; row < rows; row++ {
+			yPos := currentY + row*blockHeight
 
 			// Add row headers at the start of each row
-			if col == 0 {
-				mergedConfig.Categories = append(mergedConfig.Categories, heroGridPosition{
-					CategoryName: "Winrate",
-					XPosition:    0,
-					YPosition:    float64(labelYPos),
-					Width:        0,
-					Height:       0,
-					HeroIDs:      []int{},
-				})
-				mergedConfig.Categories = append(mergedConfig.Categories, heroGridPosition{
-					CategoryName: "Matches",
-					XPosition:    0,
-					YPosition:    float64(labelYPos + heroWinrateSpacing),
-					Width:        0,
-					Height:       0,
-					HeroIDs:      []int{},
-				})
+			if !template.HideRowHeaders {
+				for labelIdx, label := range labels {
+					addCategory(label.Header, 0, yPos+labelIdx*geometry.LabelSpacing, 0, 0, []int{})
+				}
 			}
 
-			// Calculate winrate
-			winrate := 0.0
-			if hero.Matches > 0 {
-				winrate = float64(hero.Wins) / float64(hero.Matches) * 100
-			}
+			for i, column := range columns {
+				rowStart := min(row*heroesPerRow, len(column.heroes))
+				rowEnd := min(rowStart+heroesPerRow, len(column.heroes))
 
-			heroWinrateCategory := heroGridPosition{
-				CategoryName: fmt.Sprintf("  %.1f%%", winrate),
-				XPosition:    float64(xPos),
-				YPosition:    float64(labelYPos),
-				Width:        0,
-				Height:       0,
-				HeroIDs:      []int{},
-			}
+				for col, hero := range column.heroes[rowStart:rowEnd] {
+					xPos := columnX[i] + col*geometry.HeroWidth
+					rank := rowStart + col + 1
+					trend := computeTrend(hero, labelBaseline)
 
-			mergedConfig.Categories = append(mergedConfig.Categories, heroWinrateCategory)
+					// All labels but the last are empty categories; the last one holds the hero card
+					for labelIdx, label := range labels[:len(labels)-1] {
+						addCategory(formatHeroLabel(label.Format, hero, rank, trend), xPos, yPos+labelIdx*geometry.LabelSpacing, 0, 0, []int{})
+					}
 
-			// Create category for the hero
-			heroCategory := heroGridPosition{
-				CategoryName: fmt.Sprintf("  %d", hero.Matches),
-				XPosition:    float64(xPos),
-				YPosition:    float64(labelYPos + heroWinrateSpacing),
-				Width:        heroWidth,
-				Height:       heroHeight,
-				HeroIDs:      []int{hero.HeroID},
+					lastLabelY := yPos + (len(labels)-1)*geometry.LabelSpacing
+					addCategory(formatHeroLabel(labels[len(labels)-1].Format, hero, rank, trend), xPos, lastLabelY, geometry.HeroWidth, geometry.HeroHeight, []int{hero.HeroID})
+				}
 			}
-			mergedConfig.Categories = append(mergedConfig.Categories, heroCategory)
 		}
 
 		// Update currentY to account for all rows of heroes
-		rows := (len(heroes) + heroesPerRow - 1) / heroesPerRow // Ceiling division
-		currentY += rows*(heroHeight+heroWinrateSpacing+heroRowSpacing) + categorySpacing
+		currentY += rows*blockHeight + geometry.CategorySpacing
 	}
 
 	// Generate categories for each position in the specified order
-	for _, position := range positions {
-		heroes := positionToHero[position]
-
-		// Get top 10 heroes by rating
-		topRated := providers.GetTopHeroesByRating(heroes, 10)
-
-		// Add list header for top rated heroes
-		generateCategoryFunc(
-			fmt.Sprintf("%s - Top Rating Heroes", position),
-			topRated,
-		)
-
-		// Get top 30 heroes by matches
-		topMatches := providers.GetHeroesSortedByMatches(heroes, 30)
+	for _, position := range layout.positions {
+		positionHeroes := layout.positionToHeroes[position]
+		pinned := layout.positionToPinned[position]
+		labelBaseline := layout.baselineFor(position, previousUpdate)
+
+		for _, section := range template.Sections {
+			var sortBaseline map[int]history.HeroStats
+			if section.SortBy == config.SortByRatingChange {
+				sortBaseline = layout.baselineFor(position, cmp.Or(section.TrendDays, defaultTrendDays))
+			}
 
-		// Add list header for top matches heroes
-		generateCategoryFunc(
-			fmt.Sprintf("%s - Most Matches Heroes", position),
-			topMatches,
-		)
+			for _, instance := range sectionInstances(position, section, positionHeroes, pinned, layout) {
+				instanceBaseline := labelBaseline
+				if instance.matchup {
+					instanceBaseline = nil
+				}
+
+				if section.GroupBy == config.GroupByNone {
+					columns := []sectionColumn{{heroes: selectSectionHeroes(instance.heroes, instance.pinned, section, sortBaseline)}}
+					generateSectionFunc(instance.title, columns, layout.heroesPerRow, section.Labels, instanceBaseline)
+					continue
+				}
+
+				columns := groupSectionHeroes(instance.heroes, instance.pinned, section, sortBaseline, layout.heroRegistry)
+				heroesPerRow := section.ColumnHeroesPerRow
+				if heroesPerRow <= 0 {
+					heroesPerRow = max(layout.heroesPerRow/len(columns), 1)
+				}
+				generateSectionFunc(instance.title, columns, heroesPerRow, section.Labels, instanceBaseline)
+			}
+		}
 	}
 
 	configs = append(configs, mergedConfig)
 	return configs
 }
 
-// processHeroesLayoutConfig processes a hero_grid_config.json file
-func processHeroesLayoutConfig(configPath string, positions []string, positionToAggregatedHeroes map[string][]providers.Hero, heroesPerRow int) error {
-	// Read the existing config file
-	data, err := os.ReadFile(configPath)
+// facetSectionHeroes returns the heroes of a position as the section's facets mode shows them
+func facetSectionHeroes(pool []providers.Hero, facets string) []providers.Hero {
+	switch facets {
+	case config.FacetsBest:
+		return providers.BestFacetHeroes(pool)
+	case config.FacetsSplit:
+		return providers.SplitFacetHeroes(pool)
+	default:
+		return pool
+	}
+}
+
+// selectSectionHeroes returns the pinned heroes followed by the top heroes by the sort key that pass
+// the section filters, Count heroes in total. Pinned heroes ignore the filters and are never cut,
+// heroes without matches pass the matches and winrate filters.
+// The baseline is only used by sections sorted by rating change.
+func selectSectionHeroes(pool []providers.Hero, pinned []int, section config.LayoutSection, baseline map[int]history.HeroStats) []providers.Hero {
+	var eligible []providers.Hero
+	for _, hero := range pool {
+		if slices.Contains(pinned, hero.HeroID) {
+			continue
+		}
+		// Heroes of rating-only lists have unknown matches and winrate, the filters don't apply to them
+		if hero.Matches > 0 && hero.Matches < section.Filters.MinMatches {
+			continue
+		}
+		if hero.Matches > 0 && section.Filters.MinWinrate > 0 && heroWinrate(hero) < section.Filters.MinWinrate {
+			continue
+		}
+		eligible = append(eligible, hero)
+	}
+
+	count := max(section.Count-len(pinned), 0)
+	if section.SortBy == config.SortByRatingChange {
+		return append(pinnedHeroes(pool, pinned), rankByRatingChange(eligible, baseline, count)...)
+	}
+
+	ranked, err := providers.RankHeroes(eligible, section.SortBy, count)
 	if err != nil {
-		return fmt.Errorf("error reading config file: %w", err)
+		slog.Warn("Unknown section sort key, falling back to rating", "sortBy", section.SortBy)
+		ranked, _ = providers.RankHeroes(eligible, providers.RankingRating, count)
 	}
+	return append(pinnedHeroes(pool, pinned), ranked...)
+}
 
-	config := heroGridConfig{
-		Version: 3,
+// heroWinrate returns the hero winrate in percent
+func heroWinrate(hero providers.Hero) float64 {
+	return providers.Winrate(hero) * 100
+}
+
+// formatWinrate returns the label of a hero winrate, empty when its matches are unknown
+func formatWinrate(hero providers.Hero) string {
+	if hero.Matches <= 0 {
+		return ""
 	}
+	return fmt.Sprintf("%.1f%%", heroWinrate(hero))
+}
 
-	if err := json.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("error parsing config file: %w", err)
+// labelPlaceholders are the placeholders understood by formatHeroLabel
+var labelPlaceholders = []string{"winrate", "matches", "rating", "winrateDelta", "ratingDelta", "rank", "facet", "facetWinrate"}
+
+// formatHeroLabel renders a label format, replacing the {winrate}, {matches}, {rating},
+// {winrateDelta}, {ratingDelta}, {rank}, {facet} and {facetWinrate} placeholders. Deltas are empty
+// when the hero has no trend, matches and winrates when the hero has no matches (rating-only lists); rank is the 1-based place of the hero in its section column. The facet
+// placeholders show the facet of the entry, or the best facet of a merged hero, and are empty without facet stats.
+func formatHeroLabel(format string, hero providers.Hero, rank int, trend heroTrend) string {
+	winrateDelta, ratingDelta := "", ""
+	if trend.known {
+		ratingDelta = formatDelta(float64(trend.ratingDelta), 0)
+	}
+	if trend.winrateKnown {
+		winrateDelta = formatDelta(trend.winrateDelta, 1)
 	}
 
-	// Filter out existing configs with [D2T] prefix
-	var filteredConfigs []heroGridCategory
-	for _, cfg := range config.Configs {
-		if !strings.HasPrefix(cfg.ConfigName, d2tPrefix) {
-			filteredConfigs = append(filteredConfigs, cfg)
-		}
+	matches := ""
+	if hero.Matches > 0 {
+		matches = strconv.Itoa(hero.Matches)
 	}
 
-	config.Configs = filteredConfigs
+	facet, facetWinrate := "", ""
+	facetHero := hero
+	if best, ok := hero.BestFacet(); ok && hero.Facet == 0 {
+		facetHero = hero.WithFacet(best)
+	}
+	if facetHero.Facet > 0 {
+		facet = fmt.Sprintf("F%d", facetHero.Facet)
+		facetWinrate = formatWinrate(facetHero)
+	}
+
+	return strings.NewReplacer(
+		"{winrateDelta}", winrateDelta,
+		"{ratingDelta}", ratingDelta,
+		"{facetWinrate}", facetWinrate,
+		"{facet}", facet,
+		"{winrate}", formatWinrate(hero),
+		"{matches}", matches,
+		"{rating}", strconv.Itoa(hero.D2PTRating),
+		"{rank}", strconv.Itoa(rank),
+	).Replace(format)
+}
 
-	// Generate hero grid config
-	newConfigs := generateHeroesLayoutConfigs(d2tPrefix, positions, positionToAggregatedHeroes, heroesPerRow)
+// jobGridConfig is the config generated by one job
+type jobGridConfig struct {
+	jobID    string
+	config   heroGridCategory
+	warnings []string // auto-fit changes and canvas overflows
+}
 
-	config.Configs = append(config.Configs, newConfigs...)
+// jobMarker returns the prefix identifying the configs generated by a job
+func jobMarker(jobID string) string {
+	if jobID == config.DefaultGenerationJobID {
+		return d2tPrefix
+	}
+	return fmt.Sprintf("[D2T:%s]", jobID)
+}
 
-	// Write the updated config back to file
-	updatedData, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshaling updated config: %w", err)
+// configJobID returns the ID of the job that generated a config; ok is false for user configs
+func configJobID(configName string) (string, bool) {
+	match := d2tMarkerRegex.FindStringSubmatch(configName)
+	if match == nil {
+		return "", false
+	}
+	if match[1] == "" {
+		return config.DefaultGenerationJobID, true
 	}
+	return match[1], true
+}
 
-	if err := os.WriteFile(configPath, updatedData, 0644); err != nil {
-		return fmt.Errorf("error writing updated config: %w", err)
+// jobConfigName returns the name of the config generated by a job: the job display name with
+// {date} replaced, or "<marker> <job name> <date>" when the job has no display name
+func jobConfigName(job config.GenerationJob, date string) string {
+	if strings.TrimSpace(job.DisplayName) != "" {
+		return strings.ReplaceAll(job.DisplayName, "{date}", date)
 	}
+	return fmt.Sprintf("%s %s %s", jobMarker(job.ID), job.Name, date)
+}
+
+// generateJobGridConfig generates the config of a job
+func generateJobGridConfig(job config.GenerationJob, layout layoutData, template config.LayoutTemplate) jobGridConfig {
+	gridConfig, warnings := generateFittedConfig(d2tPrefix, layout, template)
+	gridConfig.ConfigName = jobConfigName(job, time.Now().Format("2006-01-02"))
+	return jobGridConfig{jobID: job.ID, config: gridConfig, warnings: warnings}
+}
 
-	return nil
+// processHeroesLayoutConfig replaces the configs of the generated jobs in a hero_grid_config.json file.
+// Configs of the target's other jobs (jobIDs) are kept, so a failed job leaves its previous grid in place;
+// configs of jobs that are no longer configured are removed.
+//
+// The file's manifest tells which configs d2tool wrote: configs the user renamed or edited no longer
+// match it and are left alone, like every other user config. A user config with the name of a
+// generated one is renamed with an "(edited)" suffix, as Dota shows only one config of a name. Files
+// without a manifest yet, written by older versions, are recognised by the "[D2T]" name marker once.
+func processHeroesLayoutConfig(configPath string, generated []jobGridConfig, jobIDs []string) error {
+	var entries []manifestEntry
+	err := modifyHeroGridFile(configPath, func(gridConfig *heroGridConfig) error {
+		manifest, hasManifest, err := loadGridManifest(configPath)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		owner := func(cfg heroGridCategory) (manifestEntry, bool) {
+			if !hasManifest {
+				jobID, ok := configJobID(cfg.ConfigName)
+				return manifestEntry{JobID: jobID, ConfigName: cfg.ConfigName, Hash: hashGridConfig(cfg), WrittenAt: now}, ok
+			}
+			entry, ok := manifest.owner(cfg)
+			if !ok && manifest.recordsName(cfg.ConfigName) {
+				slog.Info("Generated config was edited, leaving it to the user", "path", configPath, "config", cfg.ConfigName)
+			}
+			return entry, ok
+		}
+
+		generatedIDs := utils.Map(generated, func(g jobGridConfig) string { return g.jobID })
+
+		// Names a renamed user config must not take
+		taken := slices.Clone(gridConfig.Configs)
+		for _, g := range generated {
+			taken = append(taken, g.config)
+		}
+
+		// Filter out the configs being replaced and the ones of removed jobs
+		var filteredConfigs []heroGridCategory
+		var previousEntries []manifestEntry // every config of the file d2tool wrote, replaced or not
+		for _, cfg := range gridConfig.Configs {
+			entry, ok := owner(cfg)
+			if ok {
+				previousEntries = append(previousEntries, entry)
+				if slices.Contains(generatedIDs, entry.JobID) || !slices.Contains(jobIDs, entry.JobID) {
+					continue
+				}
+				entries = append(entries, entry)
+			} else if slices.ContainsFunc(generated, func(g jobGridConfig) bool { return g.config.ConfigName == cfg.ConfigName }) {
+				name := uniqueConfigName(taken, cfg.ConfigName+" (edited)")
+				slog.Info("Renaming user config with the name of a generated config", "path", configPath, "config", cfg.ConfigName, "name", name)
+				cfg.ConfigName = name
+				taken = append(taken, cfg)
+			}
+			filteredConfigs = append(filteredConfigs, cfg)
+		}
+
+		gridConfig.Configs = filteredConfigs
+		for _, g := range generated {
+			gridConfig.Configs = append(gridConfig.Configs, g.config)
+			entries = append(entries, manifestEntry{JobID: g.jobID, ConfigName: g.config.ConfigName, Hash: hashGridConfig(g.config), WrittenAt: now})
+		}
+
+		// Until the grid is written the manifest lists the configs of both the old and the new file, so
+		// whichever file a failed write leaves, no config of d2tool is mistaken for a user config
+		return saveGridManifest(configPath, gridManifest{Configs: append(previousEntries, entries...)})
+	})
+	if err != nil {
+		return err
+	}
+	return saveGridManifest(configPath, gridManifest{Configs: entries})
 }
@@ -1,8 +1,10 @@
 package heroesLayout
 
 import (
+	"d2tool/config"
 	"d2tool/providers"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,7 +23,7 @@ func TestGenerateHeroesLayoutConfigs_CreatesD2TPrefix(t *testing.T) {
 		},
 	}
 
-	configs := generateHeroesLayoutConfigs(d2tPrefix, positions, positionToHeroes, 15)
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}, config.DefaultLayoutTemplate())
 
 	if len(configs) == 0 {
 		t.Fatal("expected at least one config")
@@ -42,7 +44,7 @@ func TestGenerateHeroesLayoutConfigs_CreatesCategories(t *testing.T) {
 		},
 	}
 
-	configs := generateHeroesLayoutConfigs(d2tPrefix, positions, positionToHeroes, 15)
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}, config.DefaultLayoutTemplate())
 
 	if len(configs[0].Categories) == 0 {
 		t.Fatal("expected categories to be created")
@@ -76,7 +78,7 @@ func TestGenerateHeroesLayoutConfigs_CalculatesWinrate(t *testing.T) {
 		},
 	}
 
-	configs := generateHeroesLayoutConfigs(d2tPrefix, positions, positionToHeroes, 15)
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}, config.DefaultLayoutTemplate())
 
 	// Find a category with winrate percentage
 	foundWinrate := false
@@ -113,7 +115,7 @@ func TestProcessHeroesLayoutConfig_PreservesUserConfigs(t *testing.T) {
 		"1": {{HeroID: 1, D2PTRating: 100, Matches: 100, Wins: 50}},
 	}
 
-	err := processHeroesLayoutConfig(configPath, positions, positionToHeroes, 15)
+	err := processHeroesLayoutConfig(configPath, defaultJobConfigs(layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}), defaultJobIDs)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -157,7 +159,7 @@ func TestProcessHeroesLayoutConfig_RemovesOldD2TConfigs(t *testing.T) {
 		"1": {{HeroID: 1, D2PTRating: 100, Matches: 100, Wins: 50}},
 	}
 
-	err := processHeroesLayoutConfig(configPath, positions, positionToHeroes, 15)
+	err := processHeroesLayoutConfig(configPath, defaultJobConfigs(layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}), defaultJobIDs)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -195,7 +197,7 @@ func TestProcessHeroesLayoutConfig_InvalidJSON(t *testing.T) {
 		"1": {{HeroID: 1}},
 	}
 
-	err := processHeroesLayoutConfig(configPath, positions, positionToHeroes, 15)
+	err := processHeroesLayoutConfig(configPath, defaultJobConfigs(layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}), defaultJobIDs)
 	if err == nil {
 		t.Error("expected error for invalid JSON")
 	}
@@ -207,18 +209,18 @@ func TestProcessHeroesLayoutConfig_FileNotFound(t *testing.T) {
 		"1": {{HeroID: 1}},
 	}
 
-	err := processHeroesLayoutConfig("/nonexistent/path/config.json", positions, positionToHeroes, 15)
+	err := processHeroesLayoutConfig("/nonexistent/path/config.json", defaultJobConfigs(layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}), defaultJobIDs)
 	if err == nil {
 		t.Error("expected error for nonexistent file")
 	}
 }
 
-func TestProcessHeroesLayoutConfig_PreservesVersion(t *testing.T) {
+func TestProcessHeroesLayoutConfig_RefusesUnknownVersion(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "hero_grid_config.json")
 
 	existingConfig := heroGridConfig{
-		Version: 42, // Custom version number
+		Version: 42, // Version newer than d2tool knows
 		Configs: []heroGridCategory{},
 	}
 	data, _ := json.MarshalIndent(existingConfig, "", "  ")
@@ -229,17 +231,14 @@ func TestProcessHeroesLayoutConfig_PreservesVersion(t *testing.T) {
 		"1": {{HeroID: 1, D2PTRating: 100, Matches: 100, Wins: 50}},
 	}
 
-	err := processHeroesLayoutConfig(configPath, positions, positionToHeroes, 15)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	err := processHeroesLayoutConfig(configPath, defaultJobConfigs(layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}), defaultJobIDs)
+	if !errors.Is(err, errUnsupportedGridVersion) {
+		t.Fatalf("expected errUnsupportedGridVersion, got %v", err)
 	}
 
 	resultData, _ := os.ReadFile(configPath)
-	var resultConfig heroGridConfig
-	json.Unmarshal(resultData, &resultConfig)
-
-	if resultConfig.Version != 42 {
-		t.Errorf("expected version 42 to be preserved, got %d", resultConfig.Version)
+	if string(resultData) != string(data) {
+		t.Error("expected a file of an unknown version to be left untouched")
 	}
 }
 
@@ -247,7 +246,7 @@ func TestGenerateHeroesLayoutConfigs_EmptyPositions(t *testing.T) {
 	positions := []string{}
 	positionToHeroes := map[string][]providers.Hero{}
 
-	configs := generateHeroesLayoutConfigs(d2tPrefix, positions, positionToHeroes, 15)
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}, config.DefaultLayoutTemplate())
 
 	if len(configs) != 1 {
 		t.Fatalf("expected 1 config even with empty positions, got %d", len(configs))
@@ -268,7 +267,7 @@ func TestGenerateHeroesLayoutConfigs_MultiplePositions(t *testing.T) {
 		}
 	}
 
-	configs := generateHeroesLayoutConfigs(d2tPrefix, positions, positionToHeroes, 15)
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 15}, config.DefaultLayoutTemplate())
 
 	// Count position headers
 	positionHeaders := 0
@@ -287,3 +286,99 @@ func TestGenerateHeroesLayoutConfigs_MultiplePositions(t *testing.T) {
 		t.Errorf("expected %d position headers, got %d", expectedHeaders, positionHeaders)
 	}
 }
+
+var defaultJobIDs = []string{config.DefaultGenerationJobID}
+
+// defaultJobConfigs generates the config of the default job with the default template
+func defaultJobConfigs(layout layoutData) []jobGridConfig {
+	return []jobGridConfig{generateJobGridConfig(config.DefaultGenerationJob(), layout, config.DefaultLayoutTemplate())}
+}
+
+func TestConfigJobID(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantID string
+		wantOK bool
+	}{
+		{"[D2T] Heroes Meta 2024-01-01", config.DefaultGenerationJobID, true},
+		{"[D2T:patch] This Patch 2024-01-01", "patch", true},
+		{"[D2T:last_8-days] Grid", "last_8-days", true},
+		{"My Custom Grid", "", false},
+		{"[D2T:] Broken", "", false},
+		{"Copy of [D2T] Heroes Meta", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := configJobID(tt.name)
+			if id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("configJobID(%q) = %q, %v, want %q, %v", tt.name, id, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateJobGridConfig_Name(t *testing.T) {
+	layout := layoutData{positions: []string{"1"}, positionToHeroes: map[string][]providers.Hero{}, heroesPerRow: 15}
+
+	defaultJob := generateJobGridConfig(config.DefaultGenerationJob(), layout, config.DefaultLayoutTemplate())
+	if !strings.HasPrefix(defaultJob.config.ConfigName, "[D2T] Heroes Meta ") {
+		t.Errorf("expected the default job to keep the legacy name, got %q", defaultJob.config.ConfigName)
+	}
+
+	patchJob := generateJobGridConfig(config.GenerationJob{ID: "patch", Name: "This Patch"}, layout, config.DefaultLayoutTemplate())
+	if !strings.HasPrefix(patchJob.config.ConfigName, "[D2T:patch] This Patch ") {
+		t.Errorf("unexpected job config name %q", patchJob.config.ConfigName)
+	}
+}
+
+func TestProcessHeroesLayoutConfig_ManagesJobsIndependently(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "hero_grid_config.json")
+
+	existingConfig := heroGridConfig{
+		Version: 3,
+		Configs: []heroGridCategory{
+			{ConfigName: "My Custom Grid", Categories: []heroGridPosition{}},
+			{ConfigName: "[D2T:last8] Last 8 Days 2024-01-01", Categories: []heroGridPosition{}},
+			{ConfigName: "[D2T:patch] This Patch 2024-01-01", Categories: []heroGridPosition{}},
+			{ConfigName: "[D2T:removed] Old Job 2024-01-01", Categories: []heroGridPosition{}},
+			{ConfigName: "[D2T] Heroes Meta 2024-01-01", Categories: []heroGridPosition{}},
+		},
+	}
+	data, _ := json.MarshalIndent(existingConfig, "", "  ")
+	os.WriteFile(configPath, data, 0644)
+
+	layout := layoutData{
+		positions:        []string{"1"},
+		positionToHeroes: map[string][]providers.Hero{"1": {{HeroID: 1, D2PTRating: 100, Matches: 100, Wins: 50}}},
+		heroesPerRow:     15,
+	}
+	// Only last8 was generated, e.g. because fetching the patch stats failed
+	generated := []jobGridConfig{
+		generateJobGridConfig(config.GenerationJob{ID: "last8", Name: "Last 8 Days"}, layout, config.DefaultLayoutTemplate()),
+	}
+
+	if err := processHeroesLayoutConfig(configPath, generated, []string{"last8", "patch"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resultData, _ := os.ReadFile(configPath)
+	var resultConfig heroGridConfig
+	json.Unmarshal(resultData, &resultConfig)
+
+	var names []string
+	for _, cfg := range resultConfig.Configs {
+		names = append(names, cfg.ConfigName)
+	}
+
+	if len(names) != 3 {
+		t.Fatalf("expected user config, kept patch config and new last8 config, got %v", names)
+	}
+	if names[0] != "My Custom Grid" || names[1] != "[D2T:patch] This Patch 2024-01-01" {
+		t.Errorf("expected user and failed job configs to be kept in place, got %v", names)
+	}
+	if !strings.HasPrefix(names[2], "[D2T:last8] Last 8 Days ") || strings.HasSuffix(names[2], "2024-01-01") {
+		t.Errorf("expected a regenerated last8 config, got %q", names[2])
+	}
+}
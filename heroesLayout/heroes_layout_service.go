@@ -1,109 +1,767 @@
 package heroesLayout
 
 import (
+	"bytes"
+	"cmp"
 	"d2tool/config"
+	"d2tool/heroes"
+	"d2tool/history"
 	"d2tool/providers"
 	"d2tool/steam"
 	"d2tool/utils"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
 	"sync"
 	"time"
 )
 
 type HeroesLayoutService interface {
 	UpdateHeroesLayout() error
+	HeroesSourcesChanged() bool
+	GetHeroes() []heroes.Hero
+	HistorySources() ([]history.Source, error)
+	GetHeroHistory(source history.Source, heroID int, positionID string, days int) ([]history.SeriesPoint, error)
+	ExportHistory(source history.Source, filePath string, format string) error
+	PreviewGridFile(gridPath string) ([]GridPreview, error)
+	ExportGridPreview(gridPath string, configName string, format string, outPath string) error
+	ExportGridConfig(gridPath string, configName string, outPath string) error
+	GetGridShareString(gridPath string, configName string) (string, error)
+	ImportGridConfig(data string, gridPaths []string, collision string) ([]GridImportResult, error)
+	GetGridConfigNames(gridPath string) ([]string, error)
+	SyncGridMirror(id string, overwriteConflicts bool) ([]MirrorTargetResult, error)
+	SyncAutoGridMirrors()
+	ValidateGridFile(gridPath string) (GridValidationReport, error)
+	RepairGridFile(gridPath string) (GridRepairResult, error)
+	HeroesProviderNames() []string
+	TestHeroesProvider(providerName string, positionID string) (ProviderTestResult, error)
+	ProviderHealth() []ProviderHealth
+}
+
+// ProviderHealth lists the recent requests of a provider
+type ProviderHealth struct {
+	Provider    string                       `json:"provider"`
+	Kind        string                       `json:"kind"`        // "heroes" or "matchups"
+	Diagnostics []providers.FetchDiagnostics `json:"diagnostics"` // newest first
+}
+
+// ProviderTestResult is the outcome of fetching one position from a provider
+type ProviderTestResult struct {
+	Heroes      []providers.Hero            `json:"heroes"` // ordered by rating
+	Diagnostics *providers.FetchDiagnostics `json:"diagnostics"`
+	Error       string                      `json:"error"`
 }
 
 type HeroesLayoutServiceImpl struct {
-	mu             sync.Mutex
-	config         *config.Config
-	steamService   *steam.SteamService
-	heroesProvider providers.HeroesProvider
+	mu               sync.Mutex
+	config           *config.Config
+	steamService     *steam.SteamService
+	heroesProvider   providers.HeroesProvider            // the default provider of jobs without one
+	heroesProviders  map[string]providers.HeroesProvider // by name, jobs and the composite provider select them
+	composite        *providers.CompositeHeroesProvider  // its sources follow the settings
+	matchupsProvider providers.MatchupsProvider
+	heroRegistry     *heroes.Registry
+	historyStore     *history.Store
 }
 
-func NewHeroesLayoutService(config *config.Config, steamService *steam.SteamService, heroesProvider providers.HeroesProvider) *HeroesLayoutServiceImpl {
+// NewHeroesLayoutService creates the service; the first of heroesProviders is the default provider
+func NewHeroesLayoutService(config *config.Config, steamService *steam.SteamService, heroesProviders []providers.HeroesProvider, matchupsProvider providers.MatchupsProvider, heroRegistry *heroes.Registry, historyStore *history.Store) *HeroesLayoutServiceImpl {
+	providerByName := make(map[string]providers.HeroesProvider, len(heroesProviders))
+	for _, provider := range heroesProviders {
+		providerByName[provider.Name()] = provider
+	}
 	return &HeroesLayoutServiceImpl{
-		config:         config,
-		steamService:   steamService,
-		heroesProvider: heroesProvider,
+		config:           config,
+		steamService:     steamService,
+		heroesProvider:   heroesProviders[0],
+		heroesProviders:  providerByName,
+		composite:        providers.NewCompositeHeroesProvider(nil, false),
+		matchupsProvider: matchupsProvider,
+		heroRegistry:     heroRegistry,
+		historyStore:     historyStore,
 	}
 }
 
+// HeroesSourcesChanged reports whether a heroes provider has new statistics since it was last
+// fetched, like an edited hero stats file, so the layouts should be updated now
+func (s *HeroesLayoutServiceImpl) HeroesSourcesChanged() bool {
+	for _, provider := range s.heroesProviders {
+		if reporter, ok := provider.(providers.ChangeReporter); ok && reporter.Changed() {
+			return true
+		}
+	}
+	return false
+}
+
+// updateTarget is a hero grid file that receives generated configs: a Steam account grid or a custom file
+type updateTarget struct {
+	path       string
+	steamId64  string // empty for custom files
+	templateID string
+	profile    config.GenerationProfile
+	jobs       []config.GenerationJob
+}
+
+// fetchKey identifies one provider request; every key is fetched at most once per update
+type fetchKey struct {
+	provider string
+	period   string
+	position string
+}
+
 func (s *HeroesLayoutServiceImpl) UpdateHeroesLayout() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	steamAccountPaths := s.steamService.GetEnabledAccountPaths() // map[steamId64]path
-	enabledFilePaths := s.config.GetEnabledFilePaths()
-	enabledPositions := s.config.GetEnabledPositionIDs()
+	targets := s.updateTargets()
+	if len(targets) == 0 {
+		slog.Info("No config files provided, skipping update")
+		return nil
+	}
 
-	pathToSteamId64 := make(map[string]string, len(steamAccountPaths))
-	var allPaths []string
-	for steamId64, path := range steamAccountPaths {
-		pathToSteamId64[path] = steamId64
-		allPaths = append(allPaths, path)
+	now := time.Now()
+
+	fetched := make(map[fetchKey][]providers.Hero)
+	fetchErrs := make(map[fetchKey]error)
+	fetchWarnings := make(map[fetchKey][]string)
+	// fetch returns the heroes of a position and warnings about suspicious statistics
+	fetch := func(provider providers.HeroesProvider, period string, position string) ([]providers.Hero, []string, error) {
+		key := fetchKey{provider: provider.Name(), period: period, position: position}
+		if err, ok := fetchErrs[key]; ok {
+			return nil, nil, err
+		}
+		if heroes, ok := fetched[key]; ok {
+			return heroes, fetchWarnings[key], nil
+		}
+
+		fetchedHeroes, err := provider.FetchHeroes(position, period)
+		if err != nil {
+			slog.Error("Error fetching heroes for position", "provider", key.provider, "position", position, "period", period, "error", err)
+			fetchErrs[key] = fmt.Errorf("error fetching heroes for position %s from %s: %w", position, key.provider, err)
+			return nil, nil, fetchErrs[key]
+		}
+		fetched[key] = completeHeroNames(providers.AggregateHeroesByID(fetchedHeroes), s.heroRegistry)
+		for _, warning := range providers.CheckHeroes(fetched[key]) {
+			slog.Warn("Suspicious heroes stats", "provider", key.provider, "position", position, "period", period, "warning", warning)
+			fetchWarnings[key] = append(fetchWarnings[key], fmt.Sprintf("%s %s: %s", key.provider, position, warning))
+		}
+		return fetched[key], fetchWarnings[key], nil
 	}
-	allPaths = append(allPaths, enabledFilePaths...)
 
-	if len(allPaths) == 0 {
-		slog.Info("No config files provided, skipping update")
-		return nil
+	// Matchups don't depend on the period or position and are fetched once per update
+	matchups := make(map[matchupKey][]providers.Matchup)
+	failedMatchups := make(map[matchupKey]error)
+	fetchMatchups := func(keys []matchupKey) (map[matchupKey][]providers.Matchup, []error) {
+		result := make(map[matchupKey][]providers.Matchup, len(keys))
+		var errs []error
+		for _, key := range keys {
+			if _, ok := matchups[key]; !ok && failedMatchups[key] == nil {
+				fetchedMatchups, err := s.matchupsProvider.FetchMatchups(key.heroID, key.kind)
+				if err != nil {
+					slog.Error("Error fetching matchups", "heroId", key.heroID, "kind", key.kind, "error", err)
+					failedMatchups[key] = fmt.Errorf("error fetching %s matchups of hero %d: %w", key.kind, key.heroID, err)
+				} else {
+					matchups[key] = fetchedMatchups
+				}
+			}
+			if err := failedMatchups[key]; err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			result[key] = matchups[key]
+		}
+		return result, errs
 	}
 
-	if len(enabledPositions) == 0 {
-		slog.Info("No hero positions enabled, skipping update")
-		return nil
+	baselines := make(map[fetchKey]trendBaseline) // by provider and period
+
+	for _, target := range targets {
+		slog.Info("Processing config file", "path", target.path, "profile", target.profile.ID)
+
+		profile := target.profile
+		enabledPositions := profile.EnabledPositionIDs()
+
+		var generated []jobGridConfig
+		var jobErrs []error
+		var warnings []string
+		for _, job := range target.jobs {
+			period := cmp.Or(job.Period, profile.Period)
+			positionIDs := job.Positions
+			if len(positionIDs) == 0 {
+				positionIDs = enabledPositions
+			}
+			if len(positionIDs) == 0 {
+				slog.Info("No hero positions enabled, skipping job", "path", target.path, "job", job.ID)
+				continue
+			}
+			provider, err := s.resolveHeroesProvider(job.Provider)
+			if err != nil {
+				jobErrs = append(jobErrs, fmt.Errorf("job %s: %w", job.Name, err))
+				continue
+			}
+
+			baselineKey := fetchKey{provider: provider.Name(), period: period}
+			if _, ok := baselines[baselineKey]; !ok {
+				baselines[baselineKey] = s.trendBaseline(provider.Name(), period, now)
+			}
+			layout := layoutData{
+				positionToHeroes: make(map[string][]providers.Hero),
+				positionToPinned: make(map[string][]int),
+				heroesPerRow:     profile.HeroesPerRow,
+				heroRegistry:     s.heroRegistry,
+				baseline:         baselines[baselineKey],
+			}
+
+			var fetchErr error
+			for _, positionID := range positionIDs {
+				position := positionPrefix + positionID
+				positionHeroes, positionWarnings, err := fetch(provider, period, position)
+				if err != nil {
+					fetchErr = err
+					break
+				}
+				for _, warning := range positionWarnings {
+					warnings = append(warnings, fmt.Sprintf("%s: %s", job.Name, warning))
+				}
+				layout.positions = append(layout.positions, position)
+				layout.positionToPinned[position] = pinnedHeroIDs(profile.HeroLists, positionID)
+				layout.positionToHeroes[position] = applyHeroLists(positionHeroes, profile.HeroLists, layout.positionToPinned[position])
+			}
+			if fetchErr != nil {
+				jobErrs = append(jobErrs, fmt.Errorf("job %s: %w", job.Name, fetchErr))
+				continue
+			}
+
+			template := s.config.GetLayoutTemplate(cmp.Or(job.TemplateID, target.templateID, profile.TemplateID))
+
+			// Missing matchups leave their sections empty instead of failing the whole grid
+			var matchupErrs []error
+			layout.matchups, matchupErrs = fetchMatchups(templateMatchupKeys(template))
+			for _, err := range matchupErrs {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", job.Name, err))
+			}
+
+			jobConfig := generateJobGridConfig(job, layout, template)
+			for _, warning := range jobConfig.warnings {
+				slog.Warn("Generated grid doesn't fit the hero grid canvas", "path", target.path, "job", job.ID, "warning", warning)
+				warnings = append(warnings, fmt.Sprintf("%s: %s", job.Name, warning))
+			}
+			generated = append(generated, jobConfig)
+		}
+
+		jobIDs := utils.Map(target.jobs, func(job config.GenerationJob) string { return job.ID })
+		if len(generated) > 0 {
+			if err := processHeroesLayoutConfig(target.path, generated, jobIDs); err != nil {
+				slog.Error("Error processing config file", "path", target.path, "error", err)
+				jobErrs = append(jobErrs, fmt.Errorf("error processing config file: %w", err))
+			} else {
+				slog.Info("Successfully updated config file", "path", target.path, "jobs", len(generated))
+			}
+		}
+
+		errorMsg := ""
+		if len(jobErrs) > 0 {
+			errorMsg = errors.Join(jobErrs...).Error()
+		}
+		if target.steamId64 != "" {
+			s.steamService.UpdateAccountStatus(target.steamId64, now.UnixMilli(), errorMsg, warnings)
+		} else {
+			s.config.UpdateHeroesLayoutFileStatus([]string{target.path}, now.UnixMilli(), errorMsg, warnings)
+		}
 	}
 
-	positions := utils.Map(enabledPositions, func(position string) string {
-		return fmt.Sprintf("%s%s", positionPrefix, position)
-	})
+	s.recordFetchedHistory(fetched, now)
+
+	if len(fetchErrs) > 0 {
+		var errs []error
+		for _, err := range fetchErrs {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+	return nil
+}
 
-	d2ptConfig := s.config.GetD2PTConfig()
-	period := d2ptConfig.Period
-	heroesPerRow := s.config.GetHeroesPerRow()
+// updateTargets returns the enabled Steam account grids followed by the enabled custom files,
+// each with its resolved generation profile
+func (s *HeroesLayoutServiceImpl) updateTargets() []updateTarget {
+	steamAccountPaths := s.steamService.GetEnabledAccountPaths() // map[steamId64]path
 
-	positionToAggregatedHeroes := make(map[string][]providers.Hero)
+	var targets []updateTarget
+	for _, account := range s.config.GetSteamAccounts() {
+		if path, ok := steamAccountPaths[account.SteamID64]; ok {
+			targets = append(targets, updateTarget{
+				path:       path,
+				steamId64:  account.SteamID64,
+				templateID: account.TemplateID,
+				profile:    s.config.GetGenerationProfile(account.ProfileID),
+				jobs:       config.EffectiveGenerationJobs(account.Jobs),
+			})
+		}
+	}
+	for _, file := range s.config.GetHeroesLayoutFiles() {
+		if file.Enabled {
+			targets = append(targets, updateTarget{
+				path:       file.FilePath,
+				templateID: file.TemplateID,
+				profile:    s.config.GetGenerationProfile(file.ProfileID),
+				jobs:       config.EffectiveGenerationJobs(file.Jobs),
+			})
+		}
+	}
+	return targets
+}
 
-	var positionsFetchErr error
-	for _, position := range positions {
-		heroes, err := s.heroesProvider.FetchHeroes(position, period)
-		if err != nil {
-			slog.Error("Error fetching heroes for position", "position", position, "error", err)
-			positionsFetchErr = fmt.Errorf("error fetching heroes for position %s: %w", position, err)
-			break
+// GetHeroes returns the metadata of every known hero
+func (s *HeroesLayoutServiceImpl) GetHeroes() []heroes.Hero {
+	return s.heroRegistry.All()
+}
+
+// resolveHeroesProvider returns the provider a job selects, empty selects the default provider.
+// The composite provider is built from the current settings, so changes apply to the next update;
+// callers must hold s.mu.
+func (s *HeroesLayoutServiceImpl) resolveHeroesProvider(name string) (providers.HeroesProvider, error) {
+	if name == "" {
+		return s.heroesProvider, nil
+	}
+	if name == providers.CompositeProviderName {
+		return s.compositeProvider()
+	}
+	if provider, ok := s.heroesProviders[name]; ok {
+		return provider, nil
+	}
+	return nil, fmt.Errorf("unknown provider %q", name)
+}
+
+// compositeProvider returns the composite provider with the configured sources. It changes the
+// sources of the shared provider, so callers must hold s.mu.
+func (s *HeroesLayoutServiceImpl) compositeProvider() (*providers.CompositeHeroesProvider, error) {
+	composite := s.config.GetCompositeConfig()
+	if len(composite.Sources) == 0 {
+		return nil, errors.New("the composite provider has no sources configured")
+	}
+
+	sources := make([]providers.CompositeSource, 0, len(composite.Sources))
+	for _, source := range composite.Sources {
+		provider, ok := s.heroesProviders[source.Provider]
+		if !ok {
+			return nil, fmt.Errorf("unknown composite provider source %q", source.Provider)
 		}
-		positionToAggregatedHeroes[position] = providers.AggregateHeroesByID(heroes)
+		sources = append(sources, providers.CompositeSource{Provider: provider, Weight: source.Weight})
 	}
+	s.composite.SetSources(sources, composite.Mode == config.CompositeFallback)
+	return s.composite, nil
+}
 
-	now := time.Now()
+// HeroesProviderNames returns the providers jobs can select: the default one first, then the
+// other ones and the composite provider sorted by name
+func (s *HeroesLayoutServiceImpl) HeroesProviderNames() []string {
+	names := []string{providers.CompositeProviderName}
+	for name := range s.heroesProviders {
+		if name != s.heroesProvider.Name() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return append([]string{s.heroesProvider.Name()}, names...)
+}
+
+// TestHeroesProvider fetches the heroes of one position from a provider with the current period.
+// A failed fetch isn't an error, it is reported in the result with the diagnostics of the request.
+func (s *HeroesLayoutServiceImpl) TestHeroesProvider(providerName string, positionID string) (ProviderTestResult, error) {
+	// A running update keeps its composite sources, the test waits for it
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	provider, err := s.resolveHeroesProvider(providerName)
+	if err != nil {
+		return ProviderTestResult{}, err
+	}
 
-	if positionsFetchErr != nil {
-		for steamId64 := range steamAccountPaths {
-			s.steamService.UpdateAccountStatus(steamId64, now.UnixMilli(), positionsFetchErr.Error())
+	result := ProviderTestResult{Heroes: []providers.Hero{}}
+	fetchedHeroes, err := provider.FetchHeroes(positionPrefix+positionID, s.config.GetD2PTConfig().Period)
+	if err != nil {
+		slog.Warn("Provider test failed", "provider", provider.Name(), "position", positionID, "error", err)
+		result.Error = err.Error()
+	} else {
+		heroList := completeHeroNames(providers.AggregateHeroesByID(fetchedHeroes), s.heroRegistry)
+		result.Heroes = providers.GetTopHeroesByRating(heroList, len(heroList))
+	}
+
+	if reporter, ok := provider.(providers.DiagnosticsReporter); ok {
+		if diagnostics := reporter.Diagnostics(); len(diagnostics) > 0 {
+			result.Diagnostics = &diagnostics[0]
 		}
-		s.config.UpdateHeroesLayoutFileStatus(enabledFilePaths, now.UnixMilli(), positionsFetchErr.Error())
-		return positionsFetchErr
 	}
+	return result, nil
+}
 
-	for _, configFile := range allPaths {
-		slog.Info("Processing config file", "path", configFile)
+// ProviderHealth returns the recent requests of every provider: the heroes providers in the order
+// of HeroesProviderNames, then the matchups provider. It only reads the diagnostics, which the
+// providers guard themselves, so it doesn't wait for a running update.
+func (s *HeroesLayoutServiceImpl) ProviderHealth() []ProviderHealth {
+	var health []ProviderHealth
+	add := func(name string, kind string, provider any) {
+		entry := ProviderHealth{Provider: name, Kind: kind, Diagnostics: []providers.FetchDiagnostics{}}
+		if reporter, ok := provider.(providers.DiagnosticsReporter); ok {
+			entry.Diagnostics = reporter.Diagnostics()
+		}
+		health = append(health, entry)
+	}
 
-		errorMsg := ""
-		if err := processHeroesLayoutConfig(configFile, positions, positionToAggregatedHeroes, heroesPerRow); err != nil {
-			slog.Error("Error processing config file", "path", configFile, "error", err)
-			errorMsg = fmt.Sprintf("error processing config file: %v", err)
+	for _, name := range s.HeroesProviderNames() {
+		if name == providers.CompositeProviderName {
+			add(name, "heroes", s.composite)
 		} else {
-			slog.Info("Successfully updated config file", "path", configFile)
+			add(name, "heroes", s.heroesProviders[name])
 		}
+	}
+	add(s.matchupsProvider.Name(), "matchups", s.matchupsProvider)
+	return health
+}
 
-		if steamId64, ok := pathToSteamId64[configFile]; ok {
-			s.steamService.UpdateAccountStatus(steamId64, now.UnixMilli(), errorMsg)
-		} else {
-			s.config.UpdateHeroesLayoutFileStatus([]string{configFile}, now.UnixMilli(), errorMsg)
+// trendBaseline returns a loader of the previous statistics of a provider and period.
+// Snapshots are read once per window; history errors are logged and disable the trends.
+func (s *HeroesLayoutServiceImpl) trendBaseline(providerName string, period string, now time.Time) trendBaseline {
+	snapshots := make(map[int]*history.Snapshot)
+
+	return func(position string, days int) map[int]history.HeroStats {
+		snapshot, ok := snapshots[days]
+		if !ok {
+			var loaded history.Snapshot
+			var found bool
+			var err error
+			if days == previousUpdate {
+				// History is saved after the grids are written, so the latest snapshot is the previous update's
+				loaded, found, err = s.historyStore.Latest(providerName, period)
+			} else {
+				loaded, found, err = s.historyStore.Baseline(providerName, period, now, days)
+			}
+			if err != nil {
+				slog.Error("Error loading heroes stats history", "days", days, "error", err)
+			}
+			if found {
+				snapshot = &loaded
+			}
+			snapshots[days] = snapshot
+		}
+
+		if snapshot == nil {
+			return nil
+		}
+		return snapshot.HeroStatsByID(position)
+	}
+}
+
+// recordFetchedHistory saves today's statistics of every fetched provider and period and deletes
+// snapshots past the retention. Failures are logged only, history must never block a layout update.
+func (s *HeroesLayoutServiceImpl) recordFetchedHistory(fetched map[fetchKey][]providers.Hero, now time.Time) {
+	snapshotToStats := make(map[fetchKey]map[string][]history.HeroStats) // by provider and period
+	for key, positionHeroes := range fetched {
+		snapshotKey := fetchKey{provider: key.provider, period: key.period}
+		if snapshotToStats[snapshotKey] == nil {
+			snapshotToStats[snapshotKey] = make(map[string][]history.HeroStats)
+		}
+		snapshotToStats[snapshotKey][key.position] = heroStats(positionHeroes)
+	}
+
+	for key, positionToStats := range snapshotToStats {
+		snapshot := history.Snapshot{
+			Provider:  key.provider,
+			Period:    key.period,
+			Date:      now.Format(history.DateLayout),
+			TakenAt:   now,
+			Positions: positionToStats,
+		}
+		if err := s.historyStore.Save(snapshot); err != nil {
+			slog.Error("Error saving heroes stats history", "provider", key.provider, "period", key.period, "error", err)
+		}
+	}
+
+	if err := s.historyStore.Prune(s.config.GetHistoryRetentionDays(), now); err != nil {
+		slog.Error("Error pruning heroes stats history", "error", err)
+	}
+}
+
+func heroStats(positionHeroes []providers.Hero) []history.HeroStats {
+	return utils.Map(positionHeroes, func(hero providers.Hero) history.HeroStats {
+		return history.HeroStats{
+			HeroID:  hero.HeroID,
+			Matches: hero.Matches,
+			Wins:    hero.Wins,
+			Rating:  hero.D2PTRating,
 		}
+	})
+}
+
+// HistorySources returns the providers and periods with recorded history. The default provider
+// with the D2PT period is always listed first, so it can be selected before its first snapshot.
+func (s *HeroesLayoutServiceImpl) HistorySources() ([]history.Source, error) {
+	sources, err := s.historyStore.Sources()
+	if err != nil {
+		return nil, fmt.Errorf("error reading heroes stats history: %w", err)
+	}
+
+	defaultSource := s.defaultHistorySource()
+	sources = slices.DeleteFunc(sources, func(source history.Source) bool {
+		return source == defaultSource
+	})
+	return append([]history.Source{defaultSource}, sources...), nil
+}
+
+// historySource fills in the default provider and period of a history source left empty
+func (s *HeroesLayoutServiceImpl) historySource(source history.Source) history.Source {
+	defaultSource := s.defaultHistorySource()
+	return history.Source{
+		Provider: cmp.Or(source.Provider, defaultSource.Provider),
+		Period:   cmp.Or(source.Period, defaultSource.Period),
+	}
+}
+
+func (s *HeroesLayoutServiceImpl) defaultHistorySource() history.Source {
+	return history.Source{Provider: s.heroesProvider.Name(), Period: s.config.GetD2PTConfig().Period}
+}
+
+// GetHeroHistory returns the daily statistics of a hero in a position recorded for a provider
+// and period over the last days, or over the whole history when days is 0
+func (s *HeroesLayoutServiceImpl) GetHeroHistory(source history.Source, heroID int, positionID string, days int) ([]history.SeriesPoint, error) {
+	from := ""
+	if days > 0 {
+		from = time.Now().AddDate(0, 0, -days).Format(history.DateLayout)
+	}
+
+	source = s.historySource(source)
+	snapshots, err := s.historyStore.Range(source.Provider, source.Period, from, "")
+	if err != nil {
+		return nil, fmt.Errorf("error reading heroes stats history: %w", err)
+	}
+
+	points := history.HeroSeries(snapshots, positionPrefix+positionID, heroID)
+	if points == nil {
+		points = []history.SeriesPoint{}
+	}
+	return points, nil
+}
+
+// ExportHistory writes the whole history of a provider and period to a file
+func (s *HeroesLayoutServiceImpl) ExportHistory(source history.Source, filePath string, format string) error {
+	source = s.historySource(source)
+	snapshots, err := s.historyStore.Range(source.Provider, source.Period, "", "")
+	if err != nil {
+		return fmt.Errorf("error reading heroes stats history: %w", err)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating export file: %w", err)
+	}
+
+	if err := history.Export(file, format, snapshots, s.heroRegistry.Name); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing export file: %w", err)
 	}
 
+	slog.Info("Exported heroes stats history", "provider", source.Provider, "period", source.Period, "path", filePath, "format", format, "snapshots", len(snapshots))
 	return nil
 }
+
+// PreviewGridFile renders every config of a hero grid file as SVG
+func (s *HeroesLayoutServiceImpl) PreviewGridFile(gridPath string) ([]GridPreview, error) {
+	return PreviewGridFile(gridPath, s.heroRegistry.Name)
+}
+
+// ExportGridPreview renders one config of a hero grid file to an SVG or PNG file
+func (s *HeroesLayoutServiceImpl) ExportGridPreview(gridPath string, configName string, format string, outPath string) error {
+	var buf bytes.Buffer
+	if err := WriteGridPreview(&buf, gridPath, configName, format, s.heroRegistry.Name); err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error writing preview file: %w", err)
+	}
+
+	slog.Info("Exported hero grid preview", "path", outPath, "config", configName, "format", format)
+	return nil
+}
+
+// ExportGridConfig writes one config of a hero grid file to a standalone JSON file
+func (s *HeroesLayoutServiceImpl) ExportGridConfig(gridPath string, configName string, outPath string) error {
+	cfg, err := findGridConfig(gridPath, configName)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalSharedGrid(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing grid file: %w", err)
+	}
+
+	slog.Info("Exported hero grid", "path", outPath, "config", configName)
+	return nil
+}
+
+// GetGridShareString returns the share string of one config of a hero grid file
+func (s *HeroesLayoutServiceImpl) GetGridShareString(gridPath string, configName string) (string, error) {
+	cfg, err := findGridConfig(gridPath, configName)
+	if err != nil {
+		return "", err
+	}
+	return encodeShareString(cfg)
+}
+
+// ImportGridConfig adds a shared config, given as a share string or exported JSON, to every grid file.
+// Files are updated independently; the result of each is reported instead of stopping at the first failure.
+func (s *HeroesLayoutServiceImpl) ImportGridConfig(data string, gridPaths []string, collision string) ([]GridImportResult, error) {
+	if collision != ImportCollisionRename && collision != ImportCollisionReplace && collision != ImportCollisionSkip {
+		return nil, fmt.Errorf("unknown collision mode %q", collision)
+	}
+	cfg, err := parseSharedGrid(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make([]GridImportResult, 0, len(gridPaths))
+	for _, gridPath := range gridPaths {
+		status, configName, err := importGridConfig(gridPath, cfg, collision)
+		result := GridImportResult{FilePath: gridPath, ConfigName: configName, Status: status}
+		if err != nil {
+			slog.Error("Error importing hero grid", "path", gridPath, "config", configName, "error", err)
+			result.Error = err.Error()
+		} else {
+			slog.Info("Imported hero grid", "path", gridPath, "config", configName, "status", status)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// ValidateGridFile checks a hero grid file against the hero grid format and the known heroes
+func (s *HeroesLayoutServiceImpl) ValidateGridFile(gridPath string) (GridValidationReport, error) {
+	return ValidateGridFile(gridPath, s.knownHero)
+}
+
+// RepairGridFile backs up a hero grid file and rewrites it with the grids that could be salvaged and repaired
+func (s *HeroesLayoutServiceImpl) RepairGridFile(gridPath string) (GridRepairResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := RepairGridFile(gridPath)
+	if err != nil {
+		return GridRepairResult{}, err
+	}
+	slog.Info("Repaired hero grid file", "path", gridPath, "backup", result.BackupPath, "configs", result.Configs, "changes", len(result.Changes))
+	return result, nil
+}
+
+func (s *HeroesLayoutServiceImpl) knownHero(heroID int) bool {
+	_, ok := s.heroRegistry.Get(heroID)
+	return ok
+}
+
+// GetGridConfigNames returns the names of the configs of a hero grid file
+func (s *HeroesLayoutServiceImpl) GetGridConfigNames(gridPath string) ([]string, error) {
+	return gridConfigNames(gridPath)
+}
+
+// SyncGridMirror copies the config of a mirror from its source account into every other enabled account.
+// Targets edited since the last sync are reported as conflicts and only overwritten when asked to.
+func (s *HeroesLayoutServiceImpl) SyncGridMirror(id string, overwriteConflicts bool) ([]MirrorTargetResult, error) {
+	mirror, ok := s.config.GetGridMirror(id)
+	if !ok {
+		return nil, fmt.Errorf("grid mirror %q not found", id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.syncGridMirror(mirror, overwriteConflicts)
+}
+
+// SyncAutoGridMirrors syncs the mirrors with auto sync enabled, never overwriting conflicts
+func (s *HeroesLayoutServiceImpl) SyncAutoGridMirrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, mirror := range s.config.GetGridMirrors() {
+		if !mirror.AutoSync {
+			continue
+		}
+		if _, err := s.syncGridMirror(mirror, false); err != nil {
+			slog.Warn("Error syncing grid mirror", "mirror", mirror.ID, "error", err)
+		}
+	}
+}
+
+func (s *HeroesLayoutServiceImpl) syncGridMirror(mirror config.GridMirror, overwriteConflicts bool) ([]MirrorTargetResult, error) {
+	now := time.Now()
+	syncedHashes := maps.Clone(mirror.SyncedHashes)
+	if syncedHashes == nil {
+		syncedHashes = make(map[string]string)
+	}
+
+	source, err := s.mirrorSource(mirror)
+	if err != nil {
+		s.config.UpdateGridMirrorSync(mirror.ID, now.UnixMilli(), syncedHashes, mirror.LastSyncConflicts, err.Error())
+		return nil, err
+	}
+	sourceHash := hashGridConfig(source)
+
+	targetPaths := s.steamService.GetEnabledAccountPaths() // map[steamId64]path
+	delete(targetPaths, mirror.SourceSteamID64)
+
+	var results []MirrorTargetResult
+	var conflicts []string
+	var errs []error
+	for _, steamId64 := range slices.Sorted(maps.Keys(targetPaths)) {
+		path := targetPaths[steamId64]
+		status, err := mirrorGridConfig(path, source, syncedHashes[steamId64], overwriteConflicts)
+		result := MirrorTargetResult{SteamID64: steamId64, FilePath: path, Status: status}
+
+		switch {
+		case err != nil:
+			slog.Error("Error mirroring hero grid", "mirror", mirror.ID, "path", path, "error", err)
+			result.Error = err.Error()
+			errs = append(errs, fmt.Errorf("account %s: %w", steamId64, err))
+		case status == MirrorStatusConflict:
+			slog.Info("Mirrored hero grid was changed in the target, skipping it", "mirror", mirror.ID, "path", path)
+			conflicts = append(conflicts, steamId64)
+		default:
+			syncedHashes[steamId64] = sourceHash
+		}
+		results = append(results, result)
+	}
+
+	errorMsg := ""
+	if len(errs) > 0 {
+		errorMsg = errors.Join(errs...).Error()
+	}
+	s.config.UpdateGridMirrorSync(mirror.ID, now.UnixMilli(), syncedHashes, conflicts, errorMsg)
+
+	slog.Info("Synced grid mirror", "mirror", mirror.ID, "config", mirror.ConfigName, "targets", len(results), "conflicts", len(conflicts))
+	return results, nil
+}
+
+// mirrorSource reads the config a mirror copies from its source account
+func (s *HeroesLayoutServiceImpl) mirrorSource(mirror config.GridMirror) (heroGridCategory, error) {
+	sourcePath, ok := s.steamService.GetAccountGridPath(mirror.SourceSteamID64)
+	if !ok {
+		return heroGridCategory{}, fmt.Errorf("hero grid config of source account %s not found", mirror.SourceSteamID64)
+	}
+	source, err := mirrorSourceConfig(sourcePath, mirror.ConfigName)
+	if err != nil {
+		return heroGridCategory{}, fmt.Errorf("error reading source grid: %w", err)
+	}
+	return source, nil
+}
@@ -0,0 +1,156 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/providers"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxTrendDays matches the longest history retention
+const maxTrendDays = 365
+
+// placeholderRegex matches "{name}" placeholders in titles and label formats
+var placeholderRegex = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// titlePlaceholders are the placeholders understood in section titles; matchup sections add {hero}
+var (
+	titlePlaceholders        = []string{"position"}
+	matchupTitlePlaceholders = []string{"position", "hero"}
+)
+
+var knownMatchups = []string{config.MatchupNone, config.MatchupCounters, config.MatchupPartners}
+
+var knownGroupings = []string{config.GroupByNone, config.GroupByAttribute, config.GroupByAttackType}
+
+var knownFacetModes = []string{config.FacetsMerge, config.FacetsBest, config.FacetsSplit}
+
+var knownAutoFitModes = []string{config.AutoFitNone, config.AutoFitHeroesPerRow, config.AutoFitScale}
+
+// ValidateLayoutTemplate checks that a template can be rendered by the generator
+func ValidateLayoutTemplate(template config.LayoutTemplate) error {
+	var errs []error
+
+	if strings.TrimSpace(template.ID) == "" {
+		errs = append(errs, fmt.Errorf("template id is required"))
+	}
+	if strings.TrimSpace(template.Name) == "" {
+		errs = append(errs, fmt.Errorf("template name is required"))
+	}
+
+	geometry := template.Geometry
+	if geometry.HeroWidth <= 0 || geometry.HeroHeight <= 0 {
+		errs = append(errs, fmt.Errorf("hero width and height must be positive"))
+	}
+	if geometry.HeaderWidth < 0 || geometry.InfoHeight < 0 || geometry.LabelSpacing < 0 ||
+		geometry.RowSpacing < 0 || geometry.CategorySpacing < 0 || geometry.ColumnSpacing < 0 {
+		errs = append(errs, fmt.Errorf("geometry spacings must not be negative"))
+	}
+
+	if !slices.Contains(knownAutoFitModes, template.AutoFit) {
+		errs = append(errs, fmt.Errorf("unknown auto-fit mode %q (expected %q, %q or none)", template.AutoFit, config.AutoFitHeroesPerRow, config.AutoFitScale))
+	}
+
+	if len(template.Sections) == 0 {
+		errs = append(errs, fmt.Errorf("template must have at least one section"))
+	}
+
+	for i, section := range template.Sections {
+		if strings.TrimSpace(section.Title) == "" {
+			errs = append(errs, fmt.Errorf("section %d: title is required", i+1))
+		}
+		placeholders := titlePlaceholders
+		if section.Matchup != config.MatchupNone {
+			placeholders = matchupTitlePlaceholders
+		}
+		if err := validatePlaceholders(section.Title, placeholders); err != nil {
+			errs = append(errs, fmt.Errorf("section %d: title: %w", i+1, err))
+		}
+		errs = append(errs, validateMatchupSection(i+1, section)...)
+		if _, ok := providers.GetRanking(section.SortBy); !ok && section.SortBy != config.SortByRatingChange {
+			errs = append(errs, fmt.Errorf("section %d: unknown sort key %q (expected one of %s)", i+1, section.SortBy, strings.Join(sortKeys(), ", ")))
+		}
+		if section.Count <= 0 {
+			errs = append(errs, fmt.Errorf("section %d: count must be positive", i+1))
+		}
+		if section.Filters.MinMatches < 0 {
+			errs = append(errs, fmt.Errorf("section %d: minimum matches must not be negative", i+1))
+		}
+		if section.Filters.MinWinrate < 0 || section.Filters.MinWinrate > 100 {
+			errs = append(errs, fmt.Errorf("section %d: minimum winrate must be between 0 and 100", i+1))
+		}
+		if !slices.Contains(knownGroupings, section.GroupBy) {
+			errs = append(errs, fmt.Errorf("section %d: unknown grouping %q (expected %q, %q or none)", i+1, section.GroupBy, config.GroupByAttribute, config.GroupByAttackType))
+		}
+		if !slices.Contains(knownFacetModes, section.Facets) {
+			errs = append(errs, fmt.Errorf("section %d: unknown facets mode %q (expected %q, %q or none)", i+1, section.Facets, config.FacetsBest, config.FacetsSplit))
+		} else if section.Facets != config.FacetsMerge && section.SortBy == config.SortByRatingChange {
+			// The history only records merged heroes
+			errs = append(errs, fmt.Errorf("section %d: sorting by %s needs merged facets", i+1, config.SortByRatingChange))
+		}
+		if section.ColumnHeroesPerRow < 0 {
+			errs = append(errs, fmt.Errorf("section %d: column heroes per row must not be negative", i+1))
+		}
+		if section.TrendDays < 0 || section.TrendDays > maxTrendDays {
+			errs = append(errs, fmt.Errorf("section %d: trend days must be between 0 and %d", i+1, maxTrendDays))
+		}
+		if len(section.Labels) == 0 {
+			errs = append(errs, fmt.Errorf("section %d: at least one label is required", i+1))
+		}
+		for j, label := range section.Labels {
+			if err := validatePlaceholders(label.Format, labelPlaceholders); err != nil {
+				errs = append(errs, fmt.Errorf("section %d: label %d: %w", i+1, j+1, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateMatchupSection checks the matchup settings of a section
+func validateMatchupSection(number int, section config.LayoutSection) []error {
+	if !slices.Contains(knownMatchups, section.Matchup) {
+		return []error{fmt.Errorf("section %d: unknown matchup %q (expected %q, %q or none)", number, section.Matchup, config.MatchupCounters, config.MatchupPartners)}
+	}
+	if section.Matchup == config.MatchupNone {
+		return nil
+	}
+
+	var errs []error
+	if len(section.MatchupHeroes) == 0 {
+		errs = append(errs, fmt.Errorf("section %d: matchup sections need at least one matchup hero", number))
+	}
+	if slices.ContainsFunc(section.MatchupHeroes, func(heroID int) bool { return heroID <= 0 }) {
+		errs = append(errs, fmt.Errorf("section %d: matchup hero IDs must be positive", number))
+	}
+	// Matchup records have neither history nor facets
+	if section.SortBy == config.SortByRatingChange {
+		errs = append(errs, fmt.Errorf("section %d: matchup sections can't be sorted by %s", number, config.SortByRatingChange))
+	}
+	if section.Facets != config.FacetsMerge {
+		errs = append(errs, fmt.Errorf("section %d: matchup sections don't split facets", number))
+	}
+	return errs
+}
+
+// validatePlaceholders reports the first placeholder of text that isn't in known
+func validatePlaceholders(text string, known []string) error {
+	for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(known, match[1]) {
+			return fmt.Errorf("unknown placeholder %q (expected one of {%s})", match[0], strings.Join(known, "}, {"))
+		}
+	}
+	return nil
+}
+
+// sortKeys returns the registered rankings and the generator's own sort keys
+func sortKeys() []string {
+	var ids []string
+	for _, ranking := range providers.GetRankings() {
+		ids = append(ids, ranking.ID)
+	}
+	return append(ids, config.SortByRatingChange)
+}
@@ -0,0 +1,251 @@
+package heroesLayout
+
+import (
+	"bytes"
+	"d2tool/config"
+	"d2tool/providers"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// goldenFixture returns two positions with 13 heroes each; ratings and match
+// counts are unique so the sort order is deterministic
+func goldenFixture() ([]string, map[string][]providers.Hero) {
+	positions := []string{"pos 1", "pos 2"}
+	positionToHeroes := map[string][]providers.Hero{}
+	for p, position := range positions {
+		var heroes []providers.Hero
+		for i := 0; i < 13; i++ {
+			heroes = append(heroes, providers.Hero{
+				HeroID:     p*100 + i + 1,
+				Matches:    100 + i*37%13*10 + p,
+				Wins:       50 + i*3,
+				D2PTRating: 500 - (i*7%13)*11 - p,
+			})
+		}
+		positionToHeroes[position] = heroes
+	}
+	return positions, positionToHeroes
+}
+
+func assertGolden(t *testing.T, name string, categories []heroGridPosition) {
+	t.Helper()
+
+	actual, err := json.MarshalIndent(categories, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal categories: %v", err)
+	}
+	actual = append(actual, '\n')
+
+	goldenPath := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, actual, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("generated categories differ from %s (run with -update to accept)", goldenPath)
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_DefaultTemplateGolden(t *testing.T) {
+	positions, positionToHeroes := goldenFixture()
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions, positionToHeroes: positionToHeroes, heroesPerRow: 4}, config.DefaultLayoutTemplate())
+
+	assertGolden(t, "default_template.golden.json", configs[0].Categories)
+}
+
+func TestGenerateHeroesLayoutConfigs_CustomTemplate(t *testing.T) {
+	positions, positionToHeroes := goldenFixture()
+
+	template := config.DefaultLayoutTemplate()
+	template.Sections = []config.LayoutSection{
+		{
+			Title:   "{position} - Reliable",
+			SortBy:  config.SortByRating,
+			Count:   3,
+			Filters: config.SectionFilters{MinMatches: 150},
+			Labels:  []config.LabelFormat{{Header: "Rating", Format: "{rating} ({matches})"}},
+		},
+	}
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions[:1], positionToHeroes: positionToHeroes, heroesPerRow: 10}, template)
+
+	var heroCategories []heroGridPosition
+	for _, cat := range configs[0].Categories {
+		if len(cat.HeroIDs) > 0 {
+			heroCategories = append(heroCategories, cat)
+		}
+	}
+
+	if len(heroCategories) != 3 {
+		t.Fatalf("expected 3 heroes, got %d", len(heroCategories))
+	}
+	for _, cat := range heroCategories {
+		for _, hero := range positionToHeroes["pos 1"] {
+			if hero.HeroID == cat.HeroIDs[0] && hero.Matches < 150 {
+				t.Errorf("hero %d has %d matches, below the section minimum", hero.HeroID, hero.Matches)
+			}
+		}
+		if !strings.Contains(cat.CategoryName, "(") {
+			t.Errorf("expected single label with rating and matches, got %q", cat.CategoryName)
+		}
+	}
+	if configs[0].Categories[0].CategoryName != "pos 1 - Reliable" {
+		t.Errorf("expected section title with position, got %q", configs[0].Categories[0].CategoryName)
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_RankingSortKey(t *testing.T) {
+	positionToHeroes := map[string][]providers.Hero{
+		"1": {
+			{HeroID: 1, Matches: 3, Wins: 3, D2PTRating: 900},
+			{HeroID: 2, Matches: 800, Wins: 440, D2PTRating: 100},
+		},
+	}
+
+	template := config.DefaultLayoutTemplate()
+	template.Sections = []config.LayoutSection{
+		{Title: "{position}", SortBy: providers.RankingWilson, Count: 1, Labels: []config.LabelFormat{{}}},
+	}
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: []string{"1"}, positionToHeroes: positionToHeroes, heroesPerRow: 10}, template)
+
+	heroCategory := configs[0].Categories[len(configs[0].Categories)-1]
+	if len(heroCategory.HeroIDs) != 1 || heroCategory.HeroIDs[0] != 2 {
+		t.Errorf("expected hero 2 ranked first by wilson lower bound, got %v", heroCategory.HeroIDs)
+	}
+}
+
+func TestValidateLayoutTemplate(t *testing.T) {
+	if err := ValidateLayoutTemplate(config.DefaultLayoutTemplate()); err != nil {
+		t.Errorf("expected built-in template to be valid, got %v", err)
+	}
+
+	for _, ranking := range providers.GetRankings() {
+		template := config.DefaultLayoutTemplate()
+		template.Sections[0].SortBy = ranking.ID
+		if err := ValidateLayoutTemplate(template); err != nil {
+			t.Errorf("expected sort key %q to be valid, got %v", ranking.ID, err)
+		}
+	}
+
+	invalid := config.DefaultLayoutTemplate()
+	invalid.Name = ""
+	invalid.Geometry.HeroWidth = 0
+	invalid.AutoFit = "shrink"
+	invalid.Sections[0].SortBy = "popularity"
+	invalid.Sections[0].Title = "{position} {period}"
+	invalid.Sections[0].Labels[0].Format = "{winrate} {pickrate}"
+	invalid.Sections[0].Facets = "each"
+	invalid.Sections[1].Count = 0
+	invalid.Sections[1].Labels = nil
+	invalid.Sections[1].SortBy = config.SortByRatingChange
+	invalid.Sections[1].Facets = config.FacetsSplit
+
+	err := ValidateLayoutTemplate(invalid)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, expected := range []string{"name", "hero width", "popularity", "count", "label", "{period}", "{pickrate}", "shrink", "each", "needs merged facets"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to mention %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_RankAndHiddenRowHeaders(t *testing.T) {
+	positions, positionToHeroes := goldenFixture()
+
+	template := config.DefaultLayoutTemplate()
+	template.HideRowHeaders = true
+	template.Sections = []config.LayoutSection{
+		{Title: "{position}", SortBy: config.SortByRating, Count: 3, Labels: []config.LabelFormat{{Header: "Rank", Format: "#{rank}"}}},
+	}
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: positions[:1], positionToHeroes: positionToHeroes, heroesPerRow: 2}, template)
+
+	var names []string
+	for _, cat := range configs[0].Categories {
+		if cat.CategoryName == "Rank" {
+			t.Errorf("expected row headers to be hidden, got %+v", cat)
+		}
+		if len(cat.HeroIDs) > 0 {
+			names = append(names, cat.CategoryName)
+		}
+	}
+	if strings.Join(names, ",") != "#1,#2,#3" {
+		t.Errorf("expected ranks #1,#2,#3, got %v", names)
+	}
+
+	firstHero := configs[0].Categories[1]
+	if firstHero.XPosition != 0 {
+		t.Errorf("expected first hero at the left edge without row headers, got x=%v", firstHero.XPosition)
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_Facets(t *testing.T) {
+	merged := providers.AggregateHeroesByID([]providers.Hero{
+		{HeroID: 1, Facet: 1, Matches: 100, Wins: 45, D2PTRating: 300},
+		{HeroID: 1, Facet: 2, Matches: 50, Wins: 27, D2PTRating: 600},
+		{HeroID: 2, Matches: 200, Wins: 100, D2PTRating: 500},
+	})
+	positionToHeroes := map[string][]providers.Hero{"1": merged}
+	labels := []config.LabelFormat{{Format: "{facet} {facetWinrate}"}}
+
+	tests := []struct {
+		name         string
+		facets       string
+		expectLabels []string // labels of the hero cards in order
+		expectHeroes []int
+	}{
+		{name: "merge", facets: config.FacetsMerge, expectLabels: []string{" ", "F2 54.0%"}, expectHeroes: []int{2, 1}},
+		{name: "best", facets: config.FacetsBest, expectLabels: []string{"F2 54.0%", " "}, expectHeroes: []int{1, 2}},
+		{name: "split", facets: config.FacetsSplit, expectLabels: []string{"F2 54.0%", " ", "F1 45.0%"}, expectHeroes: []int{1, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := config.DefaultLayoutTemplate()
+			template.Sections = []config.LayoutSection{
+				{Title: "{position}", SortBy: config.SortByRating, Count: 5, Labels: labels, Facets: tt.facets},
+			}
+
+			configs := generateHeroesLayoutConfigs(d2tPrefix, layoutData{positions: []string{"1"}, positionToHeroes: positionToHeroes, heroesPerRow: 10}, template)
+
+			var names []string
+			var heroIDs []int
+			for _, cat := range configs[0].Categories {
+				if len(cat.HeroIDs) > 0 {
+					names = append(names, cat.CategoryName)
+					heroIDs = append(heroIDs, cat.HeroIDs...)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectLabels, ",") {
+				t.Errorf("expected labels %q, got %q", tt.expectLabels, names)
+			}
+			if len(heroIDs) != len(tt.expectHeroes) {
+				t.Fatalf("expected heroes %v, got %v", tt.expectHeroes, heroIDs)
+			}
+			for i := range heroIDs {
+				if heroIDs[i] != tt.expectHeroes[i] {
+					t.Errorf("expected heroes %v, got %v", tt.expectHeroes, heroIDs)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/providers"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// matchupKey identifies the matchups of one hero fetched for an update
+type matchupKey struct {
+	kind   string
+	heroID int
+}
+
+// sectionInstance is a section as generated for one position. Counter sections produce one
+// instance per faced hero; every other section produces one.
+type sectionInstance struct {
+	title   string
+	heroes  []providers.Hero
+	pinned  []int
+	matchup bool // the stats are matchup records, which have no history for trends
+}
+
+// sectionInstances returns the instances of a section for a position, with the heroes they choose from
+func sectionInstances(position string, section config.LayoutSection, positionHeroes []providers.Hero, pinned []int, layout layoutData) []sectionInstance {
+	title := strings.ReplaceAll(section.Title, "{position}", position)
+
+	switch section.Matchup {
+	case config.MatchupCounters:
+		instances := make([]sectionInstance, 0, len(section.MatchupHeroes))
+		for _, heroID := range section.MatchupHeroes {
+			instances = append(instances, sectionInstance{
+				title:   strings.ReplaceAll(title, "{hero}", layout.heroName(heroID)),
+				heroes:  matchupHeroes(positionHeroes, []int{heroID}, layout.matchups, providers.MatchupVersus),
+				matchup: true,
+			})
+		}
+		return instances
+
+	case config.MatchupPartners:
+		names := make([]string, 0, len(section.MatchupHeroes))
+		for _, heroID := range section.MatchupHeroes {
+			names = append(names, layout.heroName(heroID))
+		}
+		return []sectionInstance{{
+			title:   strings.ReplaceAll(title, "{hero}", strings.Join(names, ", ")),
+			heroes:  matchupHeroes(positionHeroes, section.MatchupHeroes, layout.matchups, providers.MatchupWith),
+			matchup: true,
+		}}
+
+	default:
+		return []sectionInstance{{title: title, heroes: facetSectionHeroes(positionHeroes, section.Facets), pinned: pinned}}
+	}
+}
+
+// matchupHeroes returns the heroes of a position with their matches and wins against or alongside the
+// reference heroes, summed over all of them. The rating stays the hero's rating for the position.
+// Reference heroes and heroes without a record are left out.
+func matchupHeroes(positionHeroes []providers.Hero, refs []int, matchups map[matchupKey][]providers.Matchup, kind string) []providers.Hero {
+	records := make(map[int]providers.Matchup)
+	for _, ref := range refs {
+		for _, matchup := range matchups[matchupKey{kind: kind, heroID: ref}] {
+			record := records[matchup.HeroID]
+			record.Matches += matchup.Matches
+			record.Wins += matchup.Wins
+			records[matchup.HeroID] = record
+		}
+	}
+
+	var result []providers.Hero
+	for _, hero := range positionHeroes {
+		record, ok := records[hero.HeroID]
+		if !ok || record.Matches == 0 || slices.Contains(refs, hero.HeroID) {
+			continue
+		}
+		result = append(result, providers.Hero{
+			HeroID:     hero.HeroID,
+			HeroName:   hero.HeroName,
+			Matches:    record.Matches,
+			Wins:       record.Wins,
+			D2PTRating: hero.D2PTRating,
+		})
+	}
+	return result
+}
+
+// templateMatchupKeys returns the matchups the sections of a template need
+func templateMatchupKeys(template config.LayoutTemplate) []matchupKey {
+	var keys []matchupKey
+	for _, section := range template.Sections {
+		kind := ""
+		switch section.Matchup {
+		case config.MatchupCounters:
+			kind = providers.MatchupVersus
+		case config.MatchupPartners:
+			kind = providers.MatchupWith
+		default:
+			continue
+		}
+		for _, heroID := range section.MatchupHeroes {
+			if key := (matchupKey{kind: kind, heroID: heroID}); !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// heroName returns the name of a hero for section titles
+func (l layoutData) heroName(heroID int) string {
+	if l.heroRegistry != nil {
+		if name := l.heroRegistry.Name(heroID); name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("Hero #%d", heroID)
+}
@@ -0,0 +1,115 @@
+package heroesLayout
+
+import (
+	"d2tool/config"
+	"d2tool/heroes"
+	"d2tool/providers"
+	"strings"
+	"testing"
+)
+
+// matchupTestLayout has heroes 1 to 5 in position "1" and matchups of the stub provider for
+// the sections of template
+func matchupTestLayout(t *testing.T, template config.LayoutTemplate) layoutData {
+	t.Helper()
+
+	provider := providers.NewStaticMatchupsProvider(
+		map[int][]providers.Matchup{
+			// Hero 2 wins most against hero 1, hero 3 has too few games to count
+			1: {{HeroID: 2, Matches: 100, Wins: 60}, {HeroID: 3, Matches: 5, Wins: 5}, {HeroID: 4, Matches: 100, Wins: 45}},
+		},
+		map[int][]providers.Matchup{
+			1: {{HeroID: 4, Matches: 100, Wins: 55}, {HeroID: 5, Matches: 100, Wins: 50}},
+			2: {{HeroID: 4, Matches: 100, Wins: 45}, {HeroID: 5, Matches: 100, Wins: 58}},
+		},
+	)
+
+	matchups := make(map[matchupKey][]providers.Matchup)
+	for _, key := range templateMatchupKeys(template) {
+		fetched, err := provider.FetchMatchups(key.heroID, key.kind)
+		if err != nil {
+			t.Fatalf("FetchMatchups failed: %v", err)
+		}
+		matchups[key] = fetched
+	}
+
+	var positionHeroes []providers.Hero
+	for id := 1; id <= 5; id++ {
+		positionHeroes = append(positionHeroes, providers.Hero{HeroID: id, Matches: 1000, Wins: 500, D2PTRating: 100 * id})
+	}
+	return layoutData{
+		positions:        []string{"1"},
+		positionToHeroes: map[string][]providers.Hero{"1": positionHeroes},
+		heroesPerRow:     10,
+		heroRegistry:     heroes.Default(),
+		matchups:         matchups,
+	}
+}
+
+func TestGenerateHeroesLayoutConfigs_MatchupSections(t *testing.T) {
+	template := config.DefaultLayoutTemplate()
+	template.Sections = []config.LayoutSection{
+		{
+			Title: "Counters to {hero}", SortBy: providers.RankingWinrate, Count: 5,
+			Filters: config.SectionFilters{MinMatches: 10}, Labels: []config.LabelFormat{{Format: "{winrate}"}},
+			Matchup: config.MatchupCounters, MatchupHeroes: []int{1},
+		},
+		{
+			Title: "Partners for {hero}", SortBy: providers.RankingWinrate, Count: 5,
+			Labels: []config.LabelFormat{{Format: "{winrate}"}}, Matchup: config.MatchupPartners, MatchupHeroes: []int{1, 2},
+		},
+	}
+	if err := ValidateLayoutTemplate(template); err != nil {
+		t.Fatalf("expected a valid template, got %v", err)
+	}
+
+	configs := generateHeroesLayoutConfigs(d2tPrefix, matchupTestLayout(t, template), template)
+
+	var titles, labels []string
+	var heroIDs []int
+	for _, cat := range configs[0].Categories {
+		if len(cat.HeroIDs) == 0 {
+			titles = append(titles, cat.CategoryName)
+			continue
+		}
+		labels = append(labels, cat.CategoryName)
+		heroIDs = append(heroIDs, cat.HeroIDs...)
+	}
+
+	if !strings.Contains(strings.Join(titles, "|"), "Counters to Anti-Mage|") || !strings.Contains(strings.Join(titles, "|"), "Partners for Anti-Mage, Axe") {
+		t.Errorf("expected section titles with hero names, got %q", titles)
+	}
+	// Counters: hero 2 (60%) before hero 4 (45%), hero 3 filtered out; partners: hero 5 (54%) before hero 4 (50%)
+	expectedHeroes := []int{2, 4, 5, 4}
+	if len(heroIDs) != len(expectedHeroes) {
+		t.Fatalf("expected heroes %v, got %v", expectedHeroes, heroIDs)
+	}
+	for i := range heroIDs {
+		if heroIDs[i] != expectedHeroes[i] {
+			t.Fatalf("expected heroes %v, got %v", expectedHeroes, heroIDs)
+		}
+	}
+	if strings.Join(labels, ",") != "60.0%,45.0%,54.0%,50.0%" {
+		t.Errorf("expected matchup winrates in labels, got %v", labels)
+	}
+}
+
+func TestValidateLayoutTemplate_MatchupSections(t *testing.T) {
+	template := config.DefaultLayoutTemplate()
+	template.Sections[0].Matchup = config.MatchupCounters
+	template.Sections[0].SortBy = config.SortByRatingChange
+	template.Sections[0].Facets = config.FacetsSplit
+	template.Sections[1].Title = "With {hero}"
+	template.Sections = append(template.Sections, template.Sections[1])
+	template.Sections[2].Matchup = "enemies"
+
+	err := ValidateLayoutTemplate(template)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, expected := range []string{"at least one matchup hero", "can't be sorted", "don't split facets", "enemies", "{hero}"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to mention %q, got %v", expected, err)
+		}
+	}
+}
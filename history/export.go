@@ -0,0 +1,82 @@
+package history
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+var csvHeader = []string{"date", "provider", "period", "position", "hero_id", "hero_name", "matches", "wins", "winrate", "rating"}
+
+// Export writes the snapshots in the given format. CSV has one row per day, position and hero;
+// JSON is the list of snapshots as stored. heroName resolves the names written to CSV.
+func Export(w io.Writer, format string, snapshots []Snapshot, heroName func(heroID int) string) error {
+	switch format {
+	case ExportFormatCSV:
+		return exportCSV(w, snapshots, heroName)
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if snapshots == nil {
+			snapshots = []Snapshot{}
+		}
+		if err := encoder.Encode(snapshots); err != nil {
+			return fmt.Errorf("error encoding history: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown export format %q (expected %q or %q)", format, ExportFormatCSV, ExportFormatJSON)
+	}
+}
+
+func exportCSV(w io.Writer, snapshots []Snapshot, heroName func(heroID int) string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+
+	for _, snapshot := range snapshots {
+		// Positions are written in a stable order
+		positions := make([]string, 0, len(snapshot.Positions))
+		for position := range snapshot.Positions {
+			positions = append(positions, position)
+		}
+		slices.SortFunc(positions, cmp.Compare)
+
+		for _, position := range positions {
+			for _, stats := range snapshot.Positions[position] {
+				record := []string{
+					snapshot.Date,
+					snapshot.Provider,
+					snapshot.Period,
+					position,
+					strconv.Itoa(stats.HeroID),
+					heroName(stats.HeroID),
+					strconv.Itoa(stats.Matches),
+					strconv.Itoa(stats.Wins),
+					strconv.FormatFloat(stats.Winrate(), 'f', 2, 64),
+					strconv.Itoa(stats.Rating),
+				}
+				if err := writer.Write(record); err != nil {
+					return fmt.Errorf("error writing history: %w", err)
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	return nil
+}
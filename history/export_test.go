@@ -0,0 +1,60 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExport_CSV(t *testing.T) {
+	snapshots := []Snapshot{testSnapshot("2024-05-01", 100)}
+	snapshots[0].Positions["pos 2"] = []HeroStats{{HeroID: 3, Matches: 0, Wins: 0, Rating: 10}}
+
+	var buf bytes.Buffer
+	err := Export(&buf, ExportFormatCSV, snapshots, func(heroID int) string {
+		return map[int]string{1: "Anti-Mage", 2: "Axe, the Red Mist"}[heroID]
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"date,provider,period,position,hero_id,hero_name,matches,wins,winrate,rating",
+		"2024-05-01,d2pt,8,pos 1,2,\"Axe, the Red Mist\",50,20,40.00,101",
+		"2024-05-01,d2pt,8,pos 1,1,Anti-Mage,100,55,55.00,100",
+		"2024-05-01,d2pt,8,pos 2,3,,0,0,0.00,10",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestExport_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Export(&buf, ExportFormatJSON, []Snapshot{testSnapshot("2024-05-01", 100)}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded []Snapshot
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("export is not valid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Date != "2024-05-01" || len(decoded[0].Positions["pos 1"]) != 2 {
+		t.Errorf("unexpected decoded export: %+v", decoded)
+	}
+
+	// An empty history is an empty list, not null
+	buf.Reset()
+	Export(&buf, ExportFormatJSON, nil, nil)
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("expected empty list, got %q", buf.String())
+	}
+}
+
+func TestExport_UnknownFormat(t *testing.T) {
+	if err := Export(&bytes.Buffer{}, "xml", nil, nil); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
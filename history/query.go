@@ -0,0 +1,119 @@
+package history
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SeriesPoint is the statistics of one hero on one day
+type SeriesPoint struct {
+	Date    string  `json:"date"`
+	Matches int     `json:"matches"`
+	Wins    int     `json:"wins"`
+	Winrate float64 `json:"winrate"` // percent
+	Rating  int     `json:"rating"`
+}
+
+// Source is a provider and period with recorded snapshots
+type Source struct {
+	Provider string `json:"provider"`
+	Period   string `json:"period"`
+}
+
+// Sources returns every provider and period with at least one snapshot, ordered by provider and period
+func (s *Store) Sources() ([]Source, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []Source{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history directory: %w", err)
+	}
+
+	sources := []Source{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, snapshotFileExt) {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(name, snapshotFileExt), "_")
+		if len(parts) != 3 || validateKey(parts[0], parts[1]) != nil {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, parts[2]); err != nil {
+			continue
+		}
+		source := Source{Provider: parts[0], Period: parts[1]}
+		if !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+
+	slices.SortFunc(sources, func(a, b Source) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.Period, b.Period))
+	})
+	return sources, nil
+}
+
+// Range returns the snapshots of the provider and period dated between from and to, inclusive,
+// oldest first. An empty bound leaves that side of the range open.
+func (s *Store) Range(provider string, period string, from string, to string) ([]Snapshot, error) {
+	dates, err := s.Dates(provider, period)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, date := range dates {
+		if (from != "" && date < from) || (to != "" && date > to) {
+			continue
+		}
+		snapshot, ok, err := s.Load(provider, period, date)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// HeroSeries extracts the statistics of a hero in a position from the snapshots.
+// Days on which the hero wasn't played in the position are left out.
+func HeroSeries(snapshots []Snapshot, position string, heroID int) []SeriesPoint {
+	var points []SeriesPoint
+	for _, snapshot := range snapshots {
+		idx := slices.IndexFunc(snapshot.Positions[position], func(stats HeroStats) bool {
+			return stats.HeroID == heroID
+		})
+		if idx < 0 {
+			continue
+		}
+
+		stats := snapshot.Positions[position][idx]
+		points = append(points, SeriesPoint{
+			Date:    snapshot.Date,
+			Matches: stats.Matches,
+			Wins:    stats.Wins,
+			Winrate: stats.Winrate(),
+			Rating:  stats.Rating,
+		})
+	}
+	return points
+}
+
+// Winrate returns the winrate in percent
+func (h HeroStats) Winrate() float64 {
+	if h.Matches <= 0 {
+		return 0
+	}
+	return float64(h.Wins) / float64(h.Matches) * 100
+}
@@ -0,0 +1,97 @@
+package history
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestStore_Sources(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	if sources, err := store.Sources(); err != nil || len(sources) != 0 {
+		t.Fatalf("expected no sources for an empty history, got %v, %v", sources, err)
+	}
+
+	for _, snapshot := range []Snapshot{
+		testSnapshot("2024-05-01", 100),
+		testSnapshot("2024-05-02", 100),
+		{Provider: "file", Period: "8", Date: "2024-05-01"},
+		{Provider: "d2pt", Period: "patch", Date: "2024-05-01"},
+	} {
+		if err := store.Save(snapshot); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	os.WriteFile(filepath.Join(dir, "notes.json"), []byte("{}"), 0644)
+
+	sources, err := store.Sources()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Source{{"d2pt", "8"}, {"d2pt", "patch"}, {"file", "8"}}
+	if !slices.Equal(sources, expected) {
+		t.Errorf("expected %v, got %v", expected, sources)
+	}
+}
+
+func TestStore_Range(t *testing.T) {
+	store := NewStore(t.TempDir())
+	for _, date := range []string{"2024-05-01", "2024-05-02", "2024-05-03", "2024-05-04"} {
+		store.Save(testSnapshot(date, 100))
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{"open range", "", "", []string{"2024-05-01", "2024-05-02", "2024-05-03", "2024-05-04"}},
+		{"from only", "2024-05-03", "", []string{"2024-05-03", "2024-05-04"}},
+		{"closed range", "2024-05-02", "2024-05-03", []string{"2024-05-02", "2024-05-03"}},
+		{"empty range", "2024-06-01", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshots, err := store.Range("d2pt", "8", tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(snapshots) != len(tt.want) {
+				t.Fatalf("expected %d snapshots, got %d", len(tt.want), len(snapshots))
+			}
+			for i, snapshot := range snapshots {
+				if snapshot.Date != tt.want[i] {
+					t.Errorf("snapshot %d: expected %s, got %s", i, tt.want[i], snapshot.Date)
+				}
+			}
+		})
+	}
+}
+
+func TestHeroSeries(t *testing.T) {
+	first := testSnapshot("2024-05-01", 100)
+	second := testSnapshot("2024-05-02", 110)
+	second.Positions["pos 1"] = second.Positions["pos 1"][:1] // hero 1 not played that day
+	third := testSnapshot("2024-05-03", 120)
+
+	points := HeroSeries([]Snapshot{first, second, third}, "pos 1", 1)
+
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %+v", points)
+	}
+	if points[0].Date != "2024-05-01" || points[0].Rating != 100 || math.Abs(points[0].Winrate-55) > 1e-9 {
+		t.Errorf("unexpected first point %+v", points[0])
+	}
+	if points[1].Date != "2024-05-03" || points[1].Rating != 120 {
+		t.Errorf("unexpected second point %+v", points[1])
+	}
+
+	if points := HeroSeries([]Snapshot{first}, "pos 5", 1); len(points) != 0 {
+		t.Errorf("expected no points for another position, got %+v", points)
+	}
+}
@@ -0,0 +1,279 @@
+package history
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateLayout is the format of snapshot dates
+const DateLayout = "2006-01-02"
+
+// DirName is the default name of the snapshots directory
+const DirName = "history"
+
+const snapshotFileExt = ".json"
+
+// keyPartRegex matches the characters allowed in provider and period names used in file names
+var keyPartRegex = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// HeroStats are the statistics of a hero in one position at snapshot time
+type HeroStats struct {
+	HeroID  int `json:"heroId"`
+	Matches int `json:"matches"`
+	Wins    int `json:"wins"`
+	Rating  int `json:"rating"`
+}
+
+// Snapshot contains the statistics fetched from a provider for one period on one day.
+// Later updates on the same day replace the positions they fetched and keep the others.
+type Snapshot struct {
+	Provider  string                 `json:"provider"`
+	Period    string                 `json:"period"`
+	Date      string                 `json:"date"`
+	TakenAt   time.Time              `json:"takenAt"`
+	Positions map[string][]HeroStats `json:"positions"` // position name -> heroes
+}
+
+// Store keeps snapshots as one JSON file per provider, period and date in a directory
+type Store struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+func snapshotFileName(provider string, period string, date string) string {
+	return fmt.Sprintf("%s_%s_%s%s", provider, period, date, snapshotFileExt)
+}
+
+func validateKey(provider string, period string) error {
+	if !keyPartRegex.MatchString(provider) {
+		return fmt.Errorf("invalid provider name %q", provider)
+	}
+	if !keyPartRegex.MatchString(period) {
+		return fmt.Errorf("invalid period %q", period)
+	}
+	return nil
+}
+
+// Save writes the snapshot. An existing snapshot for the same provider, period and date is merged:
+// positions in the new snapshot replace its positions, positions the new one lacks are kept, so a
+// later update that fetched fewer positions doesn't lose the day's other statistics.
+func (s *Store) Save(snapshot Snapshot) error {
+	if err := validateKey(snapshot.Provider, snapshot.Period); err != nil {
+		return err
+	}
+	if _, err := time.Parse(DateLayout, snapshot.Date); err != nil {
+		return fmt.Errorf("invalid snapshot date %q: %w", snapshot.Date, err)
+	}
+
+	positions := make(map[string][]HeroStats, len(snapshot.Positions))
+	for position, stats := range snapshot.Positions {
+		positions[position] = sortedHeroStats(stats)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := filepath.Join(s.dir, snapshotFileName(snapshot.Provider, snapshot.Period, snapshot.Date))
+	// A snapshot that can't be read is overwritten rather than blocking today's history
+	if existing, ok, err := readSnapshot(path); err == nil && ok {
+		for position, stats := range existing.Positions {
+			if _, fetched := positions[position]; !fetched {
+				positions[position] = stats
+			}
+		}
+	}
+	snapshot.Positions = positions
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("error marshaling snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("error creating history directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated snapshot
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("error replacing snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Dates returns the dates with a snapshot for the provider and period, oldest first
+func (s *Store) Dates(provider string, period string) ([]string, error) {
+	if err := validateKey(provider, period); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history directory: %w", err)
+	}
+
+	prefix := fmt.Sprintf("%s_%s_", provider, period)
+	var dates []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, snapshotFileExt) {
+			continue
+		}
+		date := strings.TrimSuffix(strings.TrimPrefix(name, prefix), snapshotFileExt)
+		if _, err := time.Parse(DateLayout, date); err == nil {
+			dates = append(dates, date)
+		}
+	}
+
+	slices.Sort(dates)
+	return dates, nil
+}
+
+// Load returns the snapshot for the provider, period and date; ok is false when there is none
+func (s *Store) Load(provider string, period string, date string) (Snapshot, bool, error) {
+	if err := validateKey(provider, period); err != nil {
+		return Snapshot{}, false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return readSnapshot(filepath.Join(s.dir, snapshotFileName(provider, period, date)))
+}
+
+// readSnapshot reads a snapshot file; ok is false when it doesn't exist
+func readSnapshot(path string) (Snapshot, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("error reading snapshot: %w", err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, false, fmt.Errorf("error parsing snapshot %s: %w", filepath.Base(path), err)
+	}
+	return snapshot, true, nil
+}
+
+// LatestOnOrBefore returns the most recent snapshot taken on or before the given date
+func (s *Store) LatestOnOrBefore(provider string, period string, date string) (Snapshot, bool, error) {
+	dates, err := s.Dates(provider, period)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+
+	for i := len(dates) - 1; i >= 0; i-- {
+		if dates[i] <= date {
+			return s.Load(provider, period, dates[i])
+		}
+	}
+	return Snapshot{}, false, nil
+}
+
+// Latest returns the most recent snapshot. Every update saves its statistics into the snapshot of
+// its day, so the latest one holds the statistics of the last update.
+func (s *Store) Latest(provider string, period string) (Snapshot, bool, error) {
+	dates, err := s.Dates(provider, period)
+	if err != nil || len(dates) == 0 {
+		return Snapshot{}, false, err
+	}
+	return s.Load(provider, period, dates[len(dates)-1])
+}
+
+// Prune removes snapshots of every provider and period that are older than retentionDays before now
+func (s *Store) Prune(retentionDays int, now time.Time) error {
+	cutoff := now.AddDate(0, 0, -retentionDays).Format(DateLayout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading history directory: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, snapshotFileExt) {
+			continue
+		}
+		date := strings.TrimSuffix(name[strings.LastIndex(name, "_")+1:], snapshotFileExt)
+		if _, err := time.Parse(DateLayout, date); err != nil || date >= cutoff {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("error removing %d expired snapshots: %w", len(errs), errs[0])
+	}
+	return nil
+}
+
+// HeroStatsByID indexes the heroes of a snapshot position by hero ID
+func (s Snapshot) HeroStatsByID(position string) map[int]HeroStats {
+	result := make(map[int]HeroStats, len(s.Positions[position]))
+	for _, stats := range s.Positions[position] {
+		result[stats.HeroID] = stats
+	}
+	return result
+}
+
+// sortedHeroStats orders heroes by ID so snapshot files are stable
+func sortedHeroStats(stats []HeroStats) []HeroStats {
+	result := slices.Clone(stats)
+	slices.SortFunc(result, func(a, b HeroStats) int {
+		return cmp.Compare(a.HeroID, b.HeroID)
+	})
+	return result
+}
+
+// Baseline returns the snapshot to compare today's statistics with over a window of days:
+// the latest one taken at least days before today, or the oldest one before today when
+// the history is shorter than the window
+func (s *Store) Baseline(provider string, period string, today time.Time, days int) (Snapshot, bool, error) {
+	snapshot, ok, err := s.LatestOnOrBefore(provider, period, today.AddDate(0, 0, -days).Format(DateLayout))
+	if err != nil || ok {
+		return snapshot, ok, err
+	}
+
+	dates, err := s.Dates(provider, period)
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	if len(dates) == 0 || dates[0] >= today.Format(DateLayout) {
+		return Snapshot{}, false, nil
+	}
+	return s.Load(provider, period, dates[0])
+}
@@ -0,0 +1,232 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func testSnapshot(date string, rating int) Snapshot {
+	return Snapshot{
+		Provider: "d2pt",
+		Period:   "8",
+		Date:     date,
+		Positions: map[string][]HeroStats{
+			"pos 1": {
+				{HeroID: 2, Matches: 50, Wins: 20, Rating: rating + 1},
+				{HeroID: 1, Matches: 100, Wins: 55, Rating: rating},
+			},
+		},
+	}
+}
+
+func mustDate(t *testing.T, date string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(DateLayout, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), DirName))
+
+	if err := store.Save(testSnapshot("2024-05-01", 100)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot, ok, err := store.Load("d2pt", "8", "2024-05-01")
+	if err != nil || !ok {
+		t.Fatalf("expected snapshot, got ok=%v err=%v", ok, err)
+	}
+
+	stats := snapshot.Positions["pos 1"]
+	if len(stats) != 2 || stats[0].HeroID != 1 || stats[1].HeroID != 2 {
+		t.Errorf("expected stats sorted by hero id, got %+v", stats)
+	}
+	if byID := snapshot.HeroStatsByID("pos 1"); byID[1].Rating != 100 {
+		t.Errorf("expected rating 100 for hero 1, got %+v", byID[1])
+	}
+
+	if _, ok, err := store.Load("d2pt", "8", "2024-05-02"); ok || err != nil {
+		t.Errorf("expected missing snapshot, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestStore_Save_ReplacesSameDay(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	store.Save(testSnapshot("2024-05-01", 100))
+	store.Save(testSnapshot("2024-05-01", 120))
+
+	snapshot, _, _ := store.Load("d2pt", "8", "2024-05-01")
+	if snapshot.HeroStatsByID("pos 1")[1].Rating != 120 {
+		t.Errorf("expected the later snapshot to replace the earlier one")
+	}
+
+	dates, _ := store.Dates("d2pt", "8")
+	if len(dates) != 1 {
+		t.Errorf("expected 1 snapshot, got %v", dates)
+	}
+}
+
+func TestStore_Save_MergesPositionsSameDay(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	morning := testSnapshot("2024-05-01", 100)
+	morning.Positions["pos 2"] = []HeroStats{{HeroID: 5, Matches: 80, Wins: 40, Rating: 90}}
+	if err := store.Save(morning); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A later update that only fetched pos 1 and pos 3
+	evening := testSnapshot("2024-05-01", 120)
+	evening.Positions["pos 3"] = []HeroStats{{HeroID: 7, Matches: 60, Wins: 33, Rating: 80}}
+	if err := store.Save(evening); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	snapshot, _, err := store.Load("d2pt", "8", "2024-05-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := snapshot.HeroStatsByID("pos 1")[1].Rating; got != 120 {
+		t.Errorf("expected pos 1 to be replaced by the later update, got rating %d", got)
+	}
+	if got := snapshot.HeroStatsByID("pos 2")[5].Rating; got != 90 {
+		t.Errorf("expected pos 2 of the earlier update to be kept, got %+v", snapshot.Positions["pos 2"])
+	}
+	if got := snapshot.HeroStatsByID("pos 3")[7].Rating; got != 80 {
+		t.Errorf("expected pos 3 of the later update, got %+v", snapshot.Positions["pos 3"])
+	}
+}
+
+func TestStore_Save_RejectsInvalidKeys(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	tests := []struct {
+		name     string
+		snapshot Snapshot
+	}{
+		{"path in provider", Snapshot{Provider: "../d2pt", Period: "8", Date: "2024-05-01"}},
+		{"underscore in period", Snapshot{Provider: "d2pt", Period: "last_8", Date: "2024-05-01"}},
+		{"invalid date", Snapshot{Provider: "d2pt", Period: "8", Date: "yesterday"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.Save(tt.snapshot); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestStore_Dates_FiltersProviderAndPeriod(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	store.Save(testSnapshot("2024-05-03", 100))
+	store.Save(testSnapshot("2024-05-01", 100))
+	other := testSnapshot("2024-05-02", 100)
+	other.Period = "patch"
+	store.Save(other)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644)
+
+	dates, err := store.Dates("d2pt", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(dates, []string{"2024-05-01", "2024-05-03"}) {
+		t.Errorf("expected sorted dates of period 8, got %v", dates)
+	}
+}
+
+func TestStore_Dates_MissingDirectory(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "missing"))
+
+	dates, err := store.Dates("d2pt", "8")
+	if err != nil || len(dates) != 0 {
+		t.Errorf("expected no dates and no error, got %v, %v", dates, err)
+	}
+}
+
+func TestStore_Baseline(t *testing.T) {
+	store := NewStore(t.TempDir())
+	store.Save(testSnapshot("2024-05-01", 80))
+	store.Save(testSnapshot("2024-05-05", 90))
+	store.Save(testSnapshot("2024-05-09", 100))
+	store.Save(testSnapshot("2024-05-10", 110))
+
+	today := mustDate(t, "2024-05-10")
+
+	tests := []struct {
+		name     string
+		days     int
+		wantDate string
+	}{
+		{"previous day", 1, "2024-05-09"},
+		{"latest snapshot old enough", 4, "2024-05-05"},
+		{"history shorter than the window", 30, "2024-05-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, ok, err := store.Baseline("d2pt", "8", today, tt.days)
+			if err != nil || !ok {
+				t.Fatalf("expected baseline, got ok=%v err=%v", ok, err)
+			}
+			if snapshot.Date != tt.wantDate {
+				t.Errorf("expected baseline from %s, got %s", tt.wantDate, snapshot.Date)
+			}
+		})
+	}
+}
+
+func TestStore_Baseline_IgnoresToday(t *testing.T) {
+	store := NewStore(t.TempDir())
+	store.Save(testSnapshot("2024-05-10", 110))
+
+	if _, ok, err := store.Baseline("d2pt", "8", mustDate(t, "2024-05-10"), 1); ok || err != nil {
+		t.Errorf("expected no baseline with only today's snapshot, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestStore_Latest(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if _, ok, err := store.Latest("d2pt", "8"); ok || err != nil {
+		t.Errorf("expected no snapshot in an empty history, got ok=%v err=%v", ok, err)
+	}
+
+	store.Save(testSnapshot("2024-05-09", 100))
+	store.Save(testSnapshot("2024-05-10", 110))
+	// A later update on the same day replaces the statistics of the earlier one
+	store.Save(testSnapshot("2024-05-10", 120))
+
+	snapshot, ok, err := store.Latest("d2pt", "8")
+	if err != nil || !ok {
+		t.Fatalf("expected the latest snapshot, got ok=%v err=%v", ok, err)
+	}
+	if snapshot.Date != "2024-05-10" || snapshot.Positions["pos 1"][0].Rating != 120 {
+		t.Errorf("expected the statistics of the last update, got %+v", snapshot)
+	}
+}
+
+func TestStore_Prune(t *testing.T) {
+	store := NewStore(t.TempDir())
+	store.Save(testSnapshot("2024-04-01", 100))
+	store.Save(testSnapshot("2024-04-20", 100))
+	store.Save(testSnapshot("2024-05-10", 100))
+
+	if err := store.Prune(30, mustDate(t, "2024-05-10")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dates, _ := store.Dates("d2pt", "8")
+	if !slices.Equal(dates, []string{"2024-04-20", "2024-05-10"}) {
+		t.Errorf("expected snapshots older than 30 days to be removed, got %v", dates)
+	}
+}
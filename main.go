@@ -1,10 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"d2tool/config"
 	"d2tool/github"
+	"d2tool/heroes"
 	"d2tool/heroesLayout"
+	"d2tool/history"
 	"d2tool/providers"
 	"d2tool/startup"
 	"d2tool/steam"
@@ -18,6 +21,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/wailsapp/wails/v2"
@@ -45,8 +49,18 @@ func main() {
 	// Parse command line flags
 	minimizedFlagName := "minimized"
 	minimized := flag.Bool(minimizedFlagName, false, "start the application minimized")
+	restartWaitPid := flag.Int(update.RestartWaitPIDFlag, 0, "wait for the process with this PID to exit before starting (used by self-update restart)")
+	updatedFrom := flag.String(update.UpdatedFromFlag, "", "version the application was updated from (used by self-update restart)")
+	restartReadyFile := flag.String(update.RestartReadyFileFlag, "", "file created to confirm the startup to the previous version (used by self-update restart)")
+	previewPath := flag.String("preview", "", "render a hero_grid_config.json file to an image and exit")
+	previewConfig := flag.String("preview-config", "", "name of the hero grid to render, the first one by default")
+	previewOut := flag.String("preview-out", "hero_grid.png", "image written by -preview, .svg or .png")
 	flag.Parse()
 
+	if *previewPath != "" {
+		os.Exit(exportGridPreview(*previewPath, *previewConfig, *previewOut))
+	}
+
 	// Setup file logging
 	setupLogger()
 
@@ -56,20 +70,36 @@ func main() {
 		os.Exit(1)
 	}
 
+	// After a self-update restart, confirm the startup and wait for the old version to release the
+	// single instance lock. The config is loaded afterwards, so the old version's last save is kept.
+	update.ConfirmRestart(*restartReadyFile)
+	update.WaitForRestartHandoff(*restartWaitPid)
+	if *updatedFrom != "" {
+		slog.Info("Started after self-update", "previousVersion", *updatedFrom)
+	}
+
 	appConfig := config.LoadConfig()
 	steamService := steam.NewSteamService(appConfig)
 	steamService.Init()
 
 	heroesProvider := providers.NewD2PTHeroesProvider(nil, "", 10*time.Minute)
+	fileProvider := providers.NewFileHeroesProvider(nil, appConfig.GetFileProviderSource, 10*time.Minute)
+	// OpenDota's SQL explorer is rate-limited, its stats are queried every few hours at most
+	openDotaHeroesProvider := providers.NewOpenDotaHeroesProvider(nil, "", 6*time.Hour)
+	matchupsProvider := providers.NewOpenDotaMatchupsProvider(nil, "", time.Hour)
+	heroRegistry := loadHeroRegistry()
+	historyStore := history.NewStore(historyDir())
 
 	// Create an instance of the app structure
 	app := NewApp(
 		appConfig,
 		update.NewUpdateService(
 			wailsProjectConfig.Info.ProductVersion,
+			*updatedFrom,
+			launchArgs(update.RestartWaitPIDFlag, update.UpdatedFromFlag, update.RestartReadyFileFlag),
 			github.NewHttpClient(""),
 		),
-		heroesLayout.NewHeroesLayoutService(appConfig, steamService, heroesProvider),
+		heroesLayout.NewHeroesLayoutService(appConfig, steamService, []providers.HeroesProvider{heroesProvider, fileProvider, openDotaHeroesProvider}, matchupsProvider, heroRegistry, historyStore),
 		startup.NewStartupService([]string{fmt.Sprintf("-%s", minimizedFlagName)}),
 		steamService,
 	)
@@ -126,6 +156,75 @@ func main() {
 	fmt.Println("D2Tool exited normally")
 }
 
+// launchArgs reconstructs the command line flags the application was started with,
+// leaving out the excluded ones, so a restart can pass them on to the new process
+func launchArgs(excludedFlags ...string) []string {
+	var args []string
+	flag.Visit(func(f *flag.Flag) {
+		if slices.Contains(excludedFlags, f.Name) {
+			return
+		}
+		args = append(args, fmt.Sprintf("-%s=%s", f.Name, f.Value.String()))
+	})
+	return append(args, flag.Args()...)
+}
+
+// loadHeroRegistry returns the embedded hero registry, refreshed from the dataset
+// next to the executable when there is one
+func loadHeroRegistry() *heroes.Registry {
+	registry := heroes.Default()
+
+	executablePath, err := os.Executable()
+	if err != nil {
+		slog.Warn("Error getting executable path, using embedded heroes dataset", "error", err)
+		return registry
+	}
+
+	overridePath := filepath.Join(filepath.Dir(executablePath), heroes.OverrideFileName)
+	if _, err := os.Stat(overridePath); err != nil {
+		return registry
+	}
+
+	if err := registry.RefreshFromFile(overridePath); err != nil {
+		slog.Warn("Error loading heroes dataset, using embedded one", "path", overridePath, "error", err)
+	} else {
+		slog.Info("Loaded heroes dataset", "path", overridePath, "version", registry.Version())
+	}
+	return registry
+}
+
+// exportGridPreview renders a hero grid for the -preview flag and returns the exit code
+func exportGridPreview(gridPath string, configName string, outPath string) int {
+	format, err := heroesLayout.PreviewFormatFromPath(outPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var buf bytes.Buffer
+	if err := heroesLayout.WriteGridPreview(&buf, gridPath, configName, format, loadHeroRegistry().Name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering preview: %v\n", err)
+		return 1
+	}
+	if err := os.WriteFile(outPath, buf.Bytes(), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing preview: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Preview written to %s\n", outPath)
+	return 0
+}
+
+// historyDir returns the directory of the hero statistics snapshots, next to the executable
+func historyDir() string {
+	executablePath, err := os.Executable()
+	if err != nil {
+		slog.Warn("Error getting executable path, storing history in the working directory", "error", err)
+		return history.DirName
+	}
+	return filepath.Join(filepath.Dir(executablePath), history.DirName)
+}
+
 // setupLogger configures file-based logging
 func setupLogger() {
 	executablePath, err := os.Executable()
@@ -0,0 +1,226 @@
+package providers
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const CompositeProviderName = "composite"
+
+// compositeRatingScale is the merged rating of a hero every source rates highest
+const compositeRatingScale = 1000
+
+// CompositeSource is a provider blended by CompositeHeroesProvider
+type CompositeSource struct {
+	Provider HeroesProvider
+	Weight   float64 // share of the source in merged stats, ignored when falling back
+}
+
+// HeroSource is what one source of a CompositeHeroesProvider reported about a hero
+type HeroSource struct {
+	Provider   string  `json:"provider"`
+	Weight     float64 `json:"weight"`
+	Matches    int     `json:"matches"`
+	Wins       int     `json:"wins"`
+	D2PTRating int     `json:"d2pt_rating"`
+}
+
+// CompositeHeroesProvider combines several providers, so grids don't depend on any single site.
+// It either merges every source that answers, weighting their stats, or falls through the sources
+// in order and uses the first one that returns heroes. Merged ratings are percentiles, see
+// mergeSourceHeroes. Each hero keeps the stats of its sources, with their own ratings, in Sources.
+type CompositeHeroesProvider struct {
+	mu       sync.RWMutex
+	sources  []CompositeSource
+	fallback bool
+
+	diagnostics diagnosticsLog
+}
+
+func NewCompositeHeroesProvider(sources []CompositeSource, fallback bool) *CompositeHeroesProvider {
+	return &CompositeHeroesProvider{
+		sources:  sources,
+		fallback: fallback,
+	}
+}
+
+// SetSources replaces the sources, so the provider follows changed settings
+func (p *CompositeHeroesProvider) SetSources(sources []CompositeSource, fallback bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sources = sources
+	p.fallback = fallback
+}
+
+func (p *CompositeHeroesProvider) Name() string {
+	return CompositeProviderName
+}
+
+// Diagnostics returns the recent requests, newest first. Failed sources are listed as warnings,
+// the requests of the sources are recorded by the sources themselves.
+func (p *CompositeHeroesProvider) Diagnostics() []FetchDiagnostics {
+	return p.diagnostics.list()
+}
+
+// FetchHeroes returns one entry per hero; an error is only returned when no source could be used
+func (p *CompositeHeroesProvider) FetchHeroes(position string, period string) ([]Hero, error) {
+	started := time.Now()
+	diagnostics := FetchDiagnostics{Provider: CompositeProviderName, Request: heroesRequest(position, period)}
+
+	heroes, sourceErrs, err := p.fetchSources(position, period)
+	for _, sourceErr := range sourceErrs {
+		diagnostics.Warnings = append(diagnostics.Warnings, sourceErr.Error())
+	}
+	p.diagnostics.recordHeroes(diagnostics, started, heroes, err)
+	return heroes, err
+}
+
+// fetchSources merges the heroes of the sources, it returns the errors of the sources that failed
+func (p *CompositeHeroesProvider) fetchSources(position string, period string) ([]Hero, []error, error) {
+	p.mu.RLock()
+	sources, fallback := p.sources, p.fallback
+	p.mu.RUnlock()
+
+	if len(sources) == 0 {
+		return nil, nil, errors.New("composite provider has no sources")
+	}
+
+	var errs []error
+	var fetched [][]Hero
+	var answered []CompositeSource
+	for _, source := range sources {
+		heroes, err := source.Provider.FetchHeroes(position, period)
+		if err == nil && len(heroes) == 0 {
+			err = errors.New("no heroes returned")
+		}
+		if err != nil {
+			slog.Warn("Composite provider source failed", "provider", source.Provider.Name(), "position", position, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", source.Provider.Name(), err))
+			continue
+		}
+
+		fetched = append(fetched, AggregateHeroesByID(heroes))
+		answered = append(answered, source)
+		if fallback {
+			break
+		}
+	}
+	if len(fetched) == 0 {
+		return nil, errs, fmt.Errorf("every composite provider source failed: %w", errors.Join(errs...))
+	}
+
+	return mergeSourceHeroes(answered, fetched, !fallback), errs, nil
+}
+
+// mergeSourceHeroes blends the aggregated heroes of each source. Stats are the weighted average over
+// the sources that have the hero, so a hero missing from one source isn't pulled towards zero; matches
+// and wins are averaged over the sources that know them, rating-only sources only blend the rating.
+// Facets and names are taken from the first source that has them.
+//
+// Providers rate heroes on their own scales, so when normalize is set each rating is first replaced by
+// its percentile among the heroes of its source, and the merged rating is the weighted percentile
+// scaled to 0 to compositeRatingScale. Sources without any rating don't blend the rating.
+func mergeSourceHeroes(sources []CompositeSource, fetched [][]Hero, normalize bool) []Hero {
+	type weightedHero struct {
+		hero   Hero
+		rating float64
+		rated  bool // false when the source has no ratings
+		weight float64
+	}
+	heroIDToEntries := make(map[int][]weightedHero)
+	for i, heroes := range fetched {
+		weight := sources[i].Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		ratings := make([]float64, len(heroes))
+		rated := false
+		for j, hero := range heroes {
+			ratings[j] = float64(hero.D2PTRating)
+			rated = rated || hero.D2PTRating != 0
+		}
+		if normalize {
+			ratings = ratingPercentiles(ratings)
+		}
+		for j, hero := range heroes {
+			hero.Sources = []HeroSource{{
+				Provider:   sources[i].Provider.Name(),
+				Weight:     weight,
+				Matches:    hero.Matches,
+				Wins:       hero.Wins,
+				D2PTRating: hero.D2PTRating,
+			}}
+			heroIDToEntries[hero.HeroID] = append(heroIDToEntries[hero.HeroID], weightedHero{hero: hero, rating: ratings[j], rated: rated, weight: weight})
+		}
+	}
+
+	result := make([]Hero, 0, len(heroIDToEntries))
+	for heroID, entries := range heroIDToEntries {
+		merged := Hero{HeroID: heroID}
+		var ratingWeight, matchesWeight, matches, wins, rating float64
+		for _, entry := range entries {
+			if entry.rated {
+				ratingWeight += entry.weight
+				rating += entry.weight * entry.rating
+			}
+			if entry.hero.Matches > 0 {
+				matchesWeight += entry.weight
+				matches += entry.weight * float64(entry.hero.Matches)
+				wins += entry.weight * float64(entry.hero.Wins)
+			}
+
+			merged.HeroName = cmp.Or(merged.HeroName, entry.hero.HeroName)
+			if merged.Facets == nil {
+				merged.Facets = entry.hero.Facets
+			}
+			merged.Sources = append(merged.Sources, entry.hero.Sources...)
+		}
+		if matchesWeight > 0 {
+			merged.Matches = int(math.Round(matches / matchesWeight))
+			merged.Wins = int(math.Round(wins / matchesWeight))
+		}
+		if ratingWeight > 0 {
+			rating /= ratingWeight
+			if normalize {
+				rating *= compositeRatingScale
+			}
+			merged.D2PTRating = int(math.Round(rating))
+		}
+		result = append(result, merged)
+	}
+
+	slices.SortFunc(result, func(a, b Hero) int {
+		return cmp.Compare(a.HeroID, b.HeroID)
+	})
+	return result
+}
+
+// ratingPercentiles returns the share of the other ratings below each rating, ties counting half.
+// A single rating is in the middle.
+func ratingPercentiles(ratings []float64) []float64 {
+	percentiles := make([]float64, len(ratings))
+	for i, rating := range ratings {
+		if len(ratings) == 1 {
+			percentiles[i] = 0.5
+			break
+		}
+		below := 0.0
+		for j, other := range ratings {
+			switch {
+			case j == i:
+			case other < rating:
+				below++
+			case other == rating:
+				below += 0.5
+			}
+		}
+		percentiles[i] = below / float64(len(ratings)-1)
+	}
+	return percentiles
+}
@@ -0,0 +1,181 @@
+package providers
+
+import (
+	"errors"
+	"testing"
+)
+
+// stubHeroesProvider returns fixed heroes or a fixed error
+type stubHeroesProvider struct {
+	name   string
+	heroes []Hero
+	err    error
+	calls  int
+}
+
+func (p *stubHeroesProvider) Name() string {
+	return p.name
+}
+
+func (p *stubHeroesProvider) FetchHeroes(position string, period string) ([]Hero, error) {
+	p.calls++
+	return p.heroes, p.err
+}
+
+func TestCompositeHeroesProvider_Merge(t *testing.T) {
+	d2pt := &stubHeroesProvider{name: "d2pt", heroes: []Hero{
+		{HeroID: 1, HeroName: "Anti-Mage", Matches: 60, Wins: 30, D2PTRating: 1000, Facet: 1},
+		{HeroID: 1, HeroName: "Anti-Mage", Matches: 40, Wins: 20, D2PTRating: 1000, Facet: 2},
+		{HeroID: 2, HeroName: "Axe", Matches: 100, Wins: 50, D2PTRating: 900},
+	}}
+	file := &stubHeroesProvider{name: "file", heroes: []Hero{
+		{HeroID: 1, Matches: 400, Wins: 240, D2PTRating: 1600},
+		{HeroID: 3, HeroName: "Bane", Matches: 10, Wins: 5, D2PTRating: 500},
+	}}
+	provider := NewCompositeHeroesProvider([]CompositeSource{{Provider: d2pt, Weight: 2}, {Provider: file, Weight: 1}}, false)
+
+	heroes, err := provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(heroes) != 3 {
+		t.Fatalf("expected 3 heroes, got %+v", heroes)
+	}
+
+	antiMage := heroes[0]
+	// Anti-Mage is the best rated hero of both sources
+	if antiMage.Matches != 200 || antiMage.Wins != 113 || antiMage.D2PTRating != compositeRatingScale {
+		t.Errorf("expected the weighted average 200/113/%d, got %d/%d/%d", compositeRatingScale, antiMage.Matches, antiMage.Wins, antiMage.D2PTRating)
+	}
+	if antiMage.HeroName != "Anti-Mage" || len(antiMage.Facets) != 2 {
+		t.Errorf("expected the name and facets of the first source, got %q and %+v", antiMage.HeroName, antiMage.Facets)
+	}
+	if len(antiMage.Sources) != 2 || antiMage.Sources[0].Provider != "d2pt" || antiMage.Sources[0].Matches != 100 || antiMage.Sources[1].D2PTRating != 1600 {
+		t.Errorf("expected the stats of both sources, got %+v", antiMage.Sources)
+	}
+
+	// A hero known by one source keeps its stats and the percentile of its rating in that source
+	if axe := heroes[1]; axe.Matches != 100 || axe.D2PTRating != 0 || len(axe.Sources) != 1 || axe.Sources[0].D2PTRating != 900 {
+		t.Errorf("expected Axe from d2pt only, got %+v", axe)
+	}
+
+	// Aggregating merged heroes again changes nothing
+	again := AggregateHeroesByID(heroes[:1])
+	if again[0].D2PTRating != antiMage.D2PTRating || len(again[0].Facets) != 2 || len(again[0].Sources) != 2 {
+		t.Errorf("expected aggregation to keep merged heroes, got %+v", again[0])
+	}
+}
+
+func TestCompositeHeroesProvider_RatingOnlySource(t *testing.T) {
+	d2pt := &stubHeroesProvider{name: "d2pt", heroes: []Hero{{HeroID: 1, Matches: 100, Wins: 60, D2PTRating: 1000}}}
+	tiers := &stubHeroesProvider{name: "file", heroes: []Hero{
+		{HeroID: 1, D2PTRating: 1400},
+		{HeroID: 2, D2PTRating: 800},
+		{HeroID: 3},
+	}}
+	provider := NewCompositeHeroesProvider([]CompositeSource{{Provider: d2pt, Weight: 1}, {Provider: tiers, Weight: 1}}, false)
+
+	heroes, err := provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Hero 3 has no statistics at all and is dropped
+	if len(heroes) != 2 {
+		t.Fatalf("expected 2 heroes, got %+v", heroes)
+	}
+	// The rating-only source blends the rating, matches and wins come from the source that has them.
+	// The only hero of d2pt is in the middle of it, Anti-Mage is the best of the file.
+	if antiMage := heroes[0]; antiMage.Matches != 100 || antiMage.Wins != 60 || antiMage.D2PTRating != 750 {
+		t.Errorf("expected 100/60/750, got %d/%d/%d", antiMage.Matches, antiMage.Wins, antiMage.D2PTRating)
+	}
+	if axe := heroes[1]; axe.Matches != 0 || axe.D2PTRating != 0 || axe.Sources[0].D2PTRating != 800 {
+		t.Errorf("expected Axe with unknown matches and the lowest rating of the file, got %+v", axe)
+	}
+}
+
+func TestCompositeHeroesProvider_MergesRatingScales(t *testing.T) {
+	d2pt := &stubHeroesProvider{name: "d2pt", heroes: []Hero{
+		{HeroID: 1, Matches: 100, Wins: 60, D2PTRating: 1500},
+		{HeroID: 2, Matches: 100, Wins: 55, D2PTRating: 1400},
+		{HeroID: 3, Matches: 100, Wins: 45, D2PTRating: 1300},
+	}}
+	openDota := &stubHeroesProvider{name: "opendota", heroes: []Hero{
+		{HeroID: 1, Matches: 20, Wins: 12, D2PTRating: 1000},
+		{HeroID: 2, Matches: 20, Wins: 11, D2PTRating: 600},
+		{HeroID: 4, Matches: 20, Wins: 8, D2PTRating: 0},
+	}}
+	provider := NewCompositeHeroesProvider([]CompositeSource{{Provider: d2pt, Weight: 1}, {Provider: openDota, Weight: 1}}, false)
+
+	heroes, err := provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Hero 3 is the worst of d2pt: its high raw rating must not put it above hero 2, which both sources rate in the middle
+	ratings := make(map[int]int, len(heroes))
+	for _, hero := range heroes {
+		ratings[hero.HeroID] = hero.D2PTRating
+	}
+	expected := map[int]int{1: 1000, 2: 500, 3: 0, 4: 0}
+	for heroID, rating := range expected {
+		if ratings[heroID] != rating {
+			t.Errorf("expected hero %d rated %d, got %d", heroID, rating, ratings[heroID])
+		}
+	}
+
+	// Falling back keeps the ratings of the answering source
+	fallback := NewCompositeHeroesProvider([]CompositeSource{{Provider: d2pt}, {Provider: openDota}}, true)
+	heroes, err = fallback.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if heroes[0].D2PTRating != 1500 {
+		t.Errorf("expected the d2pt rating 1500, got %d", heroes[0].D2PTRating)
+	}
+}
+
+func TestCompositeHeroesProvider_SourceFailures(t *testing.T) {
+	failing := &stubHeroesProvider{name: "d2pt", err: errors.New("unexpected status code: 503")}
+	empty := &stubHeroesProvider{name: "empty"}
+	file := &stubHeroesProvider{name: "file", heroes: []Hero{{HeroID: 1, Matches: 10, Wins: 5, D2PTRating: 700}}}
+	other := &stubHeroesProvider{name: "other", heroes: []Hero{{HeroID: 1, Matches: 30, Wins: 15, D2PTRating: 900}}}
+
+	t.Run("merge skips failed sources", func(t *testing.T) {
+		provider := NewCompositeHeroesProvider([]CompositeSource{{Provider: failing, Weight: 1}, {Provider: file, Weight: 1}}, false)
+		heroes, err := provider.FetchHeroes("pos 1", "8")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(heroes) != 1 || heroes[0].Sources[0].D2PTRating != 700 || heroes[0].Sources[0].Provider != "file" {
+			t.Errorf("expected heroes of the working source, got %+v", heroes)
+		}
+	})
+
+	t.Run("fallback uses the first answering source", func(t *testing.T) {
+		other.calls = 0
+		provider := NewCompositeHeroesProvider([]CompositeSource{{Provider: failing}, {Provider: empty}, {Provider: file}, {Provider: other}}, true)
+		heroes, err := provider.FetchHeroes("pos 1", "8")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(heroes) != 1 || heroes[0].D2PTRating != 700 || len(heroes[0].Sources) != 1 || heroes[0].Sources[0].Provider != "file" {
+			t.Errorf("expected heroes of the file source, got %+v", heroes)
+		}
+		if other.calls != 0 {
+			t.Error("expected sources after the answering one not to be fetched")
+		}
+	})
+
+	t.Run("every source failed", func(t *testing.T) {
+		provider := NewCompositeHeroesProvider([]CompositeSource{{Provider: failing}, {Provider: empty}}, true)
+		if _, err := provider.FetchHeroes("pos 1", "8"); err == nil {
+			t.Error("expected an error when every source failed")
+		}
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		if _, err := NewCompositeHeroesProvider(nil, false).FetchHeroes("pos 1", "8"); err == nil {
+			t.Error("expected an error without sources")
+		}
+	})
+}
@@ -12,6 +12,8 @@ import (
 )
 
 const (
+	D2PTProviderName = "d2pt"
+
 	apiD2ptUrl  = "https://dota2protracker.com/api"
 	period8Days = "8"
 	periodPatch = "patch"
@@ -34,6 +36,8 @@ type D2PTHeroesProvider struct {
 
 	mu    sync.RWMutex
 	cache map[cacheKey]cacheEntry
+
+	diagnostics diagnosticsLog
 }
 
 func NewD2PTHeroesProvider(httpClient *http.Client, apiUrl string, ttl time.Duration) *D2PTHeroesProvider {
@@ -50,21 +54,35 @@ func NewD2PTHeroesProvider(httpClient *http.Client, apiUrl string, ttl time.Dura
 	}
 }
 
+func (p *D2PTHeroesProvider) Name() string {
+	return D2PTProviderName
+}
+
+// Diagnostics returns the recent requests, newest first
+func (p *D2PTHeroesProvider) Diagnostics() []FetchDiagnostics {
+	return p.diagnostics.list()
+}
+
 func (p *D2PTHeroesProvider) FetchHeroes(position string, period string) ([]Hero, error) {
 	key := cacheKey{position: position, period: period}
+	started := time.Now()
+	diagnostics := FetchDiagnostics{Provider: D2PTProviderName, Request: heroesRequest(position, period)}
 
 	if p.ttl > 0 {
 		p.mu.RLock()
 		if entry, ok := p.cache[key]; ok {
 			if time.Since(entry.fetchedAt) < p.ttl {
 				p.mu.RUnlock()
+				diagnostics.CacheHit = true
+				p.diagnostics.recordHeroes(diagnostics, started, entry.heroes, nil)
 				return entry.heroes, nil
 			}
 		}
 		p.mu.RUnlock()
 	}
 
-	heroes, err := p.fetchFromAPI(position, period)
+	heroes, err := p.fetchFromAPI(position, period, &diagnostics)
+	p.diagnostics.recordHeroes(diagnostics, started, heroes, err)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +99,8 @@ func (p *D2PTHeroesProvider) FetchHeroes(position string, period string) ([]Hero
 	return heroes, nil
 }
 
-func (p *D2PTHeroesProvider) fetchFromAPI(position string, period string) ([]Hero, error) {
+// fetchFromAPI requests the heroes of a position, filling the URL, status and size of the diagnostics
+func (p *D2PTHeroesProvider) fetchFromAPI(position string, period string, diagnostics *FetchDiagnostics) ([]Hero, error) {
 	if period == "" {
 		period = period8Days
 	}
@@ -105,6 +124,7 @@ func (p *D2PTHeroesProvider) fetchFromAPI(position string, period string) ([]Her
 	}
 
 	d2ptUrl := fmt.Sprintf("%s/heroes/stats?%s", apiUrl, params.Encode())
+	diagnostics.URL = RedactURL(d2ptUrl)
 
 	req, err := http.NewRequest("GET", d2ptUrl, nil)
 	if err != nil {
@@ -120,12 +140,14 @@ func (p *D2PTHeroesProvider) fetchFromAPI(position string, period string) ([]Her
 		return nil, fmt.Errorf("error fetching data: %w", err)
 	}
 	defer resp.Body.Close()
+	diagnostics.StatusCode = resp.StatusCode
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
+	diagnostics.PayloadBytes = len(body)
 	if err != nil {
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
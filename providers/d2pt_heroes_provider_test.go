@@ -423,6 +423,63 @@ func TestAggregateHeroesByID_DoesNotModifyOriginal(t *testing.T) {
 	}
 }
 
+func TestAggregateHeroesByID_Facets(t *testing.T) {
+	heroes := []Hero{
+		{HeroID: 1, Facet: 2, Matches: 50, Wins: 30, D2PTRating: 90},
+		{HeroID: 1, Facet: 1, Matches: 100, Wins: 50, D2PTRating: 60},
+		{HeroID: 2, Matches: 200, Wins: 110, D2PTRating: 100},
+	}
+
+	aggregated := AggregateHeroesByID(heroes)
+
+	for _, hero := range aggregated {
+		switch hero.HeroID {
+		case 1:
+			if hero.Facet != 0 || hero.Matches != 150 {
+				t.Errorf("expected the facets merged into one hero, got %+v", hero)
+			}
+			expected := []FacetStats{
+				{Facet: 1, Matches: 100, Wins: 50, D2PTRating: 60},
+				{Facet: 2, Matches: 50, Wins: 30, D2PTRating: 90},
+			}
+			if len(hero.Facets) != 2 || hero.Facets[0] != expected[0] || hero.Facets[1] != expected[1] {
+				t.Errorf("expected facet stats %+v, got %+v", expected, hero.Facets)
+			}
+		case 2:
+			if hero.Facets != nil {
+				t.Errorf("expected no facet stats for a hero without facets, got %+v", hero.Facets)
+			}
+		}
+	}
+}
+
+func TestFacetHeroes(t *testing.T) {
+	heroes := []Hero{
+		{HeroID: 1, Matches: 150, Wins: 80, D2PTRating: 70, Facets: []FacetStats{
+			{Facet: 1, Matches: 100, Wins: 50, D2PTRating: 60},
+			{Facet: 2, Matches: 50, Wins: 30, D2PTRating: 90},
+		}},
+		{HeroID: 2, Matches: 200, Wins: 110, D2PTRating: 100},
+	}
+
+	best := BestFacetHeroes(heroes)
+	if len(best) != 2 || best[0].Facet != 2 || best[0].Matches != 50 || best[0].D2PTRating != 90 {
+		t.Errorf("expected hero 1 replaced by facet 2, got %+v", best)
+	}
+	if best[1].Facet != 0 || best[1].Matches != 200 {
+		t.Errorf("expected hero 2 kept as is, got %+v", best[1])
+	}
+
+	split := SplitFacetHeroes(heroes)
+	if len(split) != 3 || split[0].Facet != 1 || split[1].Facet != 2 || split[2].HeroID != 2 {
+		t.Errorf("expected one hero per facet, got %+v", split)
+	}
+
+	if heroes[0].Facet != 0 || heroes[0].Matches != 150 {
+		t.Error("expected the original heroes to be left unchanged")
+	}
+}
+
 // --- Benchmarks ---
 
 func BenchmarkGetTopHeroesByRating(b *testing.B) {
@@ -0,0 +1,144 @@
+package providers
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diagnosticsLogSize is the number of requests each provider remembers
+const diagnosticsLogSize = 20
+
+// redactedQueryParams are query parameters whose values are hidden in diagnostics
+var redactedQueryParams = []string{"key", "api_key", "apikey", "token", "access_token", "secret", "password", "signature", "sig"}
+
+// FetchDiagnostics describes one request of a provider
+type FetchDiagnostics struct {
+	Provider        string   `json:"provider"`
+	Request         string   `json:"request"` // what was fetched, e.g. "pos 1, period 8"
+	URL             string   `json:"url"`     // with credentials redacted, empty when no request was sent
+	TimestampMillis int64    `json:"timestampMillis"`
+	LatencyMillis   int64    `json:"latencyMillis"`
+	StatusCode      int      `json:"statusCode"` // 0 when no HTTP response was received
+	PayloadBytes    int      `json:"payloadBytes"`
+	HeroCount       int      `json:"heroCount"` // heroes or matchups returned
+	CacheHit        bool     `json:"cacheHit"`
+	Error           string   `json:"error"`
+	Warnings        []string `json:"warnings"` // suspicious responses and failed composite sources
+}
+
+// DiagnosticsReporter is implemented by providers that record their recent requests
+type DiagnosticsReporter interface {
+	// Diagnostics returns the recent requests, newest first
+	Diagnostics() []FetchDiagnostics
+}
+
+// diagnosticsLog keeps the last requests of a provider
+type diagnosticsLog struct {
+	mu      sync.Mutex
+	entries []FetchDiagnostics // oldest first
+}
+
+// record completes an entry started at started with the latency and error, and stores it
+func (l *diagnosticsLog) record(entry FetchDiagnostics, started time.Time, err error) {
+	entry.TimestampMillis = started.UnixMilli()
+	entry.LatencyMillis = time.Since(started).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > diagnosticsLogSize {
+		l.entries = slices.Delete(l.entries, 0, len(l.entries)-diagnosticsLogSize)
+	}
+}
+
+// recordHeroes records a heroes request, flagging suspicious heroes of a successful one
+func (l *diagnosticsLog) recordHeroes(entry FetchDiagnostics, started time.Time, heroes []Hero, err error) {
+	if err == nil {
+		entry.HeroCount = len(heroes)
+		entry.Warnings = append(entry.Warnings, CheckHeroes(heroes)...)
+	}
+	l.record(entry, started, err)
+}
+
+func (l *diagnosticsLog) list() []FetchDiagnostics {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make([]FetchDiagnostics, len(l.entries))
+	for i, entry := range l.entries {
+		entry.Warnings = slices.Clone(entry.Warnings)
+		result[len(l.entries)-1-i] = entry
+	}
+	return result
+}
+
+// heroesRequest describes a heroes request in diagnostics
+func heroesRequest(position string, period string) string {
+	if period == "" {
+		return position
+	}
+	return fmt.Sprintf("%s, period %s", position, period)
+}
+
+// RedactURL hides the password and the values of credential query parameters of a URL
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" { // local paths are kept as they are
+		return rawURL
+	}
+
+	query := u.Query()
+	redacted := false
+	for name := range query {
+		if slices.Contains(redactedQueryParams, strings.ToLower(name)) {
+			query.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if redacted {
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
+
+// CheckHeroes flags heroes that look like a broken response rather than real statistics. Heroes
+// without matches are fine when they are rated and have no wins: that is a rating-only list.
+func CheckHeroes(heroes []Hero) []string {
+	if len(heroes) == 0 {
+		return []string{"no heroes returned"}
+	}
+
+	var warnings []string
+	allZeroRating, allZeroMatches, anyWins := true, true, false
+	invalidIDs, winsAboveMatches := 0, 0
+	for _, hero := range heroes {
+		allZeroRating = allZeroRating && hero.D2PTRating == 0
+		allZeroMatches = allZeroMatches && hero.Matches == 0
+		anyWins = anyWins || hero.Wins > 0
+		if hero.HeroID <= 0 {
+			invalidIDs++
+		}
+		if hero.Wins > hero.Matches {
+			winsAboveMatches++
+		}
+	}
+	if allZeroRating {
+		warnings = append(warnings, "every hero has a rating of 0")
+	}
+	if allZeroMatches && (allZeroRating || anyWins) {
+		warnings = append(warnings, "every hero has 0 matches")
+	}
+	if invalidIDs > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d heroes have an invalid hero ID", invalidIDs))
+	}
+	if winsAboveMatches > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d heroes have more wins than matches", winsAboveMatches))
+	}
+	return warnings
+}
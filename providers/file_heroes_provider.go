@@ -0,0 +1,421 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const FileProviderName = "file"
+
+// Formats of hero files
+const (
+	FileFormatJSON = "json"
+	FileFormatCSV  = "csv"
+	FileFormatYAML = "yaml"
+)
+
+// ErrNoFileSource is returned when the file provider is used without a configured file or URL
+var ErrNoFileSource = errors.New("no hero stats file or URL configured")
+
+// heroFile is the JSON and YAML schema of a hero file: the heroes of each position, with the fields
+// of Hero. Positions are keyed by "pos 1" to "pos 5", or just "1" to "5".
+//
+//	{"positions": {"1": [{"hero_id": 1, "hero_name": "Anti-Mage", "matches": 120, "wins": 66, "d2pt_rating": 1450}]}}
+//
+// CSV files have a header row with a "position" column and the Hero fields as further columns.
+type heroFile struct {
+	Positions map[string][]Hero `json:"positions"`
+}
+
+type fileCacheEntry struct {
+	source         string
+	modTime        time.Time // of local files, which are reloaded when it changes
+	size           int64
+	fetchedAt      time.Time // of URLs, which are reloaded after the TTL
+	positionHeroes map[string][]Hero
+}
+
+// fileVersion identifies the content of a local file by its modification time and size
+type fileVersion struct {
+	source  string
+	modTime time.Time
+	size    int64
+}
+
+// FileHeroesProvider reads team-curated hero stats from a local JSON, CSV or YAML file or from an
+// HTTP URL. The period is ignored, the file holds one list per position. A local file is read again
+// as soon as it changes, a URL after the TTL. Changed reports edits of a local file so layouts can be
+// updated without waiting for the next scheduled update.
+type FileHeroesProvider struct {
+	httpClient *http.Client
+	source     func() string // the current file path or URL, it can change between fetches
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	cache *fileCacheEntry
+	read  fileVersion // the local file read last, even when it couldn't be parsed
+
+	diagnostics diagnosticsLog
+}
+
+func NewFileHeroesProvider(httpClient *http.Client, source func() string, ttl time.Duration) *FileHeroesProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 30 * time.Second,
+		}
+	}
+	return &FileHeroesProvider{
+		httpClient: httpClient,
+		source:     source,
+		ttl:        ttl,
+	}
+}
+
+func (p *FileHeroesProvider) Name() string {
+	return FileProviderName
+}
+
+// Diagnostics returns the recent requests, newest first
+func (p *FileHeroesProvider) Diagnostics() []FetchDiagnostics {
+	return p.diagnostics.list()
+}
+
+func (p *FileHeroesProvider) FetchHeroes(position string, period string) ([]Hero, error) {
+	started := time.Now()
+	diagnostics := FetchDiagnostics{Provider: FileProviderName, Request: position}
+
+	heroes, err := p.fetchPosition(position, &diagnostics)
+	p.diagnostics.recordHeroes(diagnostics, started, heroes, err)
+	return heroes, err
+}
+
+func (p *FileHeroesProvider) fetchPosition(position string, diagnostics *FetchDiagnostics) ([]Hero, error) {
+	positionHeroes, err := p.load(diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	heroes, ok := positionHeroes[normalizeFilePosition(position)]
+	if !ok {
+		return nil, fmt.Errorf("hero stats file has no heroes for position %q", position)
+	}
+	return heroes, nil
+}
+
+// load returns the heroes of every position, reading the source again when it changed
+func (p *FileHeroesProvider) load(diagnostics *FetchDiagnostics) (map[string][]Hero, error) {
+	source := strings.TrimSpace(p.source())
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.read = fileVersion{source: source}
+	if source == "" {
+		return nil, ErrNoFileSource
+	}
+	diagnostics.URL = RedactURL(source)
+
+	if isHTTPSource(source) {
+		if p.cache != nil && p.cache.source == source && p.ttl > 0 && time.Since(p.cache.fetchedAt) < p.ttl {
+			diagnostics.CacheHit = true
+			return p.cache.positionHeroes, nil
+		}
+		data, format, err := p.download(source, diagnostics)
+		if err != nil {
+			return nil, err
+		}
+		positionHeroes, err := ParseHeroFile(data, format)
+		if err != nil {
+			return nil, err
+		}
+		p.cache = &fileCacheEntry{source: source, fetchedAt: time.Now(), positionHeroes: positionHeroes}
+		return positionHeroes, nil
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading hero stats file: %w", err)
+	}
+	p.read = fileVersion{source: source, modTime: info.ModTime(), size: info.Size()}
+	if p.cache != nil && p.cache.source == source && p.cache.modTime.Equal(info.ModTime()) && p.cache.size == info.Size() {
+		diagnostics.CacheHit = true
+		return p.cache.positionHeroes, nil
+	}
+
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading hero stats file: %w", err)
+	}
+	diagnostics.PayloadBytes = len(data)
+	positionHeroes, err := ParseHeroFile(data, FileFormatFromName(source))
+	if err != nil {
+		return nil, err
+	}
+	p.cache = &fileCacheEntry{source: source, modTime: info.ModTime(), size: info.Size(), positionHeroes: positionHeroes}
+	return positionHeroes, nil
+}
+
+// Changed reports whether the source was edited, removed or replaced by another source since it
+// was last read. URLs are only read again after the TTL and never report changes. A file that
+// failed to load isn't reported again until it changes.
+func (p *FileHeroesProvider) Changed() bool {
+	source := strings.TrimSpace(p.source())
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.read.source == "":
+		// Nothing was read yet, the provider isn't used
+		return false
+	case source != p.read.source:
+		return true
+	case isHTTPSource(source):
+		return false
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		// Removed since it was read; a file that was already missing hasn't changed
+		return !p.read.modTime.IsZero()
+	}
+	return !p.read.modTime.Equal(info.ModTime()) || p.read.size != info.Size()
+}
+
+// download fetches a hero file; its format comes from the URL path, or the content type when the path has no known extension
+func (p *FileHeroesProvider) download(source string, diagnostics *FetchDiagnostics) ([]byte, string, error) {
+	req, err := http.NewRequest("GET", source, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("error fetching data: %w", err)
+	}
+	defer resp.Body.Close()
+	diagnostics.StatusCode = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	diagnostics.PayloadBytes = len(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading response body: %w", err)
+	}
+
+	format := FileFormatJSON
+	if u, err := url.Parse(source); err == nil && knownFileExtension(path.Ext(u.Path)) {
+		format = FileFormatFromName(u.Path)
+	} else if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		switch {
+		case strings.Contains(mediaType, "csv"):
+			format = FileFormatCSV
+		case strings.Contains(mediaType, "yaml"):
+			format = FileFormatYAML
+		}
+	}
+	return body, format, nil
+}
+
+func isHTTPSource(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+func knownFileExtension(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".json", ".csv", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// FileFormatFromName returns the format of a hero file by its extension, JSON when it's unknown
+func FileFormatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FileFormatCSV
+	case ".yaml", ".yml":
+		return FileFormatYAML
+	default:
+		return FileFormatJSON
+	}
+}
+
+// ValidateFileSource checks that a hero file source is an HTTP URL or a path of a known format
+func ValidateFileSource(source string) error {
+	source = strings.TrimSpace(source)
+	if source == "" || isHTTPSource(source) {
+		return nil
+	}
+	if !knownFileExtension(filepath.Ext(source)) {
+		return fmt.Errorf("hero stats file %q must be a .json, .csv, .yaml or .yml file", source)
+	}
+	return nil
+}
+
+// ParseHeroFile reads the heroes of every position of a hero file. Matches and wins are optional:
+// tier lists that only set ratings leave them 0, which layouts treat as unknown.
+func ParseHeroFile(data []byte, format string) (map[string][]Hero, error) {
+	var positionHeroes map[string][]Hero
+	var err error
+	switch format {
+	case FileFormatCSV:
+		positionHeroes, err = parseHeroCSV(data)
+	case FileFormatYAML:
+		positionHeroes, err = parseHeroYAML(data)
+	default:
+		positionHeroes, err = parseHeroJSON(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]Hero, len(positionHeroes))
+	for position, heroes := range positionHeroes {
+		key := normalizeFilePosition(position)
+		if key == "" {
+			return nil, errors.New("hero stats file has heroes without position")
+		}
+		for i, hero := range heroes {
+			if hero.HeroID <= 0 {
+				return nil, fmt.Errorf("position %q, hero %d: hero_id is required", position, i+1)
+			}
+			result[key] = append(result[key], hero)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("hero stats file has no positions")
+	}
+	return result, nil
+}
+
+func parseHeroJSON(data []byte) (map[string][]Hero, error) {
+	var file heroFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+	return file.Positions, nil
+}
+
+// parseHeroYAML reads YAML with the JSON schema: the document is converted to JSON, so both
+// formats share the Hero field names
+func parseHeroYAML(data []byte) (map[string][]Hero, error) {
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("error unmarshaling YAML: %w", err)
+	}
+	jsonData, err := json.Marshal(stringKeys(document))
+	if err != nil {
+		return nil, fmt.Errorf("error converting YAML: %w", err)
+	}
+	return parseHeroJSON(jsonData)
+}
+
+// stringKeys converts the keys of YAML maps to strings, as unquoted position keys like 1: are decoded as numbers
+func stringKeys(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = stringKeys(item)
+		}
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[fmt.Sprint(key)] = stringKeys(item)
+		}
+		return result
+	case []any:
+		for i, item := range v {
+			v[i] = stringKeys(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+// parseHeroCSV reads a CSV file with a header row. The position and hero_id columns are required,
+// hero_name, matches, wins, d2pt_rating and facet are optional and other columns are ignored.
+func parseHeroCSV(data []byte) (map[string][]Hero, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("CSV file is empty")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"position", "hero_id"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV file has no %q column", required)
+		}
+	}
+
+	positionHeroes := make(map[string][]Hero)
+	for line, record := range records[1:] {
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number := func(column string) (int, error) {
+			if value(column) == "" {
+				return 0, nil
+			}
+			n, err := strconv.Atoi(value(column))
+			if err != nil {
+				return 0, fmt.Errorf("CSV line %d: invalid %s %q", line+2, column, value(column))
+			}
+			return n, nil
+		}
+
+		hero := Hero{HeroName: value("hero_name")}
+		for column, field := range map[string]*int{
+			"hero_id":     &hero.HeroID,
+			"matches":     &hero.Matches,
+			"wins":        &hero.Wins,
+			"d2pt_rating": &hero.D2PTRating,
+			"facet":       &hero.Facet,
+		} {
+			if *field, err = number(column); err != nil {
+				return nil, err
+			}
+		}
+		positionHeroes[value("position")] = append(positionHeroes[value("position")], hero)
+	}
+	return positionHeroes, nil
+}
+
+// normalizeFilePosition maps "1" and "Pos 1" to the "pos 1" positions are fetched with
+func normalizeFilePosition(position string) string {
+	position = strings.ToLower(strings.TrimSpace(position))
+	if _, err := strconv.Atoi(position); err == nil {
+		return "pos " + position
+	}
+	return position
+}
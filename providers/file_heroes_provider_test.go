@@ -0,0 +1,196 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseHeroFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+	}{
+		{
+			name:   "json",
+			format: FileFormatJSON,
+			data:   `{"positions": {"1": [{"hero_id": 1, "hero_name": "Anti-Mage", "matches": 120, "wins": 66, "d2pt_rating": 1450}, {"hero_id": 2, "d2pt_rating": 900}]}}`,
+		},
+		{
+			name:   "yaml",
+			format: FileFormatYAML,
+			data: `positions:
+  1:
+    - hero_id: 1
+      hero_name: Anti-Mage
+      matches: 120
+      wins: 66
+      d2pt_rating: 1450
+    - hero_id: 2
+      d2pt_rating: 900
+`,
+		},
+		{
+			name:   "csv",
+			format: FileFormatCSV,
+			data:   "position,hero_id,hero_name,matches,wins,d2pt_rating,note\nPos 1,1,Anti-Mage,120,66,1450,farm\n1,2,,,,900,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positionHeroes, err := ParseHeroFile([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("ParseHeroFile failed: %v", err)
+			}
+			heroes := positionHeroes["pos 1"]
+			if len(positionHeroes) != 1 || len(heroes) != 2 {
+				t.Fatalf("expected 2 heroes in pos 1, got %+v", positionHeroes)
+			}
+			expected := Hero{HeroID: 1, HeroName: "Anti-Mage", Matches: 120, Wins: 66, D2PTRating: 1450}
+			if heroes[0].HeroID != expected.HeroID || heroes[0].HeroName != expected.HeroName || heroes[0].Matches != expected.Matches ||
+				heroes[0].Wins != expected.Wins || heroes[0].D2PTRating != expected.D2PTRating {
+				t.Errorf("expected %+v, got %+v", expected, heroes[0])
+			}
+			if heroes[1].Matches != 0 || heroes[1].Wins != 0 || heroes[1].D2PTRating != 900 {
+				t.Errorf("expected a rating-only hero to keep unknown matches, got %+v", heroes[1])
+			}
+		})
+	}
+}
+
+func TestParseHeroFile_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		data   string
+	}{
+		{"invalid json", FileFormatJSON, `{"positions": [`},
+		{"no positions", FileFormatJSON, `{}`},
+		{"missing hero id", FileFormatJSON, `{"positions": {"1": [{"hero_name": "Anti-Mage"}]}}`},
+		{"missing csv column", FileFormatCSV, "position,hero_name\n1,Anti-Mage\n"},
+		{"invalid csv number", FileFormatCSV, "position,hero_id\n1,one\n"},
+		{"csv without position", FileFormatCSV, "position,hero_id\n,1\n"},
+		{"invalid yaml", FileFormatYAML, "positions: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseHeroFile([]byte(tt.data), tt.format); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFileHeroesProvider_ReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tiers.csv")
+	writeFile := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write hero file: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+
+	modTime := time.Now().Add(-time.Hour)
+	writeFile("position,hero_id,d2pt_rating\n1,1,1000\n", modTime)
+	provider := NewFileHeroesProvider(nil, func() string { return path }, 0)
+	if provider.Changed() {
+		t.Error("expected no change before the file was read")
+	}
+
+	heroes, err := provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("FetchHeroes failed: %v", err)
+	}
+	if len(heroes) != 1 || heroes[0].D2PTRating != 1000 {
+		t.Fatalf("expected the file heroes, got %+v", heroes)
+	}
+	if provider.Changed() {
+		t.Error("expected no change right after reading the file")
+	}
+
+	writeFile("position,hero_id,d2pt_rating\n1,1,1200\n1,2,800\n", modTime.Add(time.Minute))
+	if !provider.Changed() {
+		t.Error("expected the edited file to be reported as changed")
+	}
+	heroes, err = provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("FetchHeroes failed: %v", err)
+	}
+	if len(heroes) != 2 || heroes[0].D2PTRating != 1200 {
+		t.Errorf("expected the changed file to be reloaded, got %+v", heroes)
+	}
+	if provider.Changed() {
+		t.Error("expected no change after the edited file was read")
+	}
+
+	// A broken edit is reported once; it isn't retried until the file changes again
+	writeFile("position,hero_id\n1,x\n", modTime.Add(2*time.Minute))
+	if !provider.Changed() {
+		t.Error("expected the broken edit to be reported as changed")
+	}
+	if _, err := provider.FetchHeroes("pos 1", "8"); err == nil {
+		t.Fatal("expected an error for the broken file")
+	}
+	if provider.Changed() {
+		t.Error("expected the broken file not to be reported again")
+	}
+
+	if _, err := provider.FetchHeroes("pos 3", "8"); err == nil {
+		t.Error("expected an error for a position missing from the file")
+	}
+}
+
+func TestFileHeroesProvider_URL(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write([]byte("positions:\n  pos 2:\n    - hero_id: 11\n      d2pt_rating: 1300\n"))
+	}))
+	defer server.Close()
+
+	provider := NewFileHeroesProvider(nil, func() string { return server.URL + "/tiers" }, time.Minute)
+	for range 2 {
+		heroes, err := provider.FetchHeroes("pos 2", "patch")
+		if err != nil {
+			t.Fatalf("FetchHeroes failed: %v", err)
+		}
+		if len(heroes) != 1 || heroes[0].HeroID != 11 {
+			t.Fatalf("expected the YAML heroes, got %+v", heroes)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the second fetch to be cached, got %d requests", requests)
+	}
+}
+
+func TestFileHeroesProvider_NoSource(t *testing.T) {
+	provider := NewFileHeroesProvider(nil, func() string { return "" }, 0)
+	if _, err := provider.FetchHeroes("pos 1", "8"); !errors.Is(err, ErrNoFileSource) {
+		t.Errorf("expected ErrNoFileSource, got %v", err)
+	}
+}
+
+func TestValidateFileSource(t *testing.T) {
+	for source, valid := range map[string]bool{
+		"":                          true,
+		"https://example.com/tiers": true,
+		`C:\team\tiers.yml`:         true,
+		"/home/coach/tiers.CSV":     true,
+		"/home/coach/tiers.txt":     false,
+	} {
+		if err := ValidateFileSource(source); (err == nil) != valid {
+			t.Errorf("ValidateFileSource(%q) error = %v, expected valid %v", source, err, valid)
+		}
+	}
+}
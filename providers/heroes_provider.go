@@ -1,14 +1,37 @@
 package providers
 
 type HeroesProvider interface {
+	// Name identifies the provider in stored statistics, it must not change between releases
+	Name() string
 	FetchHeroes(position string, period string) ([]Hero, error)
 }
 
-// Hero represents a Dota 2 hero with its statistics
+// ChangeReporter is implemented by providers whose statistics can change outside of scheduled updates
+type ChangeReporter interface {
+	// Changed reports whether the statistics changed since they were last fetched
+	Changed() bool
+}
+
+// Hero represents a Dota 2 hero with its statistics. Matches is 0 when the source only rates heroes,
+// its wins and winrate are unknown then.
 type Hero struct {
 	HeroID     int    `json:"hero_id"`
 	Matches    int    `json:"matches"`
 	Wins       int    `json:"wins"`
 	HeroName   string `json:"hero_name"`
 	D2PTRating int    `json:"d2pt_rating"`
+	// Facet is the facet variant the stats are about, 0 when they cover every facet of the hero
+	Facet int `json:"facet"`
+	// Facets holds the stats of each facet of a hero merged by AggregateHeroesByID, ordered by facet
+	Facets []FacetStats `json:"-"`
+	// Sources holds the stats each source reported when the hero comes from a CompositeHeroesProvider
+	Sources []HeroSource `json:"sources,omitempty"`
+}
+
+// FacetStats are the statistics of one facet variant of a hero
+type FacetStats struct {
+	Facet      int `json:"facet"`
+	Matches    int `json:"matches"`
+	Wins       int `json:"wins"`
+	D2PTRating int `json:"d2pt_rating"`
 }
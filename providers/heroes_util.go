@@ -1,8 +1,15 @@
 package providers
 
-import "sort"
-
-// AggregateHeroesByID merges heroes with the same hero_id by summing wins and matches.
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// AggregateHeroesByID merges heroes with the same hero_id by summing wins and matches. Heroes with
+// neither matches nor a rating carry no statistics and are dropped.
+// The entries of a hero are its facet variants; their stats are kept in Facets of the merged hero.
+// Already merged heroes keep their Facets and Sources, so aggregating twice changes nothing.
 func AggregateHeroesByID(heroes []Hero) []Hero {
 	heroIdToAllInstances := make(map[int][]Hero)
 	aggregatedHeroMap := make(map[int]Hero)
@@ -25,13 +32,20 @@ func AggregateHeroesByID(heroes []Hero) []Hero {
 			aggregatedHero.Wins += hero.Wins
 		}
 
-		if aggregatedHero.Matches == 0 {
+		for _, hero := range mapHeroes {
+			// Heroes with unknown matches weigh the same, rating-only lists have nothing else
+			weight := 1 / float64(len(mapHeroes))
+			if aggregatedHero.Matches > 0 {
+				weight = float64(hero.Matches) / float64(aggregatedHero.Matches)
+			}
+			aggregatedHero.D2PTRating += int(float64(hero.D2PTRating) * weight)
+		}
+		if aggregatedHero.Matches == 0 && aggregatedHero.D2PTRating == 0 {
 			continue
 		}
-
+		aggregatedHero.Facets = aggregateFacets(mapHeroes)
 		for _, hero := range mapHeroes {
-			weight := float64(hero.Matches) / float64(aggregatedHero.Matches)
-			aggregatedHero.D2PTRating += int(float64(hero.D2PTRating) * weight)
+			aggregatedHero.Sources = append(aggregatedHero.Sources, hero.Sources...)
 		}
 
 		aggregatedHeroMap[heroId] = aggregatedHero
@@ -45,6 +59,89 @@ func AggregateHeroesByID(heroes []Hero) []Hero {
 	return result
 }
 
+// aggregateFacets merges the entries of one hero by facet, the same way AggregateHeroesByID merges
+// heroes. Entries without a facet contribute the facets they were merged from; nil is returned when
+// there are none.
+func aggregateFacets(entries []Hero) []FacetStats {
+	byFacet := make(map[int][]Hero)
+	for _, entry := range entries {
+		if entry.Facet > 0 {
+			byFacet[entry.Facet] = append(byFacet[entry.Facet], entry)
+			continue
+		}
+		for _, facet := range entry.Facets {
+			byFacet[facet.Facet] = append(byFacet[facet.Facet], Hero{}.WithFacet(facet))
+		}
+	}
+
+	var facets []FacetStats
+	for facet, facetEntries := range byFacet {
+		stats := FacetStats{Facet: facet}
+		for _, entry := range facetEntries {
+			stats.Matches += entry.Matches
+			stats.Wins += entry.Wins
+		}
+		if stats.Matches == 0 {
+			continue
+		}
+		for _, entry := range facetEntries {
+			stats.D2PTRating += int(float64(entry.D2PTRating) * float64(entry.Matches) / float64(stats.Matches))
+		}
+		facets = append(facets, stats)
+	}
+
+	slices.SortFunc(facets, func(a, b FacetStats) int {
+		return cmp.Compare(a.Facet, b.Facet)
+	})
+	return facets
+}
+
+// BestFacet returns the facet of a merged hero with the highest rating; more matches break ties
+func (h Hero) BestFacet() (FacetStats, bool) {
+	if len(h.Facets) == 0 {
+		return FacetStats{}, false
+	}
+	return slices.MaxFunc(h.Facets, func(a, b FacetStats) int {
+		return cmp.Or(cmp.Compare(a.D2PTRating, b.D2PTRating), cmp.Compare(a.Matches, b.Matches))
+	}), true
+}
+
+// WithFacet returns the hero with the stats of one of its facets
+func (h Hero) WithFacet(facet FacetStats) Hero {
+	h.Facet = facet.Facet
+	h.Matches = facet.Matches
+	h.Wins = facet.Wins
+	h.D2PTRating = facet.D2PTRating
+	return h
+}
+
+// BestFacetHeroes replaces every merged hero by its best facet; heroes without facet stats are kept as they are
+func BestFacetHeroes(heroes []Hero) []Hero {
+	result := make([]Hero, 0, len(heroes))
+	for _, hero := range heroes {
+		if best, ok := hero.BestFacet(); ok {
+			hero = hero.WithFacet(best)
+		}
+		result = append(result, hero)
+	}
+	return result
+}
+
+// SplitFacetHeroes replaces every merged hero by one hero per facet; heroes without facet stats are kept as they are
+func SplitFacetHeroes(heroes []Hero) []Hero {
+	result := make([]Hero, 0, len(heroes))
+	for _, hero := range heroes {
+		if len(hero.Facets) == 0 {
+			result = append(result, hero)
+			continue
+		}
+		for _, facet := range hero.Facets {
+			result = append(result, hero.WithFacet(facet))
+		}
+	}
+	return result
+}
+
 // GetTopHeroesByRating returns the top N heroes by d2pt_rating
 func GetTopHeroesByRating(heroes []Hero, n int) []Hero {
 	// Create a copy to avoid modifying the original slice
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of matchup statistics
+const (
+	// MatchupVersus are games where the other hero played against the hero
+	MatchupVersus = "versus"
+	// MatchupWith are games where the other hero played on the same team as the hero
+	MatchupWith = "with"
+)
+
+// ErrMatchupKindUnsupported is returned by providers that have no data of the requested kind
+var ErrMatchupKindUnsupported = errors.New("matchup kind not supported by the provider")
+
+// MatchupsProvider returns how heroes fare against or alongside a hero
+type MatchupsProvider interface {
+	// Name identifies the provider in logs and settings
+	Name() string
+	FetchMatchups(heroID int, kind string) ([]Matchup, error)
+}
+
+// Matchup is the record of another hero against or alongside the hero the matchups were fetched for
+type Matchup struct {
+	HeroID  int `json:"hero_id"` // the other hero
+	Matches int `json:"matches"`
+	Wins    int `json:"wins"` // matches won by the other hero
+}
+
+// StaticMatchupsProvider serves matchups from memory, for tests and offline use
+type StaticMatchupsProvider struct {
+	versus map[int][]Matchup
+	with   map[int][]Matchup
+}
+
+func NewStaticMatchupsProvider(versus map[int][]Matchup, with map[int][]Matchup) *StaticMatchupsProvider {
+	return &StaticMatchupsProvider{versus: versus, with: with}
+}
+
+func (p *StaticMatchupsProvider) Name() string {
+	return "static"
+}
+
+func (p *StaticMatchupsProvider) FetchMatchups(heroID int, kind string) ([]Matchup, error) {
+	switch kind {
+	case MatchupVersus:
+		return p.versus[heroID], nil
+	case MatchupWith:
+		return p.with[heroID], nil
+	default:
+		return nil, fmt.Errorf("unknown matchup kind %q", kind)
+	}
+}
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// OpenDotaProviderName is the name of the OpenDota heroes and matchups providers
+	OpenDotaProviderName = "opendota"
+
+	apiOpenDotaUrl = "https://api.opendota.com/api"
+)
+
+// openDotaExplorerResult is the response of OpenDota's SQL explorer
+type openDotaExplorerResult struct {
+	Rows json.RawMessage `json:"rows"`
+	Err  any             `json:"err"`
+}
+
+// openDotaClient requests the public OpenDota API for the OpenDota providers
+type openDotaClient struct {
+	httpClient *http.Client
+	apiUrl     string
+}
+
+func newOpenDotaClient(httpClient *http.Client, apiUrl string) openDotaClient {
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 30 * time.Second,
+		}
+	}
+	return openDotaClient{httpClient: httpClient, apiUrl: apiUrl}
+}
+
+func (c openDotaClient) baseUrl() string {
+	if apiUrl := strings.TrimRight(c.apiUrl, "/"); apiUrl != "" {
+		return apiUrl
+	}
+	return apiOpenDotaUrl
+}
+
+// get requests a JSON document by its path below the API URL, filling the URL, status and size of the diagnostics
+func (c openDotaClient) get(path string, diagnostics *FetchDiagnostics) ([]byte, error) {
+	requestUrl := c.baseUrl() + path
+	diagnostics.URL = RedactURL(requestUrl)
+
+	req, err := http.NewRequest("GET", requestUrl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching data: %w", err)
+	}
+	defer resp.Body.Close()
+	diagnostics.StatusCode = resp.StatusCode
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	diagnostics.PayloadBytes = len(body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	return body, nil
+}
+
+// explore runs a query on the SQL explorer, which covers the professional matches OpenDota parsed,
+// and unmarshals the result rows into rows
+func (c openDotaClient) explore(query string, rows any, diagnostics *FetchDiagnostics) error {
+	body, err := c.get("/explorer?sql="+url.QueryEscape(query), diagnostics)
+	if err != nil {
+		return err
+	}
+
+	var result openDotaExplorerResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+	if result.Err != nil {
+		return fmt.Errorf("explorer query failed: %v", result.Err)
+	}
+	if len(result.Rows) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result.Rows, rows); err != nil {
+		return fmt.Errorf("error unmarshaling explorer rows: %w", err)
+	}
+	return nil
+}
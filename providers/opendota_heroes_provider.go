@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// openDotaRatingScale is the rating of the best hero of a position, OpenDota has no rating of its own
+const openDotaRatingScale = 1000
+
+// openDotaHeroesQuery counts the matches and wins of every hero in every position. The position of a
+// player is their farm priority: the rank of their gold per minute on their team.
+const openDotaHeroesQuery = `SELECT hero_id, position::int, count(*)::int AS matches, sum(win)::int AS wins
+FROM (
+  SELECT player_matches.hero_id,
+    row_number() OVER (PARTITION BY player_matches.match_id, player_matches.player_slot < 128 ORDER BY player_matches.gold_per_min DESC) AS position,
+    CASE WHEN (player_matches.player_slot < 128) = matches.radiant_win THEN 1 ELSE 0 END AS win
+  FROM player_matches
+  JOIN matches ON matches.match_id = player_matches.match_id
+  WHERE %s
+) ranked
+GROUP BY hero_id, position`
+
+// openDotaPeriodFilters select the matches of the D2PT periods
+var openDotaPeriodFilters = map[string]string{
+	period8Days: "matches.start_time >= extract(epoch from now() - interval '8 days')",
+	periodPatch: "matches.match_id IN (SELECT match_id FROM match_patch WHERE patch = (SELECT patch FROM match_patch ORDER BY match_id DESC LIMIT 1))",
+}
+
+// openDotaHeroRow is a row of openDotaHeroesQuery
+type openDotaHeroRow struct {
+	HeroID   int `json:"hero_id"`
+	Position int `json:"position"`
+	Matches  int `json:"matches"`
+	Wins     int `json:"wins"`
+}
+
+type openDotaHeroesCacheEntry struct {
+	positionHeroes map[string][]Hero
+	fetchedAt      time.Time
+}
+
+// OpenDotaHeroesProvider reads hero stats of the professional matches OpenDota parsed, with its SQL
+// explorer, so grids can fall back to OpenDota when Dota 2 Pro Tracker is down. Positions are farm
+// priorities, which can swap supports, and the rating is the Composite ranking score of the position
+// scaled to 0 to openDotaRatingScale. One request returns every position of a period.
+type OpenDotaHeroesProvider struct {
+	client openDotaClient
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]openDotaHeroesCacheEntry // by period
+
+	diagnostics diagnosticsLog
+}
+
+func NewOpenDotaHeroesProvider(httpClient *http.Client, apiUrl string, ttl time.Duration) *OpenDotaHeroesProvider {
+	return &OpenDotaHeroesProvider{
+		client: newOpenDotaClient(httpClient, apiUrl),
+		ttl:    ttl,
+		cache:  make(map[string]openDotaHeroesCacheEntry),
+	}
+}
+
+func (p *OpenDotaHeroesProvider) Name() string {
+	return OpenDotaProviderName
+}
+
+// Diagnostics returns the recent requests, newest first
+func (p *OpenDotaHeroesProvider) Diagnostics() []FetchDiagnostics {
+	return p.diagnostics.list()
+}
+
+func (p *OpenDotaHeroesProvider) FetchHeroes(position string, period string) ([]Hero, error) {
+	started := time.Now()
+	diagnostics := FetchDiagnostics{Provider: OpenDotaProviderName, Request: heroesRequest(position, period)}
+
+	heroes, err := p.fetchPosition(position, period, &diagnostics)
+	p.diagnostics.recordHeroes(diagnostics, started, heroes, err)
+	return heroes, err
+}
+
+func (p *OpenDotaHeroesProvider) fetchPosition(position string, period string, diagnostics *FetchDiagnostics) ([]Hero, error) {
+	if period == "" {
+		period = period8Days
+	}
+	filter, ok := openDotaPeriodFilters[period]
+	if !ok {
+		return nil, fmt.Errorf("invalid period value: %s", period)
+	}
+	position = strings.ToLower(strings.TrimSpace(position))
+
+	// Positions of a period are fetched together, so the lock keeps parallel fetches from repeating the query
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.cache[period]
+	if ok && p.ttl > 0 && time.Since(entry.fetchedAt) < p.ttl {
+		diagnostics.CacheHit = true
+	} else {
+		var rows []openDotaHeroRow
+		err := p.client.explore(fmt.Sprintf(openDotaHeroesQuery, filter), &rows, diagnostics)
+		switch {
+		case err == nil:
+			entry = openDotaHeroesCacheEntry{positionHeroes: openDotaPositionHeroes(rows), fetchedAt: time.Now()}
+			p.cache[period] = entry
+		case ok:
+			// The explorer times out and throttles often, the last stats are better than none
+			diagnostics.CacheHit = true
+			diagnostics.Warnings = append(diagnostics.Warnings,
+				fmt.Sprintf("request failed, using the stats of %s: %v", entry.fetchedAt.Format(time.DateTime), err))
+		default:
+			return nil, err
+		}
+	}
+
+	heroes, ok := entry.positionHeroes[position]
+	if !ok {
+		return nil, fmt.Errorf("no heroes for position %q", position)
+	}
+	return heroes, nil
+}
+
+// openDotaPositionHeroes groups the query rows by position and rates the heroes of each position
+func openDotaPositionHeroes(rows []openDotaHeroRow) map[string][]Hero {
+	result := make(map[string][]Hero)
+	for _, row := range rows {
+		if row.Position < 1 || row.Position > 5 {
+			continue
+		}
+		key := "pos " + strconv.Itoa(row.Position)
+		result[key] = append(result[key], Hero{HeroID: row.HeroID, Matches: row.Matches, Wins: row.Wins})
+	}
+
+	for _, heroes := range result {
+		for i, score := range compositeScores(heroes) {
+			heroes[i].D2PTRating = int(math.Round(score * openDotaRatingScale))
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOpenDotaHeroesProvider_FetchHeroes(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/explorer" {
+			t.Errorf("expected path /explorer, got %s", r.URL.Path)
+		}
+		if sql := r.URL.Query().Get("sql"); !strings.Contains(sql, "interval '8 days'") {
+			t.Errorf("expected a query of the last 8 days, got %q", sql)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"rows": [
+			{"hero_id": 1, "position": 1, "matches": 200, "wins": 120},
+			{"hero_id": 2, "position": 1, "matches": 100, "wins": 40},
+			{"hero_id": 5, "position": 5, "matches": 50, "wins": 25},
+			{"hero_id": 6, "position": 6, "matches": 1, "wins": 1}
+		], "err": null}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaHeroesProvider(nil, server.URL, time.Minute)
+
+	heroes, err := provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(heroes) != 2 || heroes[0].HeroID != 1 || heroes[0].Matches != 200 || heroes[0].Wins != 120 {
+		t.Fatalf("expected the heroes of position 1, got %+v", heroes)
+	}
+	// The better and more played hero gets the whole scale, the other one none of it
+	if heroes[0].D2PTRating != openDotaRatingScale || heroes[1].D2PTRating != 0 {
+		t.Errorf("expected ratings %d and 0, got %d and %d", openDotaRatingScale, heroes[0].D2PTRating, heroes[1].D2PTRating)
+	}
+
+	// Other positions of the period come from the same request
+	heroes, err = provider.FetchHeroes("pos 5", "8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(heroes) != 1 || heroes[0].HeroID != 5 {
+		t.Errorf("expected the heroes of position 5, got %+v", heroes)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected one request for every position, got %d", requests.Load())
+	}
+
+	if _, err := provider.FetchHeroes("pos 3", "8"); err == nil {
+		t.Error("expected an error for a position without heroes")
+	}
+}
+
+func TestOpenDotaHeroesProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("sql"), "match_patch") {
+			w.Write([]byte(`{"rows": [], "err": "statement timeout"}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaHeroesProvider(nil, server.URL, 0)
+
+	if _, err := provider.FetchHeroes("pos 1", "patch"); err == nil || !strings.Contains(err.Error(), "statement timeout") {
+		t.Errorf("expected the explorer error, got %v", err)
+	}
+	if _, err := provider.FetchHeroes("pos 1", "8"); err == nil {
+		t.Error("expected an error for a 503 response")
+	}
+	if _, err := provider.FetchHeroes("pos 1", "30"); err == nil {
+		t.Error("expected an error for an unknown period")
+	}
+	if diagnostics := provider.Diagnostics(); len(diagnostics) != 3 || diagnostics[1].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the failed requests in the diagnostics, got %+v", diagnostics)
+	}
+}
+
+func TestOpenDotaHeroesProvider_KeepsStatsOnFailure(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"rows": [{"hero_id": 1, "position": 1, "matches": 200, "wins": 120}], "err": null}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaHeroesProvider(nil, server.URL, 0)
+	if _, err := provider.FetchHeroes("pos 1", "8"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	failing.Store(true)
+	heroes, err := provider.FetchHeroes("pos 1", "8")
+	if err != nil {
+		t.Fatalf("expected the last stats, got %v", err)
+	}
+	if len(heroes) != 1 || heroes[0].HeroID != 1 {
+		t.Errorf("expected the last stats, got %+v", heroes)
+	}
+	if diagnostics := provider.Diagnostics()[0]; !diagnostics.CacheHit || len(diagnostics.Warnings) == 0 {
+		t.Errorf("expected a cache hit with a warning, got %+v", diagnostics)
+	}
+}
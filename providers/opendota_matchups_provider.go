@@ -0,0 +1,185 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// openDotaWithDays is the window of the matches teammate records are counted over
+	openDotaWithDays = 90
+	// openDotaWithTTL is the shortest time teammate records are cached. Their query is heavy for the
+	// rate-limited SQL explorer, and a day changes little in 90 days of matches.
+	openDotaWithTTL = 24 * time.Hour
+)
+
+// openDotaWithQuery counts, for every other hero, the matches it played on the same team as the
+// hero and the matches that team won. It runs on the match data behind OpenDota's hero matchups,
+// which are versus records of the same matches.
+const openDotaWithQuery = `SELECT other.hero_id,
+  count(*)::int AS games_played,
+  sum(CASE WHEN (other.player_slot < 128) = matches.radiant_win THEN 1 ELSE 0 END)::int AS wins
+FROM player_matches ref
+JOIN player_matches other ON other.match_id = ref.match_id
+  AND other.hero_id != ref.hero_id
+  AND (other.player_slot < 128) = (ref.player_slot < 128)
+JOIN matches ON matches.match_id = ref.match_id
+WHERE ref.hero_id = %d
+  AND matches.start_time >= extract(epoch from now() - interval '%d days')
+GROUP BY other.hero_id`
+
+// openDotaMatchup is an entry of OpenDota's hero matchups, where wins are the wins of the requested
+// hero, or a row of openDotaWithQuery, where they are the wins of the team of both heroes
+type openDotaMatchup struct {
+	HeroID      int `json:"hero_id"`
+	GamesPlayed int `json:"games_played"`
+	Wins        int `json:"wins"`
+}
+
+type matchupsCacheKey struct {
+	heroID int
+	kind   string
+}
+
+type matchupsCacheEntry struct {
+	matchups  []Matchup
+	fetchedAt time.Time
+}
+
+// OpenDotaMatchupsProvider reads hero records from the public OpenDota API: versus records from
+// its hero matchups, and teammate records aggregated from the same matches with its SQL explorer,
+// as OpenDota has no endpoint for heroes on the same team.
+type OpenDotaMatchupsProvider struct {
+	client openDotaClient
+	ttl    time.Duration
+
+	mu    sync.RWMutex
+	cache map[matchupsCacheKey]matchupsCacheEntry
+
+	diagnostics diagnosticsLog
+}
+
+func NewOpenDotaMatchupsProvider(httpClient *http.Client, apiUrl string, ttl time.Duration) *OpenDotaMatchupsProvider {
+	return &OpenDotaMatchupsProvider{
+		client: newOpenDotaClient(httpClient, apiUrl),
+		ttl:    ttl,
+		cache:  make(map[matchupsCacheKey]matchupsCacheEntry),
+	}
+}
+
+func (p *OpenDotaMatchupsProvider) Name() string {
+	return OpenDotaProviderName
+}
+
+// Diagnostics returns the recent requests, newest first
+func (p *OpenDotaMatchupsProvider) Diagnostics() []FetchDiagnostics {
+	return p.diagnostics.list()
+}
+
+func (p *OpenDotaMatchupsProvider) FetchMatchups(heroID int, kind string) ([]Matchup, error) {
+	if kind != MatchupVersus && kind != MatchupWith {
+		return nil, fmt.Errorf("%w: %s has no %q matchups", ErrMatchupKindUnsupported, OpenDotaProviderName, kind)
+	}
+
+	started := time.Now()
+	diagnostics := FetchDiagnostics{Provider: OpenDotaProviderName, Request: fmt.Sprintf("hero %d, %s", heroID, kind)}
+	cacheKey := matchupsCacheKey{heroID: heroID, kind: kind}
+
+	ttl := p.cacheTTL(kind)
+	p.mu.RLock()
+	cached, hasCached := p.cache[cacheKey]
+	p.mu.RUnlock()
+	if hasCached && time.Since(cached.fetchedAt) < ttl {
+		diagnostics.CacheHit = true
+		diagnostics.HeroCount = len(cached.matchups)
+		p.diagnostics.record(diagnostics, started, nil)
+		return cached.matchups, nil
+	}
+
+	var matchups []Matchup
+	var err error
+	if kind == MatchupWith {
+		matchups, err = p.fetchWithFromAPI(heroID, &diagnostics)
+	} else {
+		matchups, err = p.fetchFromAPI(heroID, &diagnostics)
+	}
+	if err != nil && hasCached {
+		// A throttled or timed out request keeps the last records rather than dropping the section
+		diagnostics.CacheHit = true
+		diagnostics.HeroCount = len(cached.matchups)
+		diagnostics.Warnings = append(diagnostics.Warnings,
+			fmt.Sprintf("request failed, using the matchups of %s: %v", cached.fetchedAt.Format(time.DateTime), err))
+		p.diagnostics.record(diagnostics, started, nil)
+		return cached.matchups, nil
+	}
+	diagnostics.HeroCount = len(matchups)
+	if err == nil && len(matchups) == 0 {
+		diagnostics.Warnings = append(diagnostics.Warnings, "no matchups returned")
+	}
+	p.diagnostics.record(diagnostics, started, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		p.mu.Lock()
+		p.cache[cacheKey] = matchupsCacheEntry{matchups: matchups, fetchedAt: time.Now()}
+		p.mu.Unlock()
+	}
+
+	return matchups, nil
+}
+
+// cacheTTL returns how long matchups of a kind are cached; caching stays off when the provider's TTL is 0
+func (p *OpenDotaMatchupsProvider) cacheTTL(kind string) time.Duration {
+	if kind == MatchupWith && p.ttl > 0 {
+		return max(p.ttl, openDotaWithTTL)
+	}
+	return p.ttl
+}
+
+// fetchFromAPI requests the versus matchups of a hero, filling the URL, status and size of the diagnostics
+func (p *OpenDotaMatchupsProvider) fetchFromAPI(heroID int, diagnostics *FetchDiagnostics) ([]Matchup, error) {
+	body, err := p.client.get(fmt.Sprintf("/heroes/%d/matchups", heroID), diagnostics)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []openDotaMatchup
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	// Matchups are reported from the other hero's side
+	matchups := make([]Matchup, 0, len(entries))
+	for _, entry := range entries {
+		matchups = append(matchups, Matchup{
+			HeroID:  entry.HeroID,
+			Matches: entry.GamesPlayed,
+			Wins:    entry.GamesPlayed - entry.Wins,
+		})
+	}
+	return matchups, nil
+}
+
+// fetchWithFromAPI runs openDotaWithQuery for a hero on the SQL explorer
+func (p *OpenDotaMatchupsProvider) fetchWithFromAPI(heroID int, diagnostics *FetchDiagnostics) ([]Matchup, error) {
+	var rows []openDotaMatchup
+	if err := p.client.explore(fmt.Sprintf(openDotaWithQuery, heroID, openDotaWithDays), &rows, diagnostics); err != nil {
+		return nil, err
+	}
+
+	// Both heroes won or lost together, so the wins are the other hero's as well
+	matchups := make([]Matchup, 0, len(rows))
+	for _, row := range rows {
+		matchups = append(matchups, Matchup{
+			HeroID:  row.HeroID,
+			Matches: row.GamesPlayed,
+			Wins:    row.Wins,
+		})
+	}
+	return matchups, nil
+}
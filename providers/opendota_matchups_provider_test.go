@@ -0,0 +1,169 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOpenDotaMatchupsProvider_FetchMatchups(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/heroes/1/matchups" {
+			t.Errorf("expected path /heroes/1/matchups, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"hero_id": 2, "games_played": 100, "wins": 40}, {"hero_id": 3, "games_played": 10, "wins": 10}]`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaMatchupsProvider(nil, server.URL, time.Minute)
+
+	matchups, err := provider.FetchMatchups(1, MatchupVersus)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Matchup{{HeroID: 2, Matches: 100, Wins: 60}, {HeroID: 3, Matches: 10, Wins: 0}}
+	if len(matchups) != len(expected) || matchups[0] != expected[0] || matchups[1] != expected[1] {
+		t.Errorf("expected wins of the other hero %+v, got %+v", expected, matchups)
+	}
+
+	if _, err := provider.FetchMatchups(1, MatchupVersus); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected the second fetch to be cached, got %d requests", requests.Load())
+	}
+}
+
+func TestOpenDotaMatchupsProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaMatchupsProvider(nil, server.URL, 0)
+
+	if _, err := provider.FetchMatchups(1, MatchupVersus); err == nil {
+		t.Error("expected error for 429 response")
+	}
+	if _, err := provider.FetchMatchups(1, "enemies"); !errors.Is(err, ErrMatchupKindUnsupported) {
+		t.Errorf("expected ErrMatchupKindUnsupported, got %v", err)
+	}
+}
+
+func TestOpenDotaMatchupsProvider_FetchWithMatchups(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/explorer" {
+			t.Errorf("expected path /explorer, got %s", r.URL.Path)
+		}
+		if sql := r.URL.Query().Get("sql"); !strings.Contains(sql, "WHERE ref.hero_id = 1") {
+			t.Errorf("expected a query for hero 1, got %q", sql)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"command": "SELECT", "rowCount": 2, "rows": [{"hero_id": 5, "games_played": 40, "wins": 24}, {"hero_id": 7, "games_played": 12, "wins": 3}], "err": null}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaMatchupsProvider(nil, server.URL, time.Minute)
+
+	matchups, err := provider.FetchMatchups(1, MatchupWith)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Matchup{{HeroID: 5, Matches: 40, Wins: 24}, {HeroID: 7, Matches: 12, Wins: 3}}
+	if len(matchups) != len(expected) || matchups[0] != expected[0] || matchups[1] != expected[1] {
+		t.Errorf("expected the wins of the shared team %+v, got %+v", expected, matchups)
+	}
+
+	// The second fetch is served from the cache
+	if _, err := provider.FetchMatchups(1, MatchupWith); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected the second fetch to be cached, got %d requests", requests.Load())
+	}
+}
+
+func TestOpenDotaMatchupsProvider_ExplorerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"rows": [], "err": "statement timeout"}`))
+	}))
+	defer server.Close()
+
+	provider := NewOpenDotaMatchupsProvider(nil, server.URL, 0)
+
+	if _, err := provider.FetchMatchups(1, MatchupWith); err == nil || !strings.Contains(err.Error(), "statement timeout") {
+		t.Errorf("expected the explorer error, got %v", err)
+	}
+}
+
+func TestOpenDotaMatchupsProvider_CacheAge(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.URL.Path == "/explorer" {
+			w.Write([]byte(`{"rows": [{"hero_id": 5, "games_played": 40, "wins": 24}], "err": null}`))
+			return
+		}
+		w.Write([]byte(`[{"hero_id": 2, "games_played": 100, "wins": 40}]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		kind         string
+		age          time.Duration
+		failing      bool
+		wantRequests int32
+		wantWarning  bool
+	}{
+		{name: "versus records expire with the ttl", kind: MatchupVersus, age: 2 * time.Hour, wantRequests: 1},
+		{name: "teammate records are kept for a day", kind: MatchupWith, age: 2 * time.Hour, wantRequests: 0},
+		{name: "teammate records expire after a day", kind: MatchupWith, age: 25 * time.Hour, wantRequests: 1},
+		{name: "failed requests keep expired records", kind: MatchupWith, age: 25 * time.Hour, failing: true, wantRequests: 1, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewOpenDotaMatchupsProvider(nil, server.URL, time.Hour)
+			failing.Store(false)
+			expected, err := provider.FetchMatchups(1, tt.kind)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			key := matchupsCacheKey{heroID: 1, kind: tt.kind}
+			provider.cache[key] = matchupsCacheEntry{matchups: expected, fetchedAt: time.Now().Add(-tt.age)}
+			failing.Store(tt.failing)
+			requests.Store(0)
+
+			matchups, err := provider.FetchMatchups(1, tt.kind)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(matchups) != len(expected) || matchups[0] != expected[0] {
+				t.Errorf("expected matchups %+v, got %+v", expected, matchups)
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, requests.Load())
+			}
+			diagnostics := provider.Diagnostics()[0]
+			if hasWarning := len(diagnostics.Warnings) > 0; hasWarning != tt.wantWarning || diagnostics.Error != "" {
+				t.Errorf("expected warning %v and no error, got %+v", tt.wantWarning, diagnostics)
+			}
+		})
+	}
+}
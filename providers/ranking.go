@@ -0,0 +1,223 @@
+package providers
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Ranking IDs registered by default
+const (
+	RankingRating    = "rating"
+	RankingMatches   = "matches"
+	RankingWinrate   = "winrate"
+	RankingWilson    = "wilson"
+	RankingBayesian  = "bayesian"
+	RankingComposite = "composite"
+)
+
+const (
+	// wilsonZ is the z-score for a 95% confidence interval
+	wilsonZ = 1.96
+	// compositeWinrateWeight is the share of the composite score given to the smoothed winrate;
+	// the rest goes to the pick rate
+	compositeWinrateWeight = 0.75
+)
+
+// ScoreFunc scores every hero of a pool; higher scores rank first.
+// The whole pool is passed so scores can depend on pool-wide statistics.
+type ScoreFunc func(heroes []Hero) []float64
+
+// Ranking is a named way to order heroes
+type Ranking struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Score       ScoreFunc `json:"-"`
+}
+
+var (
+	rankingsMu sync.RWMutex
+	rankings   = map[string]Ranking{}
+)
+
+func init() {
+	RegisterRanking(Ranking{
+		ID:          RankingRating,
+		Name:        "D2PT Rating",
+		Description: "Rating reported by the stats provider",
+		Score:       perHeroScore(func(hero Hero) float64 { return float64(hero.D2PTRating) }),
+	})
+	RegisterRanking(Ranking{
+		ID:          RankingMatches,
+		Name:        "Matches",
+		Description: "Number of matches played",
+		Score:       perHeroScore(func(hero Hero) float64 { return float64(hero.Matches) }),
+	})
+	RegisterRanking(Ranking{
+		ID:          RankingWinrate,
+		Name:        "Winrate",
+		Description: "Raw winrate, unreliable for heroes with few matches",
+		Score:       perHeroScore(Winrate),
+	})
+	RegisterRanking(Ranking{
+		ID:          RankingWilson,
+		Name:        "Winrate (Wilson lower bound)",
+		Description: "Lower bound of the 95% confidence interval of the winrate, penalizes small samples",
+		Score:       perHeroScore(WilsonLowerBound),
+	})
+	RegisterRanking(Ranking{
+		ID:          RankingBayesian,
+		Name:        "Winrate (Bayesian)",
+		Description: "Winrate smoothed towards the average of all heroes of the position",
+		Score:       bayesianScores,
+	})
+	RegisterRanking(Ranking{
+		ID:          RankingComposite,
+		Name:        "Composite",
+		Description: "Smoothed winrate weighted with pick rate",
+		Score:       compositeScores,
+	})
+}
+
+// RegisterRanking adds a ranking to the registry, replacing any ranking with the same ID
+func RegisterRanking(ranking Ranking) {
+	rankingsMu.Lock()
+	defer rankingsMu.Unlock()
+	rankings[ranking.ID] = ranking
+}
+
+// GetRanking returns the ranking registered under id
+func GetRanking(id string) (Ranking, bool) {
+	rankingsMu.RLock()
+	defer rankingsMu.RUnlock()
+	ranking, ok := rankings[id]
+	return ranking, ok
+}
+
+// GetRankings returns all registered rankings sorted by ID
+func GetRankings() []Ranking {
+	rankingsMu.RLock()
+	defer rankingsMu.RUnlock()
+
+	result := make([]Ranking, 0, len(rankings))
+	for _, ranking := range rankings {
+		result = append(result, ranking)
+	}
+	slices.SortFunc(result, func(a, b Ranking) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+// RankHeroes returns the top N heroes ordered by the ranking with the given ID.
+// Heroes with equal scores keep their original order.
+func RankHeroes(heroes []Hero, rankingID string, n int) ([]Hero, error) {
+	ranking, ok := GetRanking(rankingID)
+	if !ok {
+		return nil, fmt.Errorf("unknown ranking %q", rankingID)
+	}
+
+	scores := ranking.Score(heroes)
+
+	indices := make([]int, len(heroes))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(a, b int) int {
+		return cmp.Compare(scores[b], scores[a])
+	})
+
+	result := make([]Hero, 0, min(n, len(heroes)))
+	for _, i := range indices[:min(n, len(indices))] {
+		result = append(result, heroes[i])
+	}
+	return result, nil
+}
+
+// Winrate returns the share of won matches between 0 and 1
+func Winrate(hero Hero) float64 {
+	if hero.Matches <= 0 {
+		return 0
+	}
+	return float64(hero.Wins) / float64(hero.Matches)
+}
+
+// WilsonLowerBound returns the lower bound of the Wilson score interval of the winrate
+func WilsonLowerBound(hero Hero) float64 {
+	if hero.Matches <= 0 {
+		return 0
+	}
+
+	n := float64(hero.Matches)
+	p := Winrate(hero)
+	z2 := wilsonZ * wilsonZ
+
+	center := p + z2/(2*n)
+	margin := wilsonZ * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return (center - margin) / (1 + z2/n)
+}
+
+func perHeroScore(score func(hero Hero) float64) ScoreFunc {
+	return func(heroes []Hero) []float64 {
+		scores := make([]float64, len(heroes))
+		for i, hero := range heroes {
+			scores[i] = score(hero)
+		}
+		return scores
+	}
+}
+
+// bayesianScores smooths each winrate towards the pool winrate, using the
+// average number of matches per hero as the weight of the prior
+func bayesianScores(heroes []Hero) []float64 {
+	totalMatches, totalWins := 0, 0
+	for _, hero := range heroes {
+		totalMatches += max(hero.Matches, 0)
+		totalWins += max(hero.Wins, 0)
+	}
+
+	scores := make([]float64, len(heroes))
+	if totalMatches == 0 {
+		return scores
+	}
+
+	priorWinrate := float64(totalWins) / float64(totalMatches)
+	priorMatches := float64(totalMatches) / float64(len(heroes))
+
+	for i, hero := range heroes {
+		scores[i] = (float64(hero.Wins) + priorWinrate*priorMatches) / (float64(hero.Matches) + priorMatches)
+	}
+	return scores
+}
+
+// compositeScores mixes the smoothed winrate and the pick rate, both scaled to [0, 1] within the pool
+func compositeScores(heroes []Hero) []float64 {
+	winrates := normalizeScores(bayesianScores(heroes))
+	pickRates := normalizeScores(perHeroScore(func(hero Hero) float64 { return float64(hero.Matches) })(heroes))
+
+	scores := make([]float64, len(heroes))
+	for i := range heroes {
+		scores[i] = compositeWinrateWeight*winrates[i] + (1-compositeWinrateWeight)*pickRates[i]
+	}
+	return scores
+}
+
+// normalizeScores applies min-max scaling; equal scores all become 0
+func normalizeScores(scores []float64) []float64 {
+	if len(scores) == 0 {
+		return scores
+	}
+
+	lo, hi := slices.Min(scores), slices.Max(scores)
+	result := make([]float64, len(scores))
+	if hi == lo {
+		return result
+	}
+	for i, score := range scores {
+		result[i] = (score - lo) / (hi - lo)
+	}
+	return result
+}
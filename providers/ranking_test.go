@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"math"
+	"testing"
+)
+
+func heroIDs(heroes []Hero) []int {
+	ids := make([]int, len(heroes))
+	for i, hero := range heroes {
+		ids[i] = hero.HeroID
+	}
+	return ids
+}
+
+func TestRankHeroes(t *testing.T) {
+	heroes := []Hero{
+		{HeroID: 1, Matches: 2, Wins: 2, D2PTRating: 100},       // 100% on a tiny sample
+		{HeroID: 2, Matches: 1000, Wins: 560, D2PTRating: 300},  // 56% on a large sample
+		{HeroID: 3, Matches: 400, Wins: 200, D2PTRating: 200},   // 50%
+		{HeroID: 4, Matches: 3000, Wins: 1380, D2PTRating: 150}, // 46%, very popular
+	}
+
+	tests := []struct {
+		ranking  string
+		expected []int
+	}{
+		{RankingRating, []int{2, 3, 4, 1}},
+		{RankingMatches, []int{4, 2, 3, 1}},
+		{RankingWinrate, []int{1, 2, 3, 4}},
+		{RankingWilson, []int{2, 3, 4, 1}},
+		{RankingBayesian, []int{2, 3, 1, 4}},
+		{RankingComposite, []int{2, 3, 1, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ranking, func(t *testing.T) {
+			ranked, err := RankHeroes(heroes, tt.ranking, len(heroes))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := heroIDs(ranked)
+			for i := range tt.expected {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected order %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRankHeroes_TopN(t *testing.T) {
+	heroes := []Hero{
+		{HeroID: 1, Matches: 10},
+		{HeroID: 2, Matches: 30},
+		{HeroID: 3, Matches: 20},
+	}
+
+	ranked, err := RankHeroes(heroes, RankingMatches, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].HeroID != 2 || ranked[1].HeroID != 3 {
+		t.Errorf("expected heroes [2 3], got %v", heroIDs(ranked))
+	}
+
+	ranked, _ = RankHeroes(heroes, RankingMatches, 10)
+	if len(ranked) != 3 {
+		t.Errorf("expected all 3 heroes when N exceeds pool size, got %d", len(ranked))
+	}
+}
+
+func TestRankHeroes_StableForEqualScores(t *testing.T) {
+	heroes := []Hero{
+		{HeroID: 5, Matches: 10},
+		{HeroID: 3, Matches: 10},
+		{HeroID: 9, Matches: 10},
+	}
+
+	ranked, _ := RankHeroes(heroes, RankingMatches, 3)
+	got := heroIDs(ranked)
+	if got[0] != 5 || got[1] != 3 || got[2] != 9 {
+		t.Errorf("expected original order for equal scores, got %v", got)
+	}
+}
+
+func TestRankHeroes_UnknownRanking(t *testing.T) {
+	if _, err := RankHeroes([]Hero{{HeroID: 1}}, "popularity", 1); err == nil {
+		t.Error("expected error for unknown ranking")
+	}
+}
+
+func TestRankHeroes_EmptyPool(t *testing.T) {
+	for _, ranking := range GetRankings() {
+		ranked, err := RankHeroes(nil, ranking.ID, 10)
+		if err != nil || len(ranked) != 0 {
+			t.Errorf("%s: expected empty result, got %v (err %v)", ranking.ID, ranked, err)
+		}
+	}
+}
+
+func TestWilsonLowerBound(t *testing.T) {
+	tests := []struct {
+		name     string
+		hero     Hero
+		expected float64
+	}{
+		{"no matches", Hero{Matches: 0, Wins: 0}, 0},
+		{"all wins small sample", Hero{Matches: 2, Wins: 2}, 0.3424},
+		{"half wins large sample", Hero{Matches: 1000, Wins: 500}, 0.4691},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WilsonLowerBound(tt.hero)
+			if math.Abs(got-tt.expected) > 0.0001 {
+				t.Errorf("expected %.4f, got %.4f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRegisterRanking(t *testing.T) {
+	RegisterRanking(Ranking{
+		ID:    "test-hero-id",
+		Name:  "Hero ID",
+		Score: perHeroScore(func(hero Hero) float64 { return float64(hero.HeroID) }),
+	})
+	defer func() {
+		rankingsMu.Lock()
+		delete(rankings, "test-hero-id")
+		rankingsMu.Unlock()
+	}()
+
+	ranked, err := RankHeroes([]Hero{{HeroID: 1}, {HeroID: 7}, {HeroID: 3}}, "test-hero-id", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := heroIDs(ranked); got[0] != 7 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("expected [7 3 1], got %v", got)
+	}
+}
+
+func TestRankHeroes_CompositeFavorsPopularHeroes(t *testing.T) {
+	heroes := []Hero{
+		{HeroID: 1, Matches: 5000, Wins: 2600}, // 52%, picked a lot
+		{HeroID: 2, Matches: 500, Wins: 280},   // 56%, rarely picked
+		{HeroID: 3, Matches: 500, Wins: 200},   // 40%
+	}
+
+	bayesian, _ := RankHeroes(heroes, RankingBayesian, 1)
+	if bayesian[0].HeroID != 2 {
+		t.Errorf("expected bayesian ranking to prefer the higher winrate, got hero %d", bayesian[0].HeroID)
+	}
+
+	composite, _ := RankHeroes(heroes, RankingComposite, 1)
+	if composite[0].HeroID != 1 {
+		t.Errorf("expected composite ranking to prefer the popular hero, got hero %d", composite[0].HeroID)
+	}
+}
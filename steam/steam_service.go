@@ -10,14 +10,18 @@ import (
 )
 
 type SteamAccountView struct {
-	SteamID64                 string `json:"steamId64"`
-	SteamID3                  string `json:"steamId3"`
-	AccountName               string `json:"accountName"`
-	PersonaName               string `json:"personaName"`
-	AvatarBase64              string `json:"avatarBase64"`
-	Enabled                   bool   `json:"enabled"`
-	LastUpdateTimestampMillis int64  `json:"lastUpdateTimestampMillis"`
-	LastUpdateErrorMessage    string `json:"lastUpdateErrorMessage"`
+	SteamID64                 string                 `json:"steamId64"`
+	SteamID3                  string                 `json:"steamId3"`
+	AccountName               string                 `json:"accountName"`
+	PersonaName               string                 `json:"personaName"`
+	AvatarBase64              string                 `json:"avatarBase64"`
+	Enabled                   bool                   `json:"enabled"`
+	TemplateID                string                 `json:"templateId"`
+	ProfileID                 string                 `json:"profileId"`
+	Jobs                      []config.GenerationJob `json:"jobs"`
+	LastUpdateTimestampMillis int64                  `json:"lastUpdateTimestampMillis"`
+	LastUpdateErrorMessage    string                 `json:"lastUpdateErrorMessage"`
+	LastUpdateWarnings        []string               `json:"lastUpdateWarnings"`
 }
 
 type SteamService struct {
@@ -123,15 +127,58 @@ func (s *SteamService) SetAccountEnabled(steamId64 string, enabled bool) {
 	}
 }
 
+// SetAccountTemplate updates the layout template in both config and cache
+func (s *SteamService) SetAccountTemplate(steamId64 string, templateID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.SetSteamAccountTemplate(steamId64, templateID)
+	for i := range s.cache {
+		if s.cache[i].SteamID64 == steamId64 {
+			s.cache[i].TemplateID = templateID
+			break
+		}
+	}
+}
+
+// SetAccountProfile updates the generation profile in both config and cache
+func (s *SteamService) SetAccountProfile(steamId64 string, profileID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config.SetSteamAccountProfile(steamId64, profileID)
+	for i := range s.cache {
+		if s.cache[i].SteamID64 == steamId64 {
+			s.cache[i].ProfileID = profileID
+			break
+		}
+	}
+}
+
+// SetAccountJobs updates the generation jobs in both config and cache
+func (s *SteamService) SetAccountJobs(steamId64 string, jobs []config.GenerationJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.config.SetSteamAccountJobs(steamId64, jobs); err != nil {
+		return err
+	}
+	for i := range s.cache {
+		if s.cache[i].SteamID64 == steamId64 {
+			s.cache[i].Jobs = slices.Clone(jobs)
+			break
+		}
+	}
+	return nil
+}
+
 // UpdateAccountStatus updates the status in both config and cache
-func (s *SteamService) UpdateAccountStatus(steamId64 string, timestampMillis int64, errorMessage string) {
+func (s *SteamService) UpdateAccountStatus(steamId64 string, timestampMillis int64, errorMessage string, warnings []string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.config.UpdateSteamAccountStatus(steamId64, timestampMillis, errorMessage)
+	s.config.UpdateSteamAccountStatus(steamId64, timestampMillis, errorMessage, warnings)
 	for i := range s.cache {
 		if s.cache[i].SteamID64 == steamId64 {
 			s.cache[i].LastUpdateTimestampMillis = timestampMillis
 			s.cache[i].LastUpdateErrorMessage = errorMessage
+			s.cache[i].LastUpdateWarnings = slices.Clone(warnings)
 			break
 		}
 	}
@@ -146,6 +193,24 @@ func (s *SteamService) IsPathValid() bool {
 	return err == nil && info.IsDir()
 }
 
+// GetAccountGridPath returns the hero_grid_config.json path of an account; ok is false when the
+// Steam path isn't set or the account wasn't found by the last scan
+func (s *SteamService) GetAccountGridPath(steamId64 string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	steamPath := s.config.GetSteamPath()
+	if steamPath == "" {
+		return "", false
+	}
+	for _, acc := range s.cache {
+		if acc.SteamID64 == steamId64 && acc.SteamID3 != "" {
+			return HeroGridConfigPath(steamPath, acc.SteamID3), true
+		}
+	}
+	return "", false
+}
+
 // GetEnabledAccountPaths returns hero_grid_config.json paths for enabled accounts
 func (s *SteamService) GetEnabledAccountPaths() map[string]string {
 	s.mu.RLock()
@@ -171,8 +236,12 @@ func (s *SteamService) buildCache(accounts []config.SteamAccountConfig, discover
 		view := SteamAccountView{
 			SteamID64:                 acc.SteamID64,
 			Enabled:                   acc.Enabled,
+			TemplateID:                acc.TemplateID,
+			ProfileID:                 acc.ProfileID,
+			Jobs:                      acc.Jobs,
 			LastUpdateTimestampMillis: acc.LastUpdateTimestampMillis,
 			LastUpdateErrorMessage:    acc.LastUpdateErrorMessage,
+			LastUpdateWarnings:        acc.LastUpdateWarnings,
 		}
 		if d, ok := discovered[acc.SteamID64]; ok {
 			view.SteamID3 = d.SteamID3
@@ -0,0 +1,224 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	partialFileSuffix      = ".part"
+	maxDownloadAttempts    = 3
+	progressReportInterval = 200 * time.Millisecond
+)
+
+// downloadRetryDelay is a variable so tests can shorten it
+var downloadRetryDelay = 2 * time.Second
+
+// newDownloadClient returns the client of update downloads. It has no overall timeout, which would
+// also cover the streamed body and cut off slow connections: only connecting and waiting for the
+// response headers are limited, and a download is stopped by cancelling its context.
+func newDownloadClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = 15 * time.Second
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: transport}
+}
+
+// DownloadProgress describes the state of an in-flight update download
+type DownloadProgress struct {
+	BytesDone      int64
+	TotalBytes     int64
+	BytesPerSecond float64
+}
+
+// ProgressFunc receives download progress reports. It may be nil.
+type ProgressFunc func(progress DownloadProgress)
+
+// progressWriter counts bytes written through it and periodically reports progress
+type progressWriter struct {
+	onProgress ProgressFunc
+	total      int64
+	done       int64
+
+	// rate is measured over the current attempt only, so resumed bytes don't inflate it
+	attemptStart     time.Time
+	attemptStartDone int64
+	lastReport       time.Time
+}
+
+func newProgressWriter(done int64, total int64, onProgress ProgressFunc) *progressWriter {
+	now := time.Now()
+	return &progressWriter{
+		onProgress:       onProgress,
+		total:            total,
+		done:             done,
+		attemptStart:     now,
+		attemptStartDone: done,
+	}
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.done += int64(len(p))
+	if time.Since(w.lastReport) >= progressReportInterval {
+		w.report()
+	}
+	return len(p), nil
+}
+
+// restart resets the rate measurement for a new attempt starting at done bytes
+func (w *progressWriter) restart(done int64) {
+	w.done = done
+	w.attemptStart = time.Now()
+	w.attemptStartDone = done
+}
+
+func (w *progressWriter) report() {
+	if w.onProgress == nil {
+		return
+	}
+
+	now := time.Now()
+	w.lastReport = now
+
+	var rate float64
+	if elapsed := now.Sub(w.attemptStart).Seconds(); elapsed > 0 {
+		rate = float64(w.done-w.attemptStartDone) / elapsed
+	}
+
+	w.onProgress(DownloadProgress{
+		BytesDone:      w.done,
+		TotalBytes:     w.total,
+		BytesPerSecond: rate,
+	})
+}
+
+// downloadAsset downloads url into destPath, resuming from a previous partial
+// download with an HTTP Range request when possible. Interrupted attempts are
+// retried up to maxDownloadAttempts times; the partial file is kept on failure
+// so that a later call can resume it.
+func downloadAsset(ctx context.Context, client *http.Client, url string, destPath string, size int64, onProgress ProgressFunc) error {
+	file, err := os.OpenFile(destPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening partial file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error getting partial file info: %w", err)
+	}
+
+	offset := info.Size()
+	if offset > size {
+		offset = 0
+	}
+
+	progress := newProgressWriter(offset, size, onProgress)
+
+	var lastErr error
+	for attempt := 1; attempt <= maxDownloadAttempts; attempt++ {
+		if offset == size {
+			break
+		}
+
+		progress.restart(offset)
+		offset, lastErr = downloadAttempt(ctx, client, url, file, offset, progress)
+		if lastErr == nil {
+			break
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		slog.Warn("Update download interrupted", "attempt", attempt, "bytesDone", offset, "error", lastErr)
+		if attempt < maxDownloadAttempts {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(downloadRetryDelay):
+			}
+		}
+	}
+
+	if lastErr != nil {
+		return lastErr
+	}
+
+	progress.report()
+
+	if offset != size {
+		return fmt.Errorf("downloaded asset size mismatch: expected %d, got %d", size, offset)
+	}
+
+	return nil
+}
+
+// downloadAttempt requests the bytes from offset onwards and appends them to file.
+// It returns the new offset, which is valid even when an error is returned.
+func downloadAttempt(ctx context.Context, client *http.Client, url string, file *os.File, offset int64, progress *progressWriter) (int64, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return offset, fmt.Errorf("error creating request: %w", err)
+	}
+
+	request.Header.Set("Accept", "application/octet-stream")
+	if offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return offset, fmt.Errorf("error downloading asset: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusPartialContent:
+		// Server honoured the range, append to what we already have
+	case http.StatusOK:
+		// Server ignored the range (or this is a fresh download), start over
+		offset = 0
+		progress.restart(0)
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Partial file doesn't match the remote asset anymore, drop it
+		if err := file.Truncate(0); err != nil {
+			return offset, fmt.Errorf("error truncating partial file: %w", err)
+		}
+		return 0, fmt.Errorf("server rejected range request starting at %d", offset)
+	default:
+		return offset, fmt.Errorf("failed to download asset: server returned status %d: %s", response.StatusCode, response.Status)
+	}
+
+	if err := file.Truncate(offset); err != nil {
+		return offset, fmt.Errorf("error truncating partial file: %w", err)
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, fmt.Errorf("error seeking partial file: %w", err)
+	}
+
+	written, err := io.Copy(io.MultiWriter(file, progress), response.Body)
+	offset += written
+	if err != nil {
+		if ctx.Err() != nil {
+			return offset, ctx.Err()
+		}
+		return offset, fmt.Errorf("error copying asset: %w", err)
+	}
+
+	if offset < progress.total {
+		return offset, fmt.Errorf("error copying asset: %w", io.ErrUnexpectedEOF)
+	}
+
+	return offset, nil
+}
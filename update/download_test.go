@@ -0,0 +1,210 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testPayload(size int) []byte {
+	payload := make([]byte, size)
+	for i := range payload {
+		payload[i] = byte(i % 251)
+	}
+	return payload
+}
+
+func newRangeServer(t *testing.T, payload []byte, rangeHeaders *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*rangeHeaders = append(*rangeHeaders, r.Header.Get("Range"))
+		http.ServeContent(w, r, "asset.zip", time.Time{}, bytes.NewReader(payload))
+	}))
+}
+
+func TestDownloadAsset_FullDownload(t *testing.T) {
+	payload := testPayload(64 * 1024)
+	var rangeHeaders []string
+	server := newRangeServer(t, payload, &rangeHeaders)
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset.zip.part")
+
+	var lastProgress DownloadProgress
+	err := downloadAsset(context.Background(), server.Client(), server.URL, destPath, int64(len(payload)), func(p DownloadProgress) {
+		lastProgress = p
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(destPath)
+	if !bytes.Equal(content, payload) {
+		t.Error("downloaded content does not match payload")
+	}
+	if rangeHeaders[0] != "" {
+		t.Errorf("expected no Range header for fresh download, got %q", rangeHeaders[0])
+	}
+	if lastProgress.BytesDone != int64(len(payload)) || lastProgress.TotalBytes != int64(len(payload)) {
+		t.Errorf("expected final progress %d/%d, got %d/%d", len(payload), len(payload), lastProgress.BytesDone, lastProgress.TotalBytes)
+	}
+}
+
+func TestDownloadAsset_ResumesPartialFile(t *testing.T) {
+	payload := testPayload(64 * 1024)
+	var rangeHeaders []string
+	server := newRangeServer(t, payload, &rangeHeaders)
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset.zip.part")
+	const alreadyDownloaded = 10000
+	os.WriteFile(destPath, payload[:alreadyDownloaded], 0644)
+
+	err := downloadAsset(context.Background(), server.Client(), server.URL, destPath, int64(len(payload)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rangeHeaders) != 1 || rangeHeaders[0] != "bytes="+strconv.Itoa(alreadyDownloaded)+"-" {
+		t.Errorf("expected a single ranged request from %d, got %v", alreadyDownloaded, rangeHeaders)
+	}
+
+	content, _ := os.ReadFile(destPath)
+	if !bytes.Equal(content, payload) {
+		t.Error("resumed content does not match payload")
+	}
+}
+
+func TestDownloadAsset_ServerIgnoresRange(t *testing.T) {
+	payload := testPayload(4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(payload)
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset.zip.part")
+	os.WriteFile(destPath, []byte("stale partial data"), 0644)
+
+	err := downloadAsset(context.Background(), server.Client(), server.URL, destPath, int64(len(payload)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(destPath)
+	if !bytes.Equal(content, payload) {
+		t.Error("expected partial file to be replaced by full content")
+	}
+}
+
+func TestDownloadAsset_RetriesAfterInterruption(t *testing.T) {
+	downloadRetryDelay = time.Millisecond
+	t.Cleanup(func() { downloadRetryDelay = 2 * time.Second })
+
+	payload := testPayload(32 * 1024)
+	var requests atomic.Int32
+	var secondRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Promise the full body but only send half, then drop the connection
+			w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+			w.WriteHeader(http.StatusOK)
+			w.Write(payload[:len(payload)/2])
+			return
+		}
+		secondRange = r.Header.Get("Range")
+		http.ServeContent(w, r, "asset.zip", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset.zip.part")
+
+	err := downloadAsset(context.Background(), server.Client(), server.URL, destPath, int64(len(payload)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+	if secondRange != "bytes="+strconv.Itoa(len(payload)/2)+"-" {
+		t.Errorf("expected retry to resume from %d, got Range %q", len(payload)/2, secondRange)
+	}
+
+	content, _ := os.ReadFile(destPath)
+	if !bytes.Equal(content, payload) {
+		t.Error("downloaded content does not match payload")
+	}
+}
+
+func TestDownloadAsset_Cancelled(t *testing.T) {
+	payload := testPayload(1024)
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(payload[:100])
+		w.(http.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset.zip.part")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	err := downloadAsset(ctx, server.Client(), server.URL, destPath, int64(len(payload)), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// The partial file is kept so a later download can resume it
+	if _, statErr := os.Stat(destPath); statErr != nil {
+		t.Errorf("expected partial file to be kept: %v", statErr)
+	}
+}
+
+func TestDownloadAsset_HTTPError(t *testing.T) {
+	downloadRetryDelay = time.Millisecond
+	t.Cleanup(func() { downloadRetryDelay = 2 * time.Second })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	destPath := filepath.Join(t.TempDir(), "asset.zip.part")
+
+	err := downloadAsset(context.Background(), server.Client(), server.URL, destPath, 100, nil)
+	if err == nil {
+		t.Error("expected error for 500 response")
+	}
+}
+
+func TestNewDownloadClient(t *testing.T) {
+	client := newDownloadClient()
+
+	// A client-wide timeout would also cut off slow downloads
+	if client.Timeout != 0 {
+		t.Errorf("expected no overall timeout, got %v", client.Timeout)
+	}
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", client.Transport)
+	}
+	if transport.TLSHandshakeTimeout <= 0 || transport.ResponseHeaderTimeout <= 0 || transport.DialContext == nil {
+		t.Error("expected connecting and the response headers to be limited")
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package update
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+)
+
+func waitForProcessExit(pid int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		// Signal 0 only checks whether the process exists
+		if err := syscall.Kill(pid, 0); errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("process %d did not exit within %s", pid, timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
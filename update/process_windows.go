@@ -0,0 +1,29 @@
+//go:build windows
+
+package update
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func waitForProcessExit(pid int, timeout time.Duration) error {
+	handle, err := windows.OpenProcess(windows.SYNCHRONIZE, false, uint32(pid))
+	if err != nil {
+		// The process is already gone (or was never ours to wait on)
+		return nil
+	}
+	defer windows.CloseHandle(handle)
+
+	event, err := windows.WaitForSingleObject(handle, uint32(timeout.Milliseconds()))
+	if err != nil {
+		return fmt.Errorf("error waiting for process %d: %w", pid, err)
+	}
+	if event == uint32(windows.WAIT_TIMEOUT) {
+		return fmt.Errorf("process %d did not exit within %s", pid, timeout)
+	}
+
+	return nil
+}
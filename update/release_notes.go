@@ -0,0 +1,127 @@
+package update
+
+import (
+	"cmp"
+	"d2tool/github"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	unsafeLinkRegex  = regexp.MustCompile(`(?i)\]\(\s*(javascript|vbscript|data|file):[^)]*\)`)
+	blankLinesRegex  = regexp.MustCompile(`\n{3,}`)
+
+	// htmlEscaper escapes the characters markdown would pass through as raw HTML,
+	// so text like "Vec<T>" is shown as written instead of being interpreted
+	htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// compareVersions compares dotted version strings numerically part by part.
+// A leading "v" or "release/" is ignored; non-numeric parts are compared as strings,
+// and a pre-release suffix ("1.0.0-beta") sorts before the plain version.
+func compareVersions(a string, b string) int {
+	aParts := splitVersion(a)
+	bParts := splitVersion(b)
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		aPart, aNum, aIsNum := versionPart(aParts, i)
+		bPart, bNum, bIsNum := versionPart(bParts, i)
+
+		var result int
+		switch {
+		case aIsNum && bIsNum:
+			result = cmp.Compare(aNum, bNum)
+		case aPart == "":
+			result = 1
+		case bPart == "":
+			result = -1
+		default:
+			result = strings.Compare(aPart, bPart)
+		}
+
+		if result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// versionPart returns the i-th part of a split version; missing parts count as numeric zero
+func versionPart(parts []string, i int) (string, int, bool) {
+	if i >= len(parts) {
+		return "", 0, true
+	}
+	num, err := strconv.Atoi(parts[i])
+	return parts[i], num, err == nil
+}
+
+func splitVersion(version string) []string {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "release/")
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '-'
+	})
+}
+
+// releasesBetween returns the published releases newer than currentVersion and
+// not newer than latestVersion, newest first
+func releasesBetween(releases []github.Release, currentVersion string, latestVersion string) []github.Release {
+	var result []github.Release
+	for _, release := range releases {
+		if release.Draft || release.Prerelease {
+			continue
+		}
+		if compareVersions(release.Name, currentVersion) > 0 && compareVersions(release.Name, latestVersion) <= 0 {
+			result = append(result, release)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b github.Release) int {
+		return compareVersions(b.Name, a.Name)
+	})
+
+	return result
+}
+
+// combineReleaseNotes joins the release bodies into a single markdown document
+// with one section per release
+func combineReleaseNotes(releases []github.Release) string {
+	var builder strings.Builder
+	for i, release := range releases {
+		if i > 0 {
+			builder.WriteString("\n\n")
+		}
+
+		builder.WriteString(fmt.Sprintf("## %s", release.Name))
+		if !release.PublishedAt.IsZero() {
+			builder.WriteString(fmt.Sprintf(" (%s)", release.PublishedAt.Format("2006-01-02")))
+		}
+		builder.WriteString("\n\n")
+
+		body := sanitizeReleaseNotes(release.Body)
+		if body == "" {
+			body = "No release notes."
+		}
+		builder.WriteString(body)
+	}
+
+	return builder.String()
+}
+
+// sanitizeReleaseNotes makes a release body safe to hand to the webview:
+// comments are stripped, raw HTML is escaped so it displays as text, and
+// script-capable link targets are removed.
+func sanitizeReleaseNotes(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = htmlCommentRegex.ReplaceAllString(body, "")
+	body = htmlEscaper.Replace(body)
+	body = unsafeLinkRegex.ReplaceAllString(body, "](#)")
+	body = blankLinesRegex.ReplaceAllString(body, "\n\n")
+	return strings.TrimSpace(body)
+}
@@ -0,0 +1,171 @@
+package update
+
+import (
+	"d2tool/github"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeGithubClient struct {
+	latest      *github.Release
+	releases    []github.Release
+	releasesErr error
+}
+
+func (c *fakeGithubClient) GetLatestRelease() (*github.Release, error) {
+	return c.latest, nil
+}
+
+func (c *fakeGithubClient) GetReleases() ([]github.Release, error) {
+	return c.releases, c.releasesErr
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"0.0.12", "0.0.12", 0},
+		{"0.0.12", "0.0.9", 1},
+		{"0.0.9", "0.0.12", -1},
+		{"v1.2.0", "1.2", 0},
+		{"release/0.1.0", "0.0.99", 1},
+		{"1.0.0", "1.0.0-beta", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s vs %s", tt.a, tt.b), func(t *testing.T) {
+			if got := compareVersions(tt.a, tt.b); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReleasesBetween(t *testing.T) {
+	releases := []github.Release{
+		{Name: "0.0.15", Prerelease: true},
+		{Name: "0.0.14"},
+		{Name: "0.0.13", Draft: true},
+		{Name: "0.0.11"},
+		{Name: "0.0.10"},
+		{Name: "0.0.12"},
+	}
+
+	between := releasesBetween(releases, "0.0.10", "0.0.14")
+
+	var names []string
+	for _, r := range between {
+		names = append(names, r.Name)
+	}
+
+	if strings.Join(names, ",") != "0.0.14,0.0.12,0.0.11" {
+		t.Errorf("expected 0.0.14,0.0.12,0.0.11, got %v", names)
+	}
+}
+
+func TestSanitizeReleaseNotes(t *testing.T) {
+	body := "<!-- Release notes generated by GitHub -->\r\n## What's Changed\r\n" +
+		"* Fix grid <script>alert(1)</script>layout\r\n" +
+		"* [click](javascript:alert(1)) and [docs](https://example.com)\r\n" +
+		"* Keeps a < b comparisons\r\n" +
+		"* Accepts Vec<T> and <steam id> & more"
+
+	sanitized := sanitizeReleaseNotes(body)
+
+	if strings.Contains(sanitized, "<script>") || strings.Contains(sanitized, "<!--") {
+		t.Errorf("expected HTML to be escaped and comments stripped, got %q", sanitized)
+	}
+	if !strings.Contains(sanitized, "&lt;script&gt;alert(1)&lt;/script&gt;layout") {
+		t.Errorf("expected the script tag to be kept as escaped text, got %q", sanitized)
+	}
+	if !strings.Contains(sanitized, "Vec&lt;T&gt; and &lt;steam id&gt; &amp; more") {
+		t.Errorf("expected angle bracket text to be escaped rather than removed, got %q", sanitized)
+	}
+	if strings.Contains(strings.ToLower(sanitized), "javascript:") {
+		t.Errorf("expected javascript: link to be removed, got %q", sanitized)
+	}
+	if !strings.Contains(sanitized, "[docs](https://example.com)") {
+		t.Errorf("expected safe link to be kept, got %q", sanitized)
+	}
+	if !strings.Contains(sanitized, "a &lt; b") {
+		t.Errorf("expected plain text comparison to be kept, got %q", sanitized)
+	}
+	if strings.Contains(sanitized, "\r") {
+		t.Error("expected CRLF to be normalized")
+	}
+}
+
+func TestCombineReleaseNotes(t *testing.T) {
+	releases := []github.Release{
+		{Name: "0.0.13", Body: "Second", PublishedAt: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "0.0.12", Body: ""},
+	}
+
+	notes := combineReleaseNotes(releases)
+
+	expected := "## 0.0.13 (2025-02-01)\n\nSecond\n\n## 0.0.12\n\nNo release notes."
+	if notes != expected {
+		t.Errorf("expected %q, got %q", expected, notes)
+	}
+}
+
+func TestCheckForUpdate_CollectsReleaseNotes(t *testing.T) {
+	latest := github.Release{Name: "0.0.13", Body: "Latest notes", HTMLURL: "https://example.com/0.0.13"}
+	client := &fakeGithubClient{
+		latest: &latest,
+		releases: []github.Release{
+			latest,
+			{Name: "0.0.12", Body: "Middle notes"},
+			{Name: "0.0.11", Body: "Current notes"},
+		},
+	}
+	service := NewUpdateService("0.0.11", "", nil, client)
+
+	if err := service.CheckForUpdate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := service.GetState()
+	if !strings.Contains(state.ReleaseNotes, "Latest notes") || !strings.Contains(state.ReleaseNotes, "Middle notes") {
+		t.Errorf("expected notes of 0.0.12 and 0.0.13, got %q", state.ReleaseNotes)
+	}
+	if strings.Contains(state.ReleaseNotes, "Current notes") {
+		t.Errorf("expected notes of the current version to be excluded, got %q", state.ReleaseNotes)
+	}
+	if state.ReleaseURL != "https://example.com/0.0.13" {
+		t.Errorf("expected release URL of latest release, got %q", state.ReleaseURL)
+	}
+}
+
+func TestCheckForUpdate_ReleaseListFailureFallsBackToLatest(t *testing.T) {
+	latest := github.Release{Name: "0.0.13", Body: "Latest notes"}
+	client := &fakeGithubClient{
+		latest:      &latest,
+		releasesErr: fmt.Errorf("rate limited"),
+	}
+	service := NewUpdateService("0.0.11", "", nil, client)
+
+	if err := service.CheckForUpdate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(service.GetState().ReleaseNotes, "Latest notes") {
+		t.Errorf("expected latest release notes, got %q", service.GetState().ReleaseNotes)
+	}
+}
+
+func TestCheckForUpdate_NoNotesWhenUpToDate(t *testing.T) {
+	latest := github.Release{Name: "0.0.11", Body: "Current notes"}
+	service := NewUpdateService("0.0.11", "", nil, &fakeGithubClient{latest: &latest})
+
+	if err := service.CheckForUpdate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if service.GetState().ReleaseNotes != "" {
+		t.Errorf("expected no release notes when up to date, got %q", service.GetState().ReleaseNotes)
+	}
+}
@@ -0,0 +1,141 @@
+package update
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// RestartWaitPIDFlag is passed to the new version so it waits for the old
+	// process to exit (and release the single-instance lock) before starting
+	RestartWaitPIDFlag = "restart-wait-pid"
+	// UpdatedFromFlag is passed to the new version with the version it replaced
+	UpdatedFromFlag = "updated-from"
+	// RestartReadyFileFlag is passed to the new version with the file it creates
+	// to confirm it started, see ConfirmRestart
+	RestartReadyFileFlag = "restart-ready-file"
+
+	restartReadyTimeout   = 30 * time.Second
+	restartReadyPollDelay = 100 * time.Millisecond
+	restartWaitTimeout    = 30 * time.Second
+)
+
+// RestartApp starts the freshly installed executable with the original launch
+// arguments. It returns once the new process confirmed it started; the caller is
+// expected to quit right after so the new process can take over the single-instance
+// lock. A new process that exits or doesn't confirm in time is stopped and reported,
+// so the current version keeps running.
+func (s *UpdateServiceImpl) RestartApp() error {
+	s.opLock.Lock()
+	defer s.opLock.Unlock()
+
+	s.stateLock.RLock()
+	installedVersion := s.installedVersion
+	s.stateLock.RUnlock()
+
+	if installedVersion == "" {
+		return fmt.Errorf("no installed update to restart into")
+	}
+
+	readyPath := filepath.Join(os.TempDir(), fmt.Sprintf("d2tool-restart-%d.ready", os.Getpid()))
+	_ = os.Remove(readyPath) // left over from an earlier failed restart
+	defer os.Remove(readyPath)
+
+	executablePath, args, err := s.restartCommand(readyPath)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Restarting into new version", "version", installedVersion, "executable", executablePath, "args", args)
+
+	cmd := exec.Command(executablePath, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting new version: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	if err := waitForRestartReady(readyPath, exited, restartReadyTimeout); err != nil {
+		if killErr := cmd.Process.Kill(); killErr != nil {
+			slog.Warn("Error stopping new version", "error", killErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// waitForRestartReady waits until the new process created readyPath, failing when it exits first
+// or doesn't confirm within the timeout
+func waitForRestartReady(readyPath string, exited <-chan error, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(restartReadyPollDelay)
+	defer ticker.Stop()
+
+	for {
+		if _, err := os.Stat(readyPath); err == nil {
+			return nil
+		}
+		select {
+		case err := <-exited:
+			return fmt.Errorf("new version exited during startup: %v", err)
+		case <-deadline:
+			return fmt.Errorf("new version did not confirm its startup within %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// restartCommand returns the executable and the arguments to start the installed update with.
+// The path is the one the application was started from: the update replaced the file there,
+// while os.Executable may now point to the renamed old version.
+func (s *UpdateServiceImpl) restartCommand(readyPath string) (string, []string, error) {
+	executablePath, err := s.appExecutable()
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := append([]string{}, s.launchArgs...)
+	args = append(args,
+		fmt.Sprintf("-%s=%d", RestartWaitPIDFlag, os.Getpid()),
+		fmt.Sprintf("-%s=%s", UpdatedFromFlag, s.currentAppVersion),
+		fmt.Sprintf("-%s=%s", RestartReadyFileFlag, readyPath),
+	)
+	return executablePath, args, nil
+}
+
+// ConfirmRestart tells the process that restarted us that we started, by creating the file of
+// RestartReadyFileFlag; an empty path means we weren't restarted. It is called as late as the
+// restart allows: the window can't start before the previous version exits and releases the
+// single-instance lock, and that version only exits once it has the confirmation.
+func ConfirmRestart(readyPath string) {
+	if readyPath == "" {
+		return
+	}
+
+	if err := os.WriteFile(readyPath, []byte(fmt.Sprint(os.Getpid())), 0644); err != nil {
+		slog.Warn("Error confirming the restart", "path", readyPath, "error", err)
+	}
+}
+
+// WaitForRestartHandoff blocks until the process that restarted us has exited.
+// pid is the value of RestartWaitPIDFlag; zero means we weren't restarted.
+func WaitForRestartHandoff(pid int) {
+	if pid <= 0 {
+		return
+	}
+
+	slog.Info("Waiting for previous version to exit", "pid", pid)
+	if err := waitForProcessExit(pid, restartWaitTimeout); err != nil {
+		slog.Warn("Previous version did not exit in time", "error", err)
+		return
+	}
+	slog.Info("Previous version exited", "pid", pid)
+}
@@ -2,6 +2,7 @@ package update
 
 import (
 	"archive/zip"
+	"context"
 	"d2tool/github"
 	"fmt"
 	"io"
@@ -21,16 +22,22 @@ const (
 )
 
 type UpdateState struct {
-	UpdateAvailable   bool
-	CurrentAppVersion string
-	LatestAppVersion  string
-	LastCheckTime     time.Time
+	UpdateAvailable    bool
+	UpdateInstalled    bool
+	CurrentAppVersion  string
+	LatestAppVersion   string
+	UpdatedFromVersion string
+	ReleaseNotes       string // sanitized markdown for every release between current and latest
+	ReleaseURL         string
+	LastCheckTime      time.Time
 }
 
 type UpdateService interface {
 	GetState() UpdateState
 	CheckForUpdate() error
-	UpdateApp() error
+	UpdateApp(ctx context.Context, onProgress ProgressFunc) error
+	CancelUpdate()
+	RestartApp() error
 	GetAppDirectory() (string, error)
 	OpenAppDirectory() error
 }
@@ -39,25 +46,39 @@ type UpdateServiceImpl struct {
 	stateLock sync.RWMutex // protects reads/writes of state fields (never held during I/O)
 	opLock    sync.Mutex   // serializes CheckForUpdate / UpdateApp (held during I/O)
 
-	currentAppVersion string
-	githubClient      github.Client
-	downloadClient    *http.Client
-
-	latestRelease *github.Release
-	lastCheckTime time.Time
+	currentAppVersion  string
+	updatedFromVersion string   // version we were restarted from after an update, if any
+	launchArgs         []string // arguments to pass to the new version on restart
+	executablePath     string   // resolved at startup, an update renames the running executable
+	githubClient       github.Client
+	downloadClient     *http.Client
+
+	latestRelease    *github.Release
+	releaseNotes     string
+	lastCheckTime    time.Time
+	cancelUpdate     context.CancelFunc
+	installedVersion string // version extracted by UpdateApp, waiting for a restart
 }
 
 func NewUpdateService(
 	currentAppVersion string,
+	updatedFromVersion string,
+	launchArgs []string,
 	githubClient github.Client,
 ) *UpdateServiceImpl {
+	executablePath, err := os.Executable()
+	if err != nil {
+		slog.Warn("Error getting executable path", "error", err)
+	}
+
 	return &UpdateServiceImpl{
-		currentAppVersion: currentAppVersion,
-		githubClient:      githubClient,
-		downloadClient: &http.Client{
-			Timeout: 10 * time.Minute,
-		},
-		lastCheckTime: time.UnixMilli(0),
+		currentAppVersion:  currentAppVersion,
+		updatedFromVersion: updatedFromVersion,
+		launchArgs:         launchArgs,
+		executablePath:     executablePath,
+		githubClient:       githubClient,
+		downloadClient:     newDownloadClient(),
+		lastCheckTime:      time.UnixMilli(0),
 	}
 }
 
@@ -67,11 +88,20 @@ func (s *UpdateServiceImpl) GetState() UpdateState {
 
 	latestVersion := s.latestAvailableVersionLocked()
 
+	var releaseURL string
+	if s.latestRelease != nil {
+		releaseURL = s.latestRelease.HTMLURL
+	}
+
 	return UpdateState{
-		UpdateAvailable:   isUpdateAvailable(latestVersion, s.currentAppVersion),
-		CurrentAppVersion: s.currentAppVersion,
-		LatestAppVersion:  latestVersion,
-		LastCheckTime:     s.lastCheckTime,
+		UpdateAvailable:    isUpdateAvailable(latestVersion, s.currentAppVersion),
+		UpdateInstalled:    s.installedVersion != "",
+		CurrentAppVersion:  s.currentAppVersion,
+		LatestAppVersion:   latestVersion,
+		UpdatedFromVersion: s.updatedFromVersion,
+		ReleaseNotes:       s.releaseNotes,
+		ReleaseURL:         releaseURL,
+		LastCheckTime:      s.lastCheckTime,
 	}
 }
 
@@ -79,7 +109,7 @@ func (s *UpdateServiceImpl) CheckForUpdate() error {
 	s.opLock.Lock()
 	defer s.opLock.Unlock()
 
-	if err := cleanupOldFiles(); err != nil {
+	if err := s.cleanupOldFiles(); err != nil {
 		slog.Warn("Error cleaning up old files", "error", err)
 	}
 
@@ -88,22 +118,35 @@ func (s *UpdateServiceImpl) CheckForUpdate() error {
 		return err
 	}
 
+	releaseNotes := s.fetchReleaseNotes(release)
+
 	s.stateLock.Lock()
 	s.latestRelease = release
+	s.releaseNotes = releaseNotes
 	s.lastCheckTime = time.Now()
 	s.stateLock.Unlock()
 
 	return nil
 }
 
-func (s *UpdateServiceImpl) UpdateApp() error {
+func (s *UpdateServiceImpl) UpdateApp(ctx context.Context, onProgress ProgressFunc) error {
 	s.opLock.Lock()
 	defer s.opLock.Unlock()
 
-	s.stateLock.RLock()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.stateLock.Lock()
 	release := s.latestRelease
 	latestVersion := s.latestAvailableVersionLocked()
-	s.stateLock.RUnlock()
+	s.cancelUpdate = cancel
+	s.stateLock.Unlock()
+
+	defer func() {
+		s.stateLock.Lock()
+		s.cancelUpdate = nil
+		s.stateLock.Unlock()
+	}()
 
 	if release == nil {
 		return fmt.Errorf("no release available to update to")
@@ -113,7 +156,48 @@ func (s *UpdateServiceImpl) UpdateApp() error {
 		return fmt.Errorf("no update available for current version %s and latest version %s", s.currentAppVersion, latestVersion)
 	}
 
-	return s.downloadAndUnarchiveRelease(release)
+	if err := s.downloadAndUnarchiveRelease(ctx, release, onProgress); err != nil {
+		return err
+	}
+
+	s.stateLock.Lock()
+	s.installedVersion = latestVersion
+	s.stateLock.Unlock()
+
+	return nil
+}
+
+// CancelUpdate aborts an in-progress UpdateApp call. The partially downloaded
+// archive is kept so the next UpdateApp call can resume it.
+func (s *UpdateServiceImpl) CancelUpdate() {
+	s.stateLock.RLock()
+	cancel := s.cancelUpdate
+	s.stateLock.RUnlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// fetchReleaseNotes collects the notes of every release the user would get by
+// updating to latest. Failing to list releases falls back to the latest release alone.
+func (s *UpdateServiceImpl) fetchReleaseNotes(latest *github.Release) string {
+	if !isUpdateAvailable(latest.Name, s.currentAppVersion) {
+		return ""
+	}
+
+	releases, err := s.githubClient.GetReleases()
+	if err != nil {
+		slog.Warn("Error fetching releases, showing latest release notes only", "error", err)
+		releases = []github.Release{*latest}
+	}
+
+	pending := releasesBetween(releases, s.currentAppVersion, latest.Name)
+	if len(pending) == 0 {
+		pending = []github.Release{*latest}
+	}
+
+	return combineReleaseNotes(pending)
 }
 
 func (s *UpdateServiceImpl) latestAvailableVersionLocked() string {
@@ -123,10 +207,19 @@ func (s *UpdateServiceImpl) latestAvailableVersionLocked() string {
 	return s.latestRelease.Name
 }
 
+// appExecutable returns the path the application was started from. It keeps pointing to the
+// installed executable after an update renamed the running one, unlike os.Executable on Linux.
+func (s *UpdateServiceImpl) appExecutable() (string, error) {
+	if s.executablePath == "" {
+		return "", fmt.Errorf("executable path is unknown")
+	}
+	return s.executablePath, nil
+}
+
 func (s *UpdateServiceImpl) GetAppDirectory() (string, error) {
-	execPath, err := os.Executable()
+	execPath, err := s.appExecutable()
 	if err != nil {
-		return "", fmt.Errorf("error getting executable path: %w", err)
+		return "", err
 	}
 	return filepath.Dir(execPath), nil
 }
@@ -143,13 +236,12 @@ func isUpdateAvailable(latestVersion string, currentVersion string) bool {
 	return latestVersion != "" && currentVersion != latestVersion
 }
 
-func cleanupOldFiles() error {
-	execPath, err := os.Executable()
+func (s *UpdateServiceImpl) cleanupOldFiles() error {
+	rootDir, err := s.GetAppDirectory()
 	if err != nil {
-		return fmt.Errorf("error getting executable path: %w", err)
+		return err
 	}
 
-	rootDir := filepath.Dir(execPath)
 	return filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -165,14 +257,14 @@ func cleanupOldFiles() error {
 	})
 }
 
-func (s *UpdateServiceImpl) downloadAndUnarchiveRelease(release *github.Release) error {
-	if err := cleanupOldFiles(); err != nil {
+func (s *UpdateServiceImpl) downloadAndUnarchiveRelease(ctx context.Context, release *github.Release, onProgress ProgressFunc) error {
+	if err := s.cleanupOldFiles(); err != nil {
 		return fmt.Errorf("error cleaning up old files: %w", err)
 	}
 
-	executablePath, err := os.Executable()
+	executablePath, err := s.appExecutable()
 	if err != nil {
-		return fmt.Errorf("error getting executable path: %w", err)
+		return err
 	}
 
 	archiveNamePrefix := constructArchiveNamePrefix()
@@ -190,44 +282,23 @@ func (s *UpdateServiceImpl) downloadAndUnarchiveRelease(release *github.Release)
 
 	slog.Info("Downloading and unarchiving latest release version", "asset", appAsset)
 
-	request, err := http.NewRequest(http.MethodGet, appAsset.URL, nil)
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-
-	request.Header.Set("Accept", "application/octet-stream")
-
-	response, err := s.downloadClient.Do(request)
-	if err != nil {
-		return fmt.Errorf("error downloading asset: %w", err)
-	}
-
-	defer response.Body.Close()
+	rootDir := filepath.Dir(executablePath)
 
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download asset: server returned status %d: %s", response.StatusCode, response.Status)
+	// The asset ID is part of the name so a partial download is never resumed against another release
+	partialPath := filepath.Join(rootDir, fmt.Sprintf("%s.%d%s", appAsset.Name, appAsset.ID, partialFileSuffix))
+	if err := downloadAsset(ctx, s.downloadClient, appAsset.URL, partialPath, appAsset.Size, onProgress); err != nil {
+		return err
 	}
 
-	rootDir := filepath.Dir(executablePath)
-	file, err := os.Create(filepath.Join(rootDir, appAsset.Name))
+	file, err := os.Open(partialPath)
 	if err != nil {
-		return fmt.Errorf("error creating file: %w", err)
+		return fmt.Errorf("error opening downloaded asset: %w", err)
 	}
 
+	defer os.Remove(partialPath)
 	defer file.Close()
-	defer os.Remove(file.Name())
-
-	written, err := io.Copy(file, response.Body)
-
-	if err != nil {
-		return fmt.Errorf("error copying asset: %w", err)
-	}
-
-	if written != appAsset.Size {
-		return fmt.Errorf("downloaded asset size mismatch: expected %d, got %d", appAsset.Size, written)
-	}
 
-	zipReader, err := zip.NewReader(file, written)
+	zipReader, err := zip.NewReader(file, appAsset.Size)
 	if err != nil {
 		return fmt.Errorf("error creating zip reader: %w", err)
 	}
@@ -3,10 +3,16 @@ package update
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"d2tool/github"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestExtractFileFromArchive_PathTraversal(t *testing.T) {
@@ -186,3 +192,139 @@ func createTestZipDirectory(t *testing.T, name string) *zip.File {
 
 	return r.File[0]
 }
+
+func TestRestartApp_NoInstalledUpdate(t *testing.T) {
+	service := NewUpdateService("1.0.0", "", nil, nil)
+
+	if service.GetState().UpdateInstalled {
+		t.Error("expected no installed update on a fresh service")
+	}
+
+	if err := service.RestartApp(); err == nil {
+		t.Error("expected error when restarting without an installed update")
+	}
+}
+
+func TestGetState_UpdatedFromVersion(t *testing.T) {
+	service := NewUpdateService("1.1.0", "1.0.0", []string{"-minimized=true"}, nil)
+
+	state := service.GetState()
+	if state.UpdatedFromVersion != "1.0.0" {
+		t.Errorf("expected updated-from version '1.0.0', got %q", state.UpdatedFromVersion)
+	}
+	if state.CurrentAppVersion != "1.1.0" {
+		t.Errorf("expected current version '1.1.0', got %q", state.CurrentAppVersion)
+	}
+}
+
+func TestRestartApp_CommandAfterInstall(t *testing.T) {
+	rootDir := t.TempDir()
+	executablePath := filepath.Join(rootDir, "d2tool")
+	if err := os.WriteFile(executablePath, []byte("old version"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := new(bytes.Buffer)
+	w := zip.NewWriter(archive)
+	f, err := w.Create("d2tool")
+	if err != nil {
+		t.Fatalf("failed to create zip entry: %v", err)
+	}
+	if _, err := f.Write([]byte("new version")); err != nil {
+		t.Fatalf("failed to write zip content: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "asset.zip", time.Time{}, bytes.NewReader(archive.Bytes()))
+	}))
+	defer server.Close()
+
+	service := NewUpdateService("1.0.0", "", []string{"-minimized=true"}, nil)
+	service.executablePath = executablePath
+	service.downloadClient = server.Client()
+	service.latestRelease = &github.Release{
+		Name:    "1.1.0",
+		TagName: "v1.1.0",
+		Assets: []github.ReleaseAsset{{
+			ID:   1,
+			Name: constructArchiveNamePrefix() + ".zip",
+			URL:  server.URL,
+			Size: int64(archive.Len()),
+		}},
+	}
+
+	if err := service.UpdateApp(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error installing update: %v", err)
+	}
+	if !service.GetState().UpdateInstalled {
+		t.Fatal("expected the update to be installed")
+	}
+
+	path, args, err := service.restartCommand("/tmp/restart.ready")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != executablePath {
+		t.Errorf("expected restart into %q, got %q", executablePath, path)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "new version" {
+		t.Errorf("expected the restart executable to be the new version, got %q", string(content))
+	}
+
+	expectedArgs := []string{
+		"-minimized=true",
+		fmt.Sprintf("-%s=%d", RestartWaitPIDFlag, os.Getpid()),
+		fmt.Sprintf("-%s=1.0.0", UpdatedFromFlag),
+		fmt.Sprintf("-%s=/tmp/restart.ready", RestartReadyFileFlag),
+	}
+	if !slices.Equal(args, expectedArgs) {
+		t.Errorf("expected args %v, got %v", expectedArgs, args)
+	}
+}
+
+func TestWaitForRestartReady(t *testing.T) {
+	tests := []struct {
+		name        string
+		confirm     bool // the new process creates the ready file
+		exit        bool // the new process exits
+		expectError bool
+	}{
+		{name: "confirmed", confirm: true},
+		{name: "confirmed before exiting", confirm: true, exit: true},
+		{name: "exited during startup", exit: true, expectError: true},
+		{name: "no confirmation", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readyPath := filepath.Join(t.TempDir(), "restart.ready")
+			if tt.confirm {
+				ConfirmRestart(readyPath)
+			}
+			exited := make(chan error, 1)
+			if tt.exit {
+				exited <- fmt.Errorf("exit status 1")
+			}
+
+			err := waitForRestartReady(readyPath, exited, 300*time.Millisecond)
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestConfirmRestart_NotRestarted(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	ConfirmRestart("")
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected no file without a restart, got %d", len(entries))
+	}
+}